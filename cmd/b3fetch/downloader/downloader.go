@@ -0,0 +1,176 @@
+package downloader
+
+import (
+	"archive/zip"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+const (
+	fileNameLayout = "02-01-2006"
+	fileNameSuffix = "_NEGOCIOSAVISTA.zip"
+	partialSuffix  = ".part"
+	filePerm       = 0o644
+)
+
+var (
+	ErrNoSession      = errors.New("no trading session")
+	ErrInvalidArchive = errors.New("invalid trades archive")
+)
+
+type Downloader struct {
+	client  *http.Client
+	baseURL string
+	dir     string
+	logger  *zap.Logger
+}
+
+func NewDownloader(client *http.Client, baseURL, dir string, logger *zap.Logger) *Downloader {
+	return &Downloader{
+		client:  client,
+		baseURL: strings.TrimRight(baseURL, "/"),
+		dir:     dir,
+		logger:  logger,
+	}
+}
+
+func TradingDays(from, to time.Time) []time.Time {
+	var days []time.Time
+	for day := from; !day.After(to); day = day.AddDate(0, 0, 1) {
+		if day.Weekday() == time.Saturday || day.Weekday() == time.Sunday {
+			continue
+		}
+		days = append(days, day)
+	}
+
+	return days
+}
+
+func FileName(day time.Time) string {
+	return day.Format(fileNameLayout) + fileNameSuffix
+}
+
+func (d *Downloader) Download(ctx context.Context, day time.Time) (string, error) {
+	target := filepath.Join(d.dir, FileName(day))
+	if _, err := os.Stat(target); err == nil {
+		d.logger.Info("File already downloaded, skipping", zap.String("file", target))
+
+		return target, nil
+	}
+
+	partial := target + partialSuffix
+	if err := d.fetch(ctx, day, partial); err != nil {
+		return "", err
+	}
+
+	if err := VerifyArchive(partial); err != nil {
+		if rmErr := os.Remove(partial); rmErr != nil {
+			d.logger.Error("Error removing invalid archive", zap.String("file", partial), zap.Error(rmErr))
+		}
+
+		return "", errors.Wrap(err, "verify")
+	}
+
+	if err := os.Rename(partial, target); err != nil {
+		return "", errors.Wrap(err, "rename")
+	}
+
+	return target, nil
+}
+
+func (d *Downloader) fetch(ctx context.Context, day time.Time, partial string) error {
+	var offset int64
+	if info, err := os.Stat(partial); err == nil {
+		offset = info.Size()
+	}
+
+	url := fmt.Sprintf("%s/%s", d.baseURL, day.Format(time.DateOnly))
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return errors.Wrap(err, "new request")
+	}
+	if offset > 0 {
+		req.Header.Set("Range", fmt.Sprintf("bytes=%d-", offset))
+		d.logger.Info("Resuming partial download", zap.String("url", url), zap.Int64("offset", offset))
+	}
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "do request")
+	}
+	defer resp.Body.Close()
+
+	flags := os.O_CREATE | os.O_WRONLY
+	switch resp.StatusCode {
+	case http.StatusOK:
+		flags |= os.O_TRUNC
+	case http.StatusPartialContent:
+		flags |= os.O_APPEND
+	case http.StatusRequestedRangeNotSatisfiable:
+		return nil
+	case http.StatusNotFound:
+		return ErrNoSession
+	default:
+		return errors.Errorf("unexpected status %d from %s", resp.StatusCode, url)
+	}
+
+	file, err := os.OpenFile(partial, flags, filePerm)
+	if err != nil {
+		return errors.Wrap(err, "open partial file")
+	}
+	defer file.Close()
+
+	if _, err := io.Copy(file, resp.Body); err != nil {
+		return errors.Wrap(err, "write partial file")
+	}
+
+	return nil
+}
+
+func VerifyArchive(path string) error {
+	archive, err := zip.OpenReader(path)
+	if err != nil {
+		return errors.Wrap(ErrInvalidArchive, err.Error())
+	}
+	defer archive.Close()
+
+	found := false
+	for _, entry := range archive.File {
+		if !strings.EqualFold(filepath.Ext(entry.Name), ".txt") {
+			continue
+		}
+		if err := verifyEntry(entry); err != nil {
+			return errors.Wrap(ErrInvalidArchive, err.Error())
+		}
+		found = true
+	}
+
+	if !found {
+		return errors.Wrap(ErrInvalidArchive, "no .txt entry")
+	}
+
+	return nil
+}
+
+func verifyEntry(entry *zip.File) error {
+	stream, err := entry.Open()
+	if err != nil {
+		return errors.Wrapf(err, "open %s", entry.Name)
+	}
+	defer stream.Close()
+
+	if _, err := io.Copy(io.Discard, stream); err != nil {
+		return errors.Wrapf(err, "read %s", entry.Name)
+	}
+
+	return nil
+}
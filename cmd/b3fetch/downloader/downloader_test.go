@@ -0,0 +1,162 @@
+package downloader
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func buildArchive(t *testing.T) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	writer := zip.NewWriter(&buf)
+	entry, err := writer.Create("02-06-2025_NEGOCIOSAVISTA.txt")
+	require.NoError(t, err)
+	_, err = entry.Write([]byte("DataReferencia;CodigoInstrumento\n2025-06-02;TF583R\n"))
+	require.NoError(t, err)
+	require.NoError(t, writer.Close())
+
+	return buf.Bytes()
+}
+
+func TestTradingDays(t *testing.T) {
+	from := time.Date(2025, 6, 6, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 6, 10, 0, 0, 0, 0, time.UTC)
+
+	days := TradingDays(from, to)
+
+	assert.Equal(t, []time.Time{
+		time.Date(2025, 6, 6, 0, 0, 0, 0, time.UTC),
+		time.Date(2025, 6, 9, 0, 0, 0, 0, time.UTC),
+		time.Date(2025, 6, 10, 0, 0, 0, 0, time.UTC),
+	}, days)
+}
+
+func TestFileName(t *testing.T) {
+	day := time.Date(2025, 6, 2, 0, 0, 0, 0, time.UTC)
+	assert.Equal(t, "02-06-2025_NEGOCIOSAVISTA.zip", FileName(day))
+}
+
+func TestDownloader_Download(t *testing.T) {
+	archive := buildArchive(t)
+	day := time.Date(2025, 6, 2, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		handler http.HandlerFunc
+		partial []byte
+		wantErr error
+	}{
+		{
+			name: "full download",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/2025-06-02", r.URL.Path)
+				_, _ = w.Write(archive)
+			},
+		},
+		{
+			name:    "resumes partial download",
+			partial: archive[:10],
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "bytes=10-", r.Header.Get("Range"))
+				w.Header().Set("Content-Range", fmt.Sprintf("bytes 10-%d/%d", len(archive)-1, len(archive)))
+				w.WriteHeader(http.StatusPartialContent)
+				_, _ = w.Write(archive[10:])
+			},
+		},
+		{
+			name: "holiday without session",
+			handler: func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusNotFound)
+			},
+			wantErr: ErrNoSession,
+		},
+		{
+			name: "corrupted archive",
+			handler: func(w http.ResponseWriter, _ *http.Request) {
+				_, _ = w.Write([]byte("not a zip"))
+			},
+			wantErr: ErrInvalidArchive,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(tt.handler)
+			defer server.Close()
+
+			dir := t.TempDir()
+			target := filepath.Join(dir, FileName(day))
+			if tt.partial != nil {
+				require.NoError(t, os.WriteFile(target+partialSuffix, tt.partial, filePerm))
+			}
+
+			d := NewDownloader(server.Client(), server.URL+"/", dir, zap.NewNop())
+			path, err := d.Download(context.Background(), day)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.NoFileExists(t, target)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, target, path)
+			got, err := os.ReadFile(path)
+			require.NoError(t, err)
+			assert.Equal(t, archive, got)
+			assert.NoFileExists(t, target+partialSuffix)
+		})
+	}
+}
+
+func TestDownloader_Download_AlreadyDownloaded(t *testing.T) {
+	day := time.Date(2025, 6, 2, 0, 0, 0, 0, time.UTC)
+	dir := t.TempDir()
+	target := filepath.Join(dir, FileName(day))
+	require.NoError(t, os.WriteFile(target, []byte("existing"), filePerm))
+
+	server := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, _ *http.Request) {
+		t.Error("unexpected request for an existing file")
+	}))
+	defer server.Close()
+
+	d := NewDownloader(server.Client(), server.URL, dir, zap.NewNop())
+	path, err := d.Download(context.Background(), day)
+	assert.NoError(t, err)
+	assert.Equal(t, target, path)
+}
+
+func TestVerifyArchive(t *testing.T) {
+	dir := t.TempDir()
+
+	valid := filepath.Join(dir, "valid.zip")
+	require.NoError(t, os.WriteFile(valid, buildArchive(t), filePerm))
+	assert.NoError(t, VerifyArchive(valid))
+
+	var buf bytes.Buffer
+	writer := zip.NewWriter(&buf)
+	_, err := writer.Create("readme.md")
+	require.NoError(t, err)
+	require.NoError(t, writer.Close())
+	noTxt := filepath.Join(dir, "no-txt.zip")
+	require.NoError(t, os.WriteFile(noTxt, buf.Bytes(), filePerm))
+	assert.ErrorIs(t, VerifyArchive(noTxt), ErrInvalidArchive)
+
+	truncated := filepath.Join(dir, "truncated.zip")
+	require.NoError(t, os.WriteFile(truncated, []byte(strings.Repeat("x", 20)), filePerm))
+	assert.ErrorIs(t, VerifyArchive(truncated), ErrInvalidArchive)
+}
@@ -0,0 +1,89 @@
+package main
+
+import (
+	"b3challenge/cmd/b3fetch/downloader"
+	"b3challenge/config"
+	"context"
+	"flag"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+func main() {
+	logger, err := setupLogger()
+	if err != nil {
+		panic("failed to create logger: " + err.Error())
+	}
+	defer logger.Sync() //nolint:errcheck
+
+	if err := config.LoadConfig(); err != nil {
+		logger.Fatal("Error loading env configs:", zap.Error(err))
+	}
+
+	today := time.Now().Format(time.DateOnly)
+	fromFlag := flag.String("from", today, "first trade date to download (YYYY-MM-DD)")
+	toFlag := flag.String("to", today, "last trade date to download (YYYY-MM-DD)")
+	dirFlag := flag.String("dir", "b3Data", "directory where the files are saved")
+	baseURLFlag := flag.String("base-url", config.GetB3BaseURL(), "base URL of the B3 trades endpoint")
+	flag.Parse()
+
+	from, err := time.Parse(time.DateOnly, *fromFlag)
+	if err != nil {
+		logger.Fatal("Invalid -from date", zap.Error(err))
+	}
+	to, err := time.Parse(time.DateOnly, *toFlag)
+	if err != nil {
+		logger.Fatal("Invalid -to date", zap.Error(err))
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	const requestTimeout = 10 * time.Minute
+	client := &http.Client{Timeout: requestTimeout} //nolint:exhaustruct
+	dl := downloader.NewDownloader(client, *baseURLFlag, *dirFlag, logger)
+
+	failed := 0
+	for _, day := range downloader.TradingDays(from, to) {
+		path, err := dl.Download(ctx, day)
+		switch {
+		case errors.Is(err, downloader.ErrNoSession):
+			logger.Info("No trading session, skipping", zap.String("date", day.Format(time.DateOnly)))
+		case err != nil:
+			failed++
+			logger.Error("Error downloading trades file", zap.String("date", day.Format(time.DateOnly)), zap.Error(err))
+		default:
+			logger.Info("Trades file ready", zap.String("date", day.Format(time.DateOnly)), zap.String("file", path))
+		}
+
+		if ctx.Err() != nil {
+			logger.Info("Received shutdown signal, stopping downloads.")
+
+			break
+		}
+	}
+
+	if failed > 0 {
+		logger.Error("Some downloads failed", zap.Int("failed", failed))
+		os.Exit(1) //nolint:gocritic
+	}
+}
+
+func setupLogger() (*zap.Logger, error) {
+	cfg := zap.NewDevelopmentConfig()
+	cfg.EncoderConfig.EncodeLevel = zapcore.CapitalColorLevelEncoder
+	cfg.EncoderConfig.EncodeTime = zapcore.ISO8601TimeEncoder
+	logger, err := cfg.Build()
+	if err != nil {
+		return nil, errors.Wrap(err, "zap")
+	}
+
+	return logger, nil
+}
@@ -0,0 +1,147 @@
+package main
+
+import (
+	"b3challenge/internal/filehandler"
+	"flag"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const defaultDataDir = "b3Data"
+
+var configFlags = map[string]string{ //nolint:gochecknoglobals
+	"dsn":            "DB_DSN",
+	"parser-workers": "PARSER_WORKER_COUNT",
+	"db-workers":     "DB_WORKER_COUNT",
+	"batch-size":     "BATCH_SIZE",
+	"chunk-size-mb":  "PARSER_CHUNK_SIZE_MB",
+	"resume":         "RESUME_INGESTION",
+	"rejects":        "REJECTS_PATH",
+	"watch-settle":   "WATCH_SETTLE_SECONDS",
+	"validation":     "VALIDATION_RULES",
+	"metrics-port":   "METRICS_PORT",
+	"max-in-flight":  "MAX_IN_FLIGHT_ROWS",
+}
+
+type stringList []string
+
+func (l *stringList) String() string {
+	return strings.Join(*l, ",")
+}
+
+func (l *stringList) Set(value string) error {
+	*l = append(*l, value)
+
+	return nil
+}
+
+type options struct {
+	inputs       []string
+	include      []string
+	exclude      []string
+	dates        filehandler.DateRange
+	format       filehandler.TradeFormat
+	dryRun       bool
+	truncateDate time.Time
+	watch        bool
+	overrides    map[string]string
+}
+
+func parseOptions(args []string, output io.Writer) (options, error) {
+	fs := flag.NewFlagSet("dbpopulate", flag.ContinueOnError)
+	fs.SetOutput(output)
+
+	var inputs, include, exclude stringList
+	fs.Var(&inputs, "input", "input directory or file, repeatable (default "+defaultDataDir+")")
+	fs.Var(&include, "include", "only load files whose name matches this glob, repeatable")
+	fs.Var(&exclude, "exclude", "skip files whose name matches this glob, repeatable")
+	fromFlag := fs.String("from", "", "only load trades on or after this trade date (YYYY-MM-DD)")
+	toFlag := fs.String("to", "", "only load trades on or before this trade date (YYYY-MM-DD)")
+	truncateFlag := fs.String("truncate-date", "", "delete the trades of this date (YYYY-MM-DD) and reload it")
+	formatFlag := fs.String("format", "auto", "trade input format: auto (by extension), csv, jsonl or parquet")
+	dryRun := fs.Bool("dry-run", false, "parse and validate the inputs without touching the database")
+	watch := fs.Bool("watch", false, "keep running and load new files as they land in the input directories")
+
+	fs.String("dsn", "", "database DSN (overrides DB_DSN)")
+	fs.Int("parser-workers", 0, "parser workers (overrides PARSER_WORKER_COUNT)")
+	fs.Int("db-workers", 0, "database workers (overrides DB_WORKER_COUNT)")
+	fs.Int("batch-size", 0, "trades per database batch (overrides BATCH_SIZE)")
+	fs.Int64("chunk-size-mb", 0, "split .txt files larger than this for parallel parsing (overrides PARSER_CHUNK_SIZE_MB)")
+	fs.Bool("resume", false, "continue interrupted files from their checkpoint (overrides RESUME_INGESTION)")
+	fs.String("rejects", "", "rejects output path (overrides REJECTS_PATH)")
+	fs.Int("watch-settle", 0, "seconds a new file must stay unchanged before loading it (overrides WATCH_SETTLE_SECONDS)")
+	fs.String("validation", "", "validation rule actions, e.g. date_mismatch=abort (overrides VALIDATION_RULES)")
+	fs.Uint("metrics-port", 0, "serve Prometheus metrics on this port (overrides METRICS_PORT)")
+	fs.Int64("max-in-flight", 0, "rows allowed between parsers and database writes (overrides MAX_IN_FLIGHT_ROWS)")
+
+	if err := fs.Parse(args); err != nil {
+		return options{}, errors.Wrap(err, "parse flags")
+	}
+
+	opts := options{
+		inputs:       inputs,
+		include:      include,
+		exclude:      exclude,
+		dates:        filehandler.DateRange{},
+		format:       filehandler.TradeFormatAuto,
+		dryRun:       *dryRun,
+		truncateDate: time.Time{},
+		watch:        *watch,
+		overrides:    make(map[string]string),
+	}
+	opts.inputs = append(opts.inputs, fs.Args()...)
+	if len(opts.inputs) == 0 {
+		opts.inputs = []string{defaultDataDir}
+	}
+
+	fs.Visit(func(f *flag.Flag) {
+		if key, ok := configFlags[f.Name]; ok {
+			opts.overrides[key] = f.Value.String()
+		}
+	})
+
+	var err error
+	if opts.format, err = filehandler.ParseTradeFormat(*formatFlag); err != nil {
+		return options{}, errors.Wrap(err, "invalid -format")
+	}
+	if opts.dates.From, err = parseDateFlag("from", *fromFlag); err != nil {
+		return options{}, err
+	}
+	if opts.dates.To, err = parseDateFlag("to", *toFlag); err != nil {
+		return options{}, err
+	}
+	if opts.truncateDate, err = parseDateFlag("truncate-date", *truncateFlag); err != nil {
+		return options{}, err
+	}
+
+	if !opts.truncateDate.IsZero() && opts.dates.IsZero() {
+		opts.dates = filehandler.DateRange{From: opts.truncateDate, To: opts.truncateDate}
+	}
+	if !opts.dates.From.IsZero() && !opts.dates.To.IsZero() && opts.dates.From.After(opts.dates.To) {
+		return options{}, errors.New("-from must not be after -to")
+	}
+	if !opts.truncateDate.IsZero() && !opts.dates.Contains(opts.truncateDate) {
+		return options{}, errors.New("-truncate-date must be inside the -from/-to range")
+	}
+	if opts.watch && !opts.truncateDate.IsZero() {
+		return options{}, errors.New("-truncate-date cannot be used with -watch")
+	}
+
+	return opts, nil
+}
+
+func parseDateFlag(name, value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+
+	date, err := time.Parse(time.DateOnly, value)
+	if err != nil {
+		return time.Time{}, errors.Wrapf(err, "invalid -%s date", name)
+	}
+
+	return date, nil
+}
@@ -0,0 +1,144 @@
+package main
+
+import (
+	"b3challenge/internal/filehandler"
+	"flag"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseOptions(t *testing.T) {
+	day := time.Date(2025, 6, 2, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		args    []string
+		want    options
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name: "defaults",
+			args: nil,
+			want: options{
+				inputs:    []string{defaultDataDir},
+				overrides: map[string]string{},
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "inputs, globs and config overrides",
+			args: []string{
+				"-input", "a", "--input=b", "-include", "*.zip", "-exclude", "*_old*",
+				"-batch-size", "1000", "-resume", "-dry-run", "c.txt",
+			},
+			want: options{
+				inputs:    []string{"a", "b", "c.txt"},
+				include:   []string{"*.zip"},
+				exclude:   []string{"*_old*"},
+				dryRun:    true,
+				overrides: map[string]string{"BATCH_SIZE": "1000", "RESUME_INGESTION": "true"},
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "date range",
+			args: []string{"-from", "2025-06-02", "-to", "2025-06-03"},
+			want: options{
+				inputs:    []string{defaultDataDir},
+				dates:     filehandler.DateRange{From: day, To: day.AddDate(0, 0, 1)},
+				overrides: map[string]string{},
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "truncate date implies the date filter",
+			args: []string{"--truncate-date", "2025-06-02"},
+			want: options{
+				inputs:       []string{defaultDataDir},
+				dates:        filehandler.DateRange{From: day, To: day},
+				truncateDate: day,
+				overrides:    map[string]string{},
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "watch mode",
+			args: []string{"-watch", "-watch-settle", "10"},
+			want: options{
+				inputs:    []string{defaultDataDir},
+				watch:     true,
+				overrides: map[string]string{"WATCH_SETTLE_SECONDS": "10"},
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "validation rules and in-flight budget",
+			args: []string{"-validation", "date_mismatch=abort", "-max-in-flight", "200000"},
+			want: options{
+				inputs: []string{defaultDataDir},
+				overrides: map[string]string{
+					"VALIDATION_RULES":   "date_mismatch=abort",
+					"MAX_IN_FLIGHT_ROWS": "200000",
+				},
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "input format",
+			args: []string{"-format", "parquet", "exports"},
+			want: options{
+				inputs:    []string{"exports"},
+				format:    filehandler.TradeFormatParquet,
+				overrides: map[string]string{},
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "unknown input format",
+			args:    []string{"-format", "avro"},
+			wantErr: assert.Error,
+		},
+		{
+			name:    "watch with truncate date",
+			args:    []string{"-watch", "-truncate-date", "2025-06-02"},
+			wantErr: assert.Error,
+		},
+		{
+			name:    "truncate date outside range",
+			args:    []string{"-truncate-date", "2025-06-02", "-from", "2025-06-03"},
+			wantErr: assert.Error,
+		},
+		{
+			name:    "inverted range",
+			args:    []string{"-from", "2025-06-03", "-to", "2025-06-02"},
+			wantErr: assert.Error,
+		},
+		{
+			name:    "invalid date",
+			args:    []string{"-from", "02/06/2025"},
+			wantErr: assert.Error,
+		},
+		{
+			name:    "unknown flag",
+			args:    []string{"-nope"},
+			wantErr: assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseOptions(tt.args, io.Discard)
+			if !tt.wantErr(t, err) {
+				return
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestParseOptions_Help(t *testing.T) {
+	_, err := parseOptions([]string{"-h"}, io.Discard)
+	assert.ErrorIs(t, err, flag.ErrHelp)
+}
@@ -0,0 +1,495 @@
+package main
+
+import (
+	"b3challenge/internal/domain/entity"
+	"b3challenge/internal/domain/usecase"
+	"b3challenge/internal/filehandler"
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+const fileStatusSkipped = "skipped"
+
+type segmentState struct {
+	lastLine  int64
+	parsed    bool
+	records   int64
+	committed int64
+	watermark int64
+	nextSeq   int
+	pending   map[int]int64
+}
+
+func newSegmentState(segment filehandler.Segment) *segmentState {
+	return &segmentState{
+		lastLine:  segment.LastLine,
+		parsed:    false,
+		records:   0,
+		committed: 0,
+		watermark: 0,
+		nextSeq:   0,
+		pending:   make(map[int]int64),
+	}
+}
+
+func (s *segmentState) advance(batch *filehandler.Batch) {
+	s.committed += int64(batch.Len())
+	s.pending[batch.Seq] = batch.LastLine
+	for {
+		line, ok := s.pending[s.nextSeq]
+		if !ok {
+			return
+		}
+		delete(s.pending, s.nextSeq)
+		s.nextSeq++
+		s.watermark = line
+	}
+}
+
+func (s *segmentState) isComplete() bool {
+	return s.parsed && s.committed == s.records
+}
+
+type ledgerEntry struct {
+	ingestion     *entity.Ingestion
+	rowsParsed    int64
+	rowsRejected  int64
+	rowsFiltered  int64
+	rowsInserted  int64
+	parseErr      error
+	promoteErr    error
+	failedBatches int
+	segments      []*segmentState
+	checkpoint    int64
+}
+
+func newLedgerEntry(ingestion *entity.Ingestion, segments []filehandler.Segment, fromLine int64) *ledgerEntry {
+	entry := &ledgerEntry{
+		ingestion:     ingestion,
+		rowsParsed:    0,
+		rowsRejected:  0,
+		rowsFiltered:  0,
+		rowsInserted:  0,
+		parseErr:      nil,
+		promoteErr:    nil,
+		failedBatches: 0,
+		segments:      make([]*segmentState, len(segments)),
+		checkpoint:    fromLine,
+	}
+	for i, segment := range segments {
+		entry.segments[i] = newSegmentState(segment)
+	}
+
+	return entry
+}
+
+func (e *ledgerEntry) advanceCheckpoint() (int64, bool) {
+	var checkpoint int64
+	for _, segment := range e.segments {
+		checkpoint = max(checkpoint, segment.watermark)
+		if !segment.isComplete() {
+			break
+		}
+		checkpoint = max(checkpoint, segment.lastLine)
+	}
+
+	if checkpoint <= e.checkpoint {
+		return e.checkpoint, false
+	}
+	e.checkpoint = checkpoint
+
+	return checkpoint, true
+}
+
+func (e *ledgerEntry) status(interrupted, filtered bool) entity.IngestionStatus {
+	switch {
+	case interrupted || e.parseErr != nil || e.promoteErr != nil || e.failedBatches > 0:
+		return entity.IngestionStatusFailed
+	case filtered:
+		return entity.IngestionStatusPartial
+	default:
+		return entity.IngestionStatusSucceeded
+	}
+}
+
+type ledgerOptions struct {
+	resume    bool
+	force     bool
+	filtered  bool
+	chunkSize int64
+	workers   int
+}
+
+// fileLedger tracks the ingestion of each file. When trades is set, trade batches are staged per
+// ingestion and only promoted into the trades table once the whole file went through.
+type fileLedger struct {
+	uc      *usecase.IngestionsUC
+	trades  *usecase.TradesUC
+	opts    ledgerOptions
+	logger  *zap.Logger
+	mu      sync.Mutex
+	entries map[string]*ledgerEntry
+	skipped []string
+}
+
+func newFileLedger(
+	uc *usecase.IngestionsUC,
+	trades *usecase.TradesUC,
+	opts ledgerOptions,
+	logger *zap.Logger,
+) *fileLedger {
+	return &fileLedger{
+		uc:      uc,
+		trades:  trades,
+		opts:    opts,
+		logger:  logger,
+		mu:      sync.Mutex{},
+		entries: make(map[string]*ledgerEntry),
+		skipped: nil,
+	}
+}
+
+func (l *fileLedger) Start(ctx context.Context, files []string) []parseJob {
+	scans := l.scan(ctx, files)
+
+	var jobs []parseJob
+	for n, file := range files {
+		scan := scans[n]
+		if scan.err != nil {
+			l.logger.Error("Error reading file, skipping it", zap.String("file", file), zap.Error(scan.err))
+			entry := newLedgerEntry(nil, nil, 0)
+			entry.parseErr = scan.err
+			l.entries[file] = entry
+
+			continue
+		}
+
+		ingestion, fromLine, skip, err := l.open(ctx, file, scan.scan)
+		if err != nil {
+			entry := newLedgerEntry(nil, nil, 0)
+			entry.parseErr = err
+			l.entries[file] = entry
+
+			continue
+		}
+		if skip {
+			l.skipped = append(l.skipped, file)
+
+			continue
+		}
+
+		segments := scan.scan.Segments
+		entry := newLedgerEntry(ingestion, segments, fromLine)
+		l.entries[file] = entry
+		for i, segment := range segments {
+			if segment.LastLine > 0 && segment.LastLine <= fromLine {
+				entry.segments[i].parsed = true
+
+				continue
+			}
+			jobs = append(jobs, parseJob{file: file, segment: segment, fromLine: fromLine})
+		}
+		if len(segments) > 1 {
+			l.logger.Info("Splitting file for parallel parsing", zap.String("file", file), zap.Int("segments", len(segments)))
+		}
+	}
+
+	return jobs
+}
+
+type fileScan struct {
+	scan filehandler.FileScan
+	err  error
+}
+
+// scan reads each file once for its checksum and segments before parsing starts. Files are scanned
+// concurrently, up to opts.workers at a time, so large inputs do not wait on each other.
+func (l *fileLedger) scan(ctx context.Context, files []string) []fileScan {
+	scans := make([]fileScan, len(files))
+	indexes := make(chan int)
+
+	var wg sync.WaitGroup
+	for range max(l.opts.workers, 1) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				scan, err := filehandler.ScanFile(files[i], l.opts.chunkSize)
+				scans[i] = fileScan{scan: scan, err: errors.Wrap(err, "scan")}
+			}
+		}()
+	}
+
+	for i := range files {
+		if ctx.Err() != nil {
+			scans[i].err = errors.Wrap(ctx.Err(), "scan interrupted")
+
+			continue
+		}
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+
+	return scans
+}
+
+func (l *fileLedger) open(
+	ctx context.Context,
+	file string,
+	scan filehandler.FileScan,
+) (*entity.Ingestion, int64, bool, error) {
+	if l.uc == nil {
+		return nil, 0, false, nil
+	}
+
+	checksum, size := scan.Checksum, scan.Size
+	if !l.opts.force {
+		ingested, err := l.uc.IsIngested(ctx, file, checksum)
+		if err != nil {
+			l.logger.Error("Error checking ingestion ledger", zap.String("file", file), zap.Error(err))
+		}
+		if ingested {
+			l.logger.Info("File already ingested with same checksum, skipping", zap.String("file", file))
+
+			return nil, 0, true, nil
+		}
+	}
+
+	var fromLine int64
+	if l.opts.resume && !l.opts.force {
+		var err error
+		fromLine, err = l.uc.GetResumeCheckpoint(ctx, file, checksum)
+		if err != nil {
+			l.logger.Error("Error reading resume checkpoint", zap.String("file", file), zap.Error(err))
+		}
+		if fromLine > 0 {
+			l.logger.Info("Resuming file from checkpoint", zap.String("file", file), zap.Int64("line", fromLine))
+		}
+	}
+
+	ingestion, err := l.uc.StartIngestion(ctx, file, size, checksum, fromLine)
+	if err != nil {
+		l.logger.Error("Error recording ingestion start", zap.String("file", file), zap.Error(err))
+		if l.trades != nil {
+			return nil, 0, false, errors.Wrap(err, "start ingestion")
+		}
+	}
+
+	if l.trades != nil {
+		kept, err := l.trades.ResumeStagedTrades(ctx, ingestion)
+		if err != nil {
+			l.logger.Error("Error preparing trades staging", zap.String("file", file), zap.Error(err))
+
+			return nil, 0, false, errors.Wrap(err, "prepare staging")
+		}
+		if kept > 0 {
+			l.logger.Info("Kept trades staged by previous run", zap.String("file", file), zap.Int("rows", kept))
+		}
+	}
+
+	return ingestion, fromLine, false, nil
+}
+
+func (l *fileLedger) StageTrades(
+	ctx context.Context,
+	source string,
+	trades []entity.Trade,
+	lines []int64,
+) (int, int, error) {
+	l.mu.Lock()
+	entry, ok := l.entries[source]
+	l.mu.Unlock()
+	if !ok || entry.ingestion == nil {
+		return 0, 0, errors.Errorf("no ingestion to stage %s into", source)
+	}
+
+	staged, cancelled, err := l.trades.StageTrades(ctx, entry.ingestion.ID, trades, lines)
+	if err != nil {
+		return 0, 0, errors.Wrap(err, "stage trades")
+	}
+
+	return staged, cancelled, nil
+}
+
+func (l *fileLedger) RecordParse(ctx context.Context, job parseJob, stats filehandler.ParseStats, err error) {
+	l.mu.Lock()
+	entry, ok := l.entries[job.file]
+	if !ok {
+		l.mu.Unlock()
+
+		return
+	}
+	entry.rowsParsed += stats.Parsed
+	entry.rowsRejected += stats.Rejected
+	entry.rowsFiltered += stats.Filtered
+	if err != nil {
+		entry.parseErr = err
+		l.mu.Unlock()
+
+		return
+	}
+	segment := entry.segments[job.segment.Index]
+	segment.parsed = true
+	segment.records = stats.Parsed
+	checkpoint, advanced := entry.advanceCheckpoint()
+	l.mu.Unlock()
+
+	if advanced {
+		l.saveCheckpoint(ctx, job.file, entry.ingestion, checkpoint)
+	}
+}
+
+func (l *fileLedger) RecordCommit(ctx context.Context, batch *filehandler.Batch, inserted, rejected int) {
+	l.mu.Lock()
+	entry, ok := l.entries[batch.Source]
+	if !ok {
+		l.mu.Unlock()
+
+		return
+	}
+	if l.trades == nil || len(batch.Trades) == 0 {
+		entry.rowsInserted += int64(inserted)
+	}
+	entry.rowsRejected += int64(rejected)
+	entry.segments[batch.Segment].advance(batch)
+	checkpoint, advanced := entry.advanceCheckpoint()
+	l.mu.Unlock()
+
+	if advanced {
+		l.saveCheckpoint(ctx, batch.Source, entry.ingestion, checkpoint)
+	}
+}
+
+func (l *fileLedger) saveCheckpoint(ctx context.Context, file string, ingestion *entity.Ingestion, checkpoint int64) {
+	if ingestion == nil || l.opts.filtered {
+		return
+	}
+
+	if err := l.uc.UpdateCheckpoint(ctx, ingestion, checkpoint); err != nil {
+		l.logger.Error("Error saving ingestion checkpoint", zap.String("file", file), zap.Error(err))
+	}
+}
+
+func (l *fileLedger) RecordFailedBatch(batch *filehandler.Batch) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	entry, ok := l.entries[batch.Source]
+	if !ok {
+		return
+	}
+	entry.failedBatches++
+}
+
+// Promote moves the trades staged for every file that went through into the trades table, one
+// transaction per file. Staged rows of failed files are dropped unless a later run can resume from
+// their checkpoint.
+func (l *fileLedger) Promote(ctx context.Context) {
+	if l.trades == nil {
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	interrupted := ctx.Err() != nil
+	ctx = context.WithoutCancel(ctx)
+
+	for file, entry := range l.entries {
+		if entry.ingestion == nil {
+			continue
+		}
+		if entry.status(interrupted, l.opts.filtered) == entity.IngestionStatusFailed {
+			l.discardStaged(ctx, file, entry)
+
+			continue
+		}
+
+		created, cancelled, err := l.trades.PromoteStagedTrades(ctx, entry.ingestion.ID)
+		if err != nil {
+			entry.promoteErr = err
+			l.logger.Error("Error promoting staged trades", zap.String("file", file), zap.Error(err))
+
+			continue
+		}
+		entry.rowsInserted += int64(created + cancelled)
+
+		l.logger.Info(
+			"Promoted staged trades",
+			zap.String("file", file),
+			zap.Int("trades_inserted", created),
+			zap.Int("cancellations_inserted", cancelled),
+		)
+	}
+}
+
+func (l *fileLedger) discardStaged(ctx context.Context, file string, entry *ledgerEntry) {
+	if entry.checkpoint > 0 && !l.opts.filtered {
+		l.logger.Info(
+			"Keeping staged trades of failed file for resume",
+			zap.String("file", file),
+			zap.Int64("checkpoint", entry.checkpoint),
+		)
+
+		return
+	}
+
+	removed, err := l.trades.DiscardStagedTrades(ctx, entry.ingestion.ID)
+	if err != nil {
+		l.logger.Error("Error discarding staged trades", zap.String("file", file), zap.Error(err))
+
+		return
+	}
+	l.logger.Info("Discarded staged trades of failed file", zap.String("file", file), zap.Int("rows", removed))
+}
+
+func (l *fileLedger) Finish(ctx context.Context) []fileReport {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	interrupted := ctx.Err() != nil
+	ctx = context.WithoutCancel(ctx)
+
+	reports := make([]fileReport, 0, len(l.entries)+len(l.skipped))
+	for file, entry := range l.entries {
+		status := entry.status(interrupted, l.opts.filtered)
+		reports = append(reports, newFileReport(file, string(status), entry))
+
+		if entry.ingestion == nil {
+			continue
+		}
+
+		entry.ingestion.RowsParsed = entry.rowsParsed
+		entry.ingestion.RowsRejected = entry.rowsRejected
+		entry.ingestion.RowsInserted = entry.rowsInserted
+		if err := l.uc.FinishIngestion(ctx, entry.ingestion, status); err != nil {
+			l.logger.Error("Error recording ingestion finish", zap.String("file", file), zap.Error(err))
+
+			continue
+		}
+
+		l.logger.Info(
+			"Ingestion recorded",
+			zap.String("file", file),
+			zap.String("status", string(status)),
+			zap.Int64("rows_parsed", entry.rowsParsed),
+			zap.Int64("rows_rejected", entry.rowsRejected),
+			zap.Int64("rows_inserted", entry.rowsInserted),
+		)
+	}
+
+	for _, file := range l.skipped {
+		reports = append(reports, newFileReport(file, fileStatusSkipped, newLedgerEntry(nil, nil, 0)))
+	}
+
+	sort.Slice(reports, func(i, j int) bool {
+		return reports[i].Path < reports[j].Path
+	})
+
+	return reports
+}
@@ -0,0 +1,132 @@
+package main
+
+import (
+	"b3challenge/internal/domain/entity"
+	"b3challenge/internal/domain/usecase"
+	"b3challenge/internal/filehandler"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+)
+
+func TestLedgerEntry_advanceCheckpoint(t *testing.T) {
+	segments := []filehandler.Segment{
+		{Index: 0, Start: 0, End: 100, FirstLine: 1, LastLine: 10},
+		{Index: 1, Start: 100, End: 200, FirstLine: 11, LastLine: 20},
+	}
+	batch := func(segment, seq int, lastLine int64, size int) *filehandler.Batch {
+		return &filehandler.Batch{
+			Source:   "f.txt",
+			Segment:  segment,
+			Seq:      seq,
+			LastLine: lastLine,
+			Trades:   make([]entity.Trade, size),
+			Lines:    nil,
+		}
+	}
+
+	entry := newLedgerEntry(nil, segments, 0)
+
+	entry.segments[1].advance(batch(1, 0, 15, 5))
+	_, advanced := entry.advanceCheckpoint()
+	assert.False(t, advanced, "later segment progress must not move the file checkpoint")
+
+	entry.segments[0].advance(batch(0, 1, 9, 4))
+	_, advanced = entry.advanceCheckpoint()
+	assert.False(t, advanced, "out of order batch must wait for its predecessor")
+
+	entry.segments[0].advance(batch(0, 0, 5, 4))
+	checkpoint, advanced := entry.advanceCheckpoint()
+	assert.True(t, advanced)
+	assert.Equal(t, int64(9), checkpoint)
+
+	entry.segments[0].parsed = true
+	entry.segments[0].records = 8
+	checkpoint, advanced = entry.advanceCheckpoint()
+	assert.True(t, advanced)
+	assert.Equal(t, int64(15), checkpoint, "completed segment hands over to the next one")
+
+	entry.segments[1].parsed = true
+	entry.segments[1].records = 5
+	checkpoint, advanced = entry.advanceCheckpoint()
+	assert.True(t, advanced)
+	assert.Equal(t, int64(20), checkpoint)
+}
+
+func TestLedgerEntry_advanceCheckpointResume(t *testing.T) {
+	entry := newLedgerEntry(nil, []filehandler.Segment{filehandler.WholeFile()}, 30)
+
+	entry.segments[0].advance(&filehandler.Batch{Source: "f.txt", Segment: 0, Seq: 0, LastLine: 20, Trades: nil, Lines: nil})
+	checkpoint, advanced := entry.advanceCheckpoint()
+	assert.False(t, advanced)
+	assert.Equal(t, int64(30), checkpoint)
+}
+
+func TestFileLedger_Promote(t *testing.T) {
+	repo := usecase.NewMockTradesRepository(gomock.NewController(t))
+	repo.EXPECT().PromoteStagedTrades(gomock.Any(), int32(1)).Return(int64(5), int64(1), nil)
+	repo.EXPECT().DiscardStagedTrades(gomock.Any(), int32(2)).Return(int64(3), nil)
+	repo.EXPECT().PromoteStagedTrades(gomock.Any(), int32(4)).Return(int64(0), int64(0), assert.AnError)
+
+	ledger := newFileLedger(nil, usecase.NewTradesUC(repo, nil), ledgerOptions{}, zap.NewNop()) //nolint:exhaustruct
+	segments := []filehandler.Segment{filehandler.WholeFile()}
+	entry := func(id int32) *ledgerEntry {
+		return newLedgerEntry(&entity.Ingestion{ID: id}, segments, 0) //nolint:exhaustruct
+	}
+	complete, failed, resumable, broken := entry(1), entry(2), entry(3), entry(4)
+	failed.failedBatches = 1
+	resumable.parseErr = assert.AnError
+	resumable.checkpoint = 40
+	ledger.entries = map[string]*ledgerEntry{
+		"complete.txt": complete, "failed.txt": failed, "resumable.txt": resumable, "broken.txt": broken,
+	}
+
+	ledger.RecordCommit(context.Background(), &filehandler.Batch{ //nolint:exhaustruct
+		Source: "complete.txt", Trades: make([]entity.Trade, 6),
+	}, 6, 0)
+	assert.Equal(t, int64(0), complete.rowsInserted, "staged trades are not inserted yet")
+
+	ledger.Promote(context.Background())
+
+	assert.Equal(t, int64(6), complete.rowsInserted)
+	assert.Equal(t, entity.IngestionStatusSucceeded, complete.status(false, false))
+	assert.Equal(t, entity.IngestionStatusFailed, broken.status(false, false))
+	assert.Contains(t, newFileReport("broken.txt", "failed", broken).Error, assert.AnError.Error())
+}
+
+func TestFileLedger_Start(t *testing.T) {
+	dir := t.TempDir()
+	var content strings.Builder
+	content.WriteString("DataReferencia;CodigoInstrumento\n")
+	for range 200 {
+		content.WriteString("2025-06-02;PETR4\n")
+	}
+	large := filepath.Join(dir, "large.txt")
+	require.NoError(t, os.WriteFile(large, []byte(content.String()), 0o600))
+	small := filepath.Join(dir, "small.jsonl")
+	require.NoError(t, os.WriteFile(small, []byte("{}\n"), 0o600))
+	missing := filepath.Join(dir, "missing.txt")
+
+	opts := ledgerOptions{resume: false, force: false, filtered: false, chunkSize: 1024, workers: 2}
+	ledger := newFileLedger(nil, nil, opts, zap.NewNop())
+	jobs := ledger.Start(context.Background(), []string{large, missing, small})
+
+	segments, err := filehandler.SplitFile(large, opts.chunkSize)
+	require.NoError(t, err)
+	require.Greater(t, len(segments), 1)
+
+	want := make([]parseJob, 0, len(segments)+1)
+	for _, segment := range segments {
+		want = append(want, parseJob{file: large, segment: segment, fromLine: 0})
+	}
+	want = append(want, parseJob{file: small, segment: filehandler.WholeFile(), fromLine: 0})
+	assert.Equal(t, want, jobs)
+	assert.ErrorIs(t, ledger.entries[missing].parseErr, os.ErrNotExist)
+}
@@ -1,13 +1,15 @@
 package main
 
 import (
-	"b3challenge/cmd/dbpopulate/filehandler"
 	"b3challenge/config"
 	"b3challenge/internal/adapter/db"
 	"b3challenge/internal/di"
 	"b3challenge/internal/domain/entity"
 	"b3challenge/internal/domain/usecase"
+	"b3challenge/internal/filehandler"
+	"bufio"
 	"context"
+	"flag"
 	"os"
 	"os/signal"
 	"sync"
@@ -19,59 +21,254 @@ import (
 	"go.uber.org/zap/zapcore"
 )
 
+type parseJob struct {
+	file     string
+	segment  filehandler.Segment
+	fromLine int64
+}
+
 func main() {
+	os.Exit(run())
+}
+
+func run() int {
 	logger, err := setupLogger()
 	if err != nil {
 		panic("failed to create logger: " + err.Error())
 	}
 	defer logger.Sync() //nolint:errcheck
 
-	diContainer, ctx, cancel := setupComponents(logger)
+	report := newRunReport(time.Now())
+
+	opts, err := setupOptions(os.Args[1:])
+	if errors.Is(err, flag.ErrHelp) {
+		return exitSuccess
+	}
+	if err != nil {
+		logger.Error("Error loading options", zap.Error(err))
+		report.Fail(err)
+
+		return report.Emit(os.Stdout, logger)
+	}
+	report.DryRun = opts.dryRun
+
+	ruleActions, err := filehandler.ParseRuleActions(config.GetValidationRules())
+	if err != nil {
+		logger.Error("Error loading validation rules", zap.Error(err))
+		report.Fail(err)
+
+		return report.Emit(os.Stdout, logger)
+	}
+	validator := filehandler.NewValidator(ruleActions)
+
+	diContainer, ctx, cancel, err := setupComponents(logger, opts.dryRun)
+	if err != nil {
+		logger.Error("Error setting up components", zap.Error(err))
+		report.Fail(err)
+
+		return report.Emit(os.Stdout, logger)
+	}
 	defer cancel()
-	defer diContainer.DB().Close()
 
-	parserWorkers := config.GetParserWorkersCount()
-	batchSize := config.GetBatchSize()
-	dbWorkers := config.GetDBWorkersCount()
+	var tradesUC *usecase.TradesUC
+	var ingestionsUC *usecase.IngestionsUC
+	writer := filehandler.BatchWriter{
+		Trades:       discardTrades,
+		StagedTrades: nil,
+		Quotes:       discardQuotes,
+		Instruments:  discardInstruments,
+		Events:       discardEvents,
+	}
+	if diContainer != nil {
+		defer diContainer.DB().Close()
+		tradesUC = diContainer.GetTradesUC()
+		ingestionsUC = diContainer.GetIngestionsUC()
+		writer = filehandler.BatchWriter{
+			Trades:       tradesUC.CreateTrades,
+			StagedTrades: nil,
+			Quotes:       diContainer.GetQuotesUC().CreateDailyQuotes,
+			Instruments:  diContainer.GetInstrumentsUC().UpsertInstruments,
+			Events:       diContainer.GetCorporateEventsUC().CreateCorporateEvents,
+		}
+	}
 
-	tradesCh := make(chan entity.Trade, parserWorkers)
-	jobCh := make(chan string)
-	dbCh := make(chan []entity.Trade, dbWorkers)
+	files, err := filehandler.FindInputFiles(opts.inputs, opts.include, opts.exclude)
+	if err != nil {
+		logger.Error("Error finding input files: ", zap.Error(err))
+		report.Fail(err)
 
-	start := time.Now()
-	files, err := filehandler.FindTXTFiles("b3Data")
+		return report.Emit(os.Stdout, logger)
+	}
+
+	logger.Info("Found input files: ", zap.Strings("files", files))
+
+	if err := truncateDate(ctx, tradesUC, opts.truncateDate, logger); err != nil {
+		report.Fail(err)
+
+		return report.Emit(os.Stdout, logger)
+	}
+
+	rejects, closeRejects, err := setupRejects(config.GetRejectsPath())
 	if err != nil {
-		logger.Error("Error finding TXT files: ", zap.Error(err))
+		logger.Error("Error opening rejects file, rejected rows will only be counted", zap.Error(err))
+		rejects, _ = filehandler.NewRejectWriter(nil, filehandler.RejectFormatJSONL)
 	}
+	defer closeRejects()
 
-	logger.Info("Found TXT files: ", zap.Strings("files", files))
+	batchSize := config.GetBatchSize()
+	budget := filehandler.NewRowBudget(config.GetMaxInFlightRows())
+	metrics := newPipelineMetrics(rejects, budget)
+	stopMetrics, err := serveMetrics(config.GetMetricsPort(), metrics, logger)
+	if err != nil {
+		logger.Error("Error starting metrics server", zap.Error(err))
+		report.Fail(err)
+
+		return report.Emit(os.Stdout, logger)
+	}
+	defer stopMetrics()
+
+	ingest := &ingester{
+		tradesUC:     tradesUC,
+		ingestionsUC: ingestionsUC,
+		writer:       writer,
+		retry: filehandler.RetryPolicy{
+			Attempts:      config.GetDBRetryAttempts(),
+			BaseDelay:     config.GetDBRetryBaseDelay(),
+			MaxDelay:      config.GetDBRetryMaxDelay(),
+			MaxPoisonRows: config.GetDBPoisonRowLimit(),
+			Transient:     db.IsTransientError,
+			RowError:      db.IsRowError,
+		},
+		parseOpts: filehandler.ParseOptions{
+			Pool:      filehandler.NewBudgetedBatchPool(batchSize, budget),
+			Rejects:   rejects,
+			Dates:     opts.dates,
+			Format:    opts.format,
+			Validator: validator,
+			Observer:  nil,
+		},
+		ledgerOpts: ledgerOptions{
+			resume:    config.IsResumeIngestionEnabled(),
+			force:     !opts.truncateDate.IsZero(),
+			filtered:  !opts.dates.IsZero(),
+			chunkSize: chunkSize(opts.format),
+			workers:   config.GetParserWorkersCount(),
+		},
+		parserWorkers: config.GetParserWorkersCount(),
+		dbWorkers:     config.GetDBWorkersCount(),
+		metrics:       metrics,
+		report:        report,
+		logger:        logger,
+	}
+
+	if opts.watch {
+		if err := watchInputs(ctx, opts, config.GetWatchSettle(), ingest.Run, logger); err != nil {
+			logger.Error("Error watching input directories", zap.Error(err))
+			report.Fail(err)
+		}
+	} else {
+		ingest.Run(ctx, files)
+	}
+
+	logRejects(rejects, logger)
+	report.SetRejects(rejects.Counts())
+	logViolations(validator, ruleActions, logger)
+	report.SetViolations(validator.Counts())
+	report.SetInFlight(budget.Limit(), budget.Peak())
+
+	logger.Info("Application finished.", zap.Duration(" Total processing time", time.Since(report.StartedAt)))
+
+	return report.Emit(os.Stdout, logger)
+}
+
+type ingester struct {
+	tradesUC      *usecase.TradesUC
+	ingestionsUC  *usecase.IngestionsUC
+	writer        filehandler.BatchWriter
+	retry         filehandler.RetryPolicy
+	parseOpts     filehandler.ParseOptions
+	ledgerOpts    ledgerOptions
+	parserWorkers int
+	dbWorkers     int
+	metrics       *pipelineMetrics
+	report        *runReport
+	logger        *zap.Logger
+}
+
+func (in *ingester) Run(ctx context.Context, files []string) {
+	ledger := newFileLedger(in.ingestionsUC, in.tradesUC, in.ledgerOpts, in.logger)
+	jobs := ledger.Start(ctx, files)
+
+	writer := in.writer
+	if in.tradesUC != nil {
+		writer.StagedTrades = ledger.StageTrades
+	}
+	write := filehandler.NewRetryingWriter(writer, in.retry, in.parseOpts.Rejects, in.logger)
+
+	jobCh := make(chan parseJob, len(jobs))
+	dbCh := make(chan *filehandler.Batch, in.dbWorkers)
+	in.metrics.TrackChannels(map[string]func() int{
+		"jobCh": func() int { return len(jobCh) },
+		"dbCh":  func() int { return len(dbCh) },
+	})
+	defer in.metrics.TrackChannels(nil)
 
 	var dbWg sync.WaitGroup
-	startDBWorkers(ctx, dbCh, diContainer.GetTradesUC(), dbWorkers, &dbWg, logger)
+	startDBWorkers(ctx, dbCh, in.parseOpts.Pool, write, in.dbWorkers, &dbWg, ledger, in.metrics, in.logger)
 
 	var parserWg sync.WaitGroup
-	startParserWorkers(ctx, parserWorkers, jobCh, tradesCh, &parserWg, logger)
+	startParserWorkers(ctx, in.parserWorkers, jobCh, dbCh, in.parseOpts, &parserWg, ledger, in.metrics, in.logger)
 
-	go func() {
-		for _, f := range files {
-			jobCh <- f
+	dispatchJobs(ctx, jobs, jobCh)
+
+	in.logger.Info("All jobs dispatched. Waiting for parsers to finish...")
+	parserWg.Wait()
+	close(dbCh)
+
+	in.logger.Info("All parsing workers finished, waiting for DB workers to commit last batches...")
+	dbWg.Wait()
+
+	ledger.Promote(ctx)
+	if in.tradesUC != nil {
+		if err := applyCancellations(ctx, in.tradesUC, in.logger); err != nil {
+			in.report.AddError(err)
 		}
-		close(jobCh)
+	}
+	if ctx.Err() != nil {
+		in.report.AddError(errors.Wrap(ctx.Err(), "run interrupted"))
+	}
+	in.report.AddFiles(ledger.Finish(ctx))
+
+	if err := in.parseOpts.Rejects.Flush(); err != nil {
+		in.logger.Error("Error flushing rejects", zap.Error(err))
+	}
+}
 
-		logger.Info("All jobs dispatched. Waiting for parsers to finish...")
-		parserWg.Wait()
+func chunkSize(format filehandler.TradeFormat) int64 {
+	if !format.Splittable() {
+		return 0
+	}
 
-		logger.Info("All parsing workers finished. Closing trades channel.")
-		close(tradesCh)
-	}()
+	return config.GetParserChunkSize()
+}
 
-	processAndBatchTrades(ctx, tradesCh, dbCh, batchSize, logger)
+func setupOptions(args []string) (options, error) {
+	if err := config.LoadConfig(); err != nil {
+		return options{}, errors.Wrap(err, "loading env configs")
+	}
 
-	logger.Info("Main process finished, waiting for DB workers to commit last batches...")
-	dbWg.Wait()
+	opts, err := parseOptions(args, os.Stderr)
+	if err != nil {
+		return options{}, err
+	}
 
-	duration := time.Since(start)
-	logger.Info("Application finished.", zap.Duration(" Total processing time", duration))
+	for key, value := range opts.overrides {
+		if err := config.Set(key, value); err != nil {
+			return options{}, errors.Wrapf(err, "override %s", key)
+		}
+	}
+
+	return opts, nil
 }
 
 func setupLogger() (*zap.Logger, error) {
@@ -86,19 +283,17 @@ func setupLogger() (*zap.Logger, error) {
 	return logger, nil
 }
 
-func setupComponents(logger *zap.Logger) (*di.Container, context.Context, context.CancelFunc) {
-	err := config.LoadConfig()
-	if err != nil {
-		logger.Error("Error loading env configs:", zap.Error(err))
+func setupComponents(logger *zap.Logger, dryRun bool) (*di.Container, context.Context, context.CancelFunc, error) {
+	var diContainer *di.Container
+	if !dryRun {
+		dbClient, err := db.NewClient(config.GetDatabaseDSN())
+		if err != nil {
+			return nil, nil, nil, errors.Wrap(err, "initializing database client")
+		}
+		diContainer = di.NewContainer(dbClient.DB())
 	}
 
 	ctx, cancel := context.WithCancel(context.Background())
-	dbClient, err := db.NewClient(config.GetDatabaseDSN())
-	if err != nil {
-		logger.Error("Error initializing database client", zap.Error(err))
-	}
-
-	diContainer := di.NewContainer(dbClient.DB())
 
 	sigCh := make(chan os.Signal, 1)
 	signal.Notify(sigCh, os.Interrupt, syscall.SIGTERM)
@@ -108,22 +303,92 @@ func setupComponents(logger *zap.Logger) (*di.Container, context.Context, contex
 		cancel()
 	}()
 
-	return diContainer, ctx, cancel
+	return diContainer, ctx, cancel, nil
+}
+
+func setupRejects(path string) (*filehandler.RejectWriter, func(), error) {
+	noop := func() {}
+	if path == "" {
+		rejects, err := filehandler.NewRejectWriter(nil, filehandler.RejectFormatJSONL)
+
+		return rejects, noop, errors.Wrap(err, "rejects writer")
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		return nil, noop, errors.Wrap(err, "create rejects file")
+	}
+	buffered := bufio.NewWriter(file)
+
+	rejects, err := filehandler.NewRejectWriter(buffered, filehandler.RejectFormatFromPath(path))
+	if err != nil {
+		file.Close()
+
+		return nil, noop, errors.Wrap(err, "rejects writer")
+	}
+
+	return rejects, func() {
+		rejects.Flush() //nolint:errcheck
+		file.Close()
+	}, nil
+}
+
+func logRejects(rejects *filehandler.RejectWriter, logger *zap.Logger) {
+	if err := rejects.Flush(); err != nil {
+		logger.Error("Error flushing rejects", zap.Error(err))
+	}
+
+	for reason, count := range rejects.Counts() {
+		logger.Info("Rejected rows", zap.String("reason", string(reason)), zap.Int64("count", count))
+	}
+}
+
+func logViolations(
+	validator *filehandler.Validator,
+	actions map[filehandler.RejectReason]filehandler.RuleAction,
+	logger *zap.Logger,
+) {
+	for rule, count := range validator.Counts() {
+		logger.Info(
+			"Validation rule violations",
+			zap.String("rule", string(rule)),
+			zap.String("action", string(actions[rule])),
+			zap.Int64("count", count),
+		)
+	}
+}
+
+func dispatchJobs(ctx context.Context, jobs []parseJob, jobCh chan<- parseJob) {
+	defer close(jobCh)
+
+	for _, job := range jobs {
+		select {
+		case jobCh <- job:
+		case <-ctx.Done():
+			return
+		}
+	}
 }
 
 func startParserWorkers(
 	ctx context.Context,
 	numWorkers int,
-	jobCh <-chan string,
-	tradesCh chan<- entity.Trade,
+	jobCh <-chan parseJob,
+	dbCh chan<- *filehandler.Batch,
+	opts filehandler.ParseOptions,
 	wg *sync.WaitGroup,
+	ledger *fileLedger,
+	metrics *pipelineMetrics,
 	logger *zap.Logger,
 ) {
-	for range numWorkers {
+	for i := range numWorkers {
 		wg.Add(1)
-		go func() {
+		go func(id int) {
 			defer wg.Done()
-			for file := range jobCh {
+			observer := metrics.ParserObserver(id)
+			workerOpts := opts
+			workerOpts.Observer = observer
+			for job := range jobCh {
 				select {
 				case <-ctx.Done():
 					logger.Info("Parser worker shutting down due to context cancellation.")
@@ -131,23 +396,30 @@ func startParserWorkers(
 					return
 
 				default:
-					if err := filehandler.ParseFileToTrades(ctx, file, tradesCh, logger); err != nil {
-						logger.Error("Error parsing file:", zap.Any("file", file), zap.Error(err))
+					start := time.Now()
+					stats, err := filehandler.ParseSegment(ctx, job.file, job.segment, job.fromLine, dbCh, workerOpts, logger)
+					observer.ObserveJob(time.Since(start))
+					ledger.RecordParse(ctx, job, stats, err)
+					if err != nil {
+						logger.Error("Error parsing file:", zap.Any("file", job.file), zap.Error(err))
 
 						continue
 					}
 				}
 			}
-		}()
+		}(i)
 	}
 }
 
 func startDBWorkers(
 	ctx context.Context,
-	dbCh chan []entity.Trade,
-	uc *usecase.TradesUC,
+	dbCh <-chan *filehandler.Batch,
+	pool *filehandler.BatchPool,
+	write *filehandler.RetryingWriter,
 	workerCount int,
 	wg *sync.WaitGroup,
+	ledger *fileLedger,
+	metrics *pipelineMetrics,
 	logger *zap.Logger,
 ) {
 	for i := range workerCount {
@@ -165,62 +437,97 @@ func startDBWorkers(
 					return
 
 				default:
-					if len(batch) == 0 {
-						continue
-					}
-
-					count, err := uc.CreateTrades(ctx, batch)
-					if err != nil {
-						logger.Error("DB worker error", zap.Int("worker_id", id), zap.Error(err))
-
-						continue
-					}
-					logger.Info(
-						"DB worker wrote batch",
-						zap.Int("worker_id", id),
-						zap.Int("trades_written", count),
-					)
+					writeBatch(ctx, id, batch, write, ledger, metrics, logger)
+					pool.Put(batch)
 				}
 			}
 		}(i)
 	}
 }
 
-func processAndBatchTrades(
+func writeBatch(
 	ctx context.Context,
-	tradesIn <-chan entity.Trade,
-	dbOut chan<- []entity.Trade,
-	batchSize int,
+	workerID int,
+	batch *filehandler.Batch,
+	write *filehandler.RetryingWriter,
+	ledger *fileLedger,
+	metrics *pipelineMetrics,
 	logger *zap.Logger,
 ) {
-	batch := make([]entity.Trade, 0, batchSize)
-	defer close(dbOut)
+	start := time.Now()
+	result, err := write.Write(ctx, batch)
+	metrics.ObserveCommit(workerID, time.Since(start), result.Written+result.Cancelled, err)
+	if err != nil {
+		ledger.RecordFailedBatch(batch)
+		logger.Error(
+			"DB worker error",
+			zap.Int("worker_id", workerID),
+			zap.String("file", batch.Source),
+			zap.Int("rows_written", result.Written+result.Cancelled),
+			zap.Error(err),
+		)
+
+		return
+	}
+	ledger.RecordCommit(ctx, batch, result.Written+result.Cancelled, result.Rejected)
+	logger.Info(
+		"DB worker wrote batch",
+		zap.Int("worker_id", workerID),
+		zap.String("file", batch.Source),
+		zap.Int("trades_written", result.Written),
+		zap.Int("cancellations_written", result.Cancelled),
+		zap.Int("rows_rejected", result.Rejected),
+		zap.Int("duplicates_skipped", batch.Len()-result.Written-result.Cancelled-result.Rejected),
+	)
+}
 
-	for {
-		select {
-		case <-ctx.Done():
-			logger.Info("Context cancelled, flushing final batch and stopping.")
-			if len(batch) > 0 {
-				dbOut <- batch
-			}
+func discardTrades(_ context.Context, _ []entity.Trade) (int, int, error) {
+	return 0, 0, nil
+}
 
-			return
+func discardQuotes(_ context.Context, _ []entity.DailyQuote) (int, error) {
+	return 0, nil
+}
 
-		case tr, ok := <-tradesIn:
-			if !ok {
-				logger.Info("Trades channel closed, flushing final batch.")
-				if len(batch) > 0 {
-					dbOut <- batch
-				}
+func discardInstruments(_ context.Context, _ []entity.Instrument) (int, error) {
+	return 0, nil
+}
 
-				return
-			}
-			batch = append(batch, tr)
-			if len(batch) >= batchSize {
-				logger.Info("Batch size reached. ", zap.Int("size", len(batch)))
-				dbOut <- batch
-				batch = make([]entity.Trade, 0, batchSize)
-			}
-		}
+func discardEvents(_ context.Context, _ []entity.CorporateEvent) (int, error) {
+	return 0, nil
+}
+
+func truncateDate(ctx context.Context, uc *usecase.TradesUC, date time.Time, logger *zap.Logger) error {
+	if date.IsZero() {
+		return nil
+	}
+	if uc == nil {
+		logger.Info("Dry run, not truncating trades", zap.String("date", date.Format(time.DateOnly)))
+
+		return nil
+	}
+
+	removed, err := uc.TruncateDate(ctx, date)
+	if err != nil {
+		logger.Error("Error truncating trades", zap.String("date", date.Format(time.DateOnly)), zap.Error(err))
+
+		return errors.Wrap(err, "truncating trades")
 	}
+
+	logger.Info("Truncated trades", zap.String("date", date.Format(time.DateOnly)), zap.Int("trades_removed", removed))
+
+	return nil
+}
+
+func applyCancellations(ctx context.Context, uc *usecase.TradesUC, logger *zap.Logger) error {
+	removed, err := uc.ApplyTradeCancellations(context.WithoutCancel(ctx))
+	if err != nil {
+		logger.Error("Error applying trade cancellations", zap.Error(err))
+
+		return errors.Wrap(err, "applying trade cancellations")
+	}
+
+	logger.Info("Applied trade cancellations", zap.Int("trades_removed", removed))
+
+	return nil
 }
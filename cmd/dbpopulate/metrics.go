@@ -0,0 +1,213 @@
+package main
+
+import (
+	"b3challenge/internal/filehandler"
+	"context"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"go.uber.org/zap"
+)
+
+const (
+	metricsNamespace         = "dbpopulate"
+	metricsReadHeaderTimeout = 5 * time.Second
+	metricsShutdownTimeout   = 5 * time.Second
+	commitBucketStart        = 0.01
+	commitBucketFactor       = 2
+	commitBucketCount        = 14
+	waitBucketStart          = 0.001
+	waitBucketCount          = 16
+)
+
+const (
+	stageParser = "parser"
+	stageDB     = "db"
+	outcomeOK   = "ok"
+	outcomeFail = "failed"
+)
+
+// pipelineMetrics exposes the ingestion pipeline in the Prometheus format. Rejected rows are read
+// from the RejectWriter, in-flight rows from the RowBudget and channel depths from the channels of
+// the current run at scrape time.
+type pipelineMetrics struct {
+	registry     *prometheus.Registry
+	rowsParsed   prometheus.Counter
+	rowsInserted prometheus.Counter
+	batchCommit  *prometheus.HistogramVec
+	parserWait   *prometheus.HistogramVec
+	workerBusy   *prometheus.CounterVec
+	rejects      *filehandler.RejectWriter
+	budget       *filehandler.RowBudget
+	rejectsDesc  *prometheus.Desc
+	depthDesc    *prometheus.Desc
+	inFlightDesc *prometheus.Desc
+	mu           sync.Mutex
+	channels     map[string]func() int
+}
+
+func newPipelineMetrics(rejects *filehandler.RejectWriter, budget *filehandler.RowBudget) *pipelineMetrics {
+	m := &pipelineMetrics{
+		registry: prometheus.NewRegistry(),
+		rowsParsed: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "rows_parsed_total",
+			Help:      "Rows parsed, counted as the parsers hand each batch over.",
+		}),
+		rowsInserted: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "rows_inserted_total",
+			Help:      "Rows written by the database workers, duplicates excluded. Trades count once staged.",
+		}),
+		batchCommit: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: metricsNamespace,
+			Name:      "batch_commit_seconds",
+			Help:      "Time to write a batch to the database, retries included.",
+			Buckets:   prometheus.ExponentialBuckets(commitBucketStart, commitBucketFactor, commitBucketCount),
+		}, []string{"outcome"}),
+		parserWait: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: metricsNamespace,
+			Name:      "parser_wait_seconds",
+			Help:      "Time a parser was blocked on the row budget or on the database workers taking a batch.",
+			Buckets:   prometheus.ExponentialBuckets(waitBucketStart, commitBucketFactor, waitBucketCount),
+		}, []string{"cause"}),
+		workerBusy: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "worker_busy_seconds_total",
+			Help:      "Time each worker spent parsing or writing, time parsers were blocked excluded.",
+		}, []string{"stage", "worker"}),
+		rejects: rejects,
+		budget:  budget,
+		rejectsDesc: prometheus.NewDesc(
+			prometheus.BuildFQName(metricsNamespace, "", "rows_rejected_total"),
+			"Rows written to the rejects output.",
+			[]string{"reason"}, nil,
+		),
+		depthDesc: prometheus.NewDesc(
+			prometheus.BuildFQName(metricsNamespace, "", "channel_depth"),
+			"Items waiting in a pipeline channel.",
+			[]string{"channel"}, nil,
+		),
+		inFlightDesc: prometheus.NewDesc(
+			prometheus.BuildFQName(metricsNamespace, "", "rows_in_flight"),
+			"Rows reserved by batches being parsed, queued or written.",
+			nil, nil,
+		),
+		mu:       sync.Mutex{},
+		channels: nil,
+	}
+
+	m.registry.MustRegister(
+		m.rowsParsed, m.rowsInserted, m.batchCommit, m.parserWait, m.workerBusy, m,
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}), //nolint:exhaustruct
+	)
+
+	return m
+}
+
+func (m *pipelineMetrics) Describe(ch chan<- *prometheus.Desc) {
+	ch <- m.rejectsDesc
+	ch <- m.depthDesc
+	ch <- m.inFlightDesc
+}
+
+func (m *pipelineMetrics) Collect(ch chan<- prometheus.Metric) {
+	for reason, count := range m.rejects.Counts() {
+		ch <- prometheus.MustNewConstMetric(m.rejectsDesc, prometheus.CounterValue, float64(count), string(reason))
+	}
+	ch <- prometheus.MustNewConstMetric(m.inFlightDesc, prometheus.GaugeValue, float64(m.budget.InFlight()))
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for name, depth := range m.channels {
+		ch <- prometheus.MustNewConstMetric(m.depthDesc, prometheus.GaugeValue, float64(depth()), name)
+	}
+}
+
+func (m *pipelineMetrics) TrackChannels(channels map[string]func() int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.channels = channels
+}
+
+func (m *pipelineMetrics) ParserObserver(worker int) *parserObserver {
+	return &parserObserver{metrics: m, worker: strconv.Itoa(worker), waited: 0}
+}
+
+func (m *pipelineMetrics) ObserveCommit(worker int, elapsed time.Duration, inserted int, err error) {
+	outcome := outcomeOK
+	if err != nil {
+		outcome = outcomeFail
+	}
+	m.batchCommit.WithLabelValues(outcome).Observe(elapsed.Seconds())
+	m.workerBusy.WithLabelValues(stageDB, strconv.Itoa(worker)).Add(elapsed.Seconds())
+	m.rowsInserted.Add(float64(inserted))
+}
+
+func (m *pipelineMetrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{}) //nolint:exhaustruct
+}
+
+// serveMetrics exposes /metrics on port until the returned function is called. A zero port
+// disables the endpoint.
+func serveMetrics(port uint16, metrics *pipelineMetrics, logger *zap.Logger) (func(), error) {
+	if port == 0 {
+		return func() {}, nil
+	}
+
+	listener, err := net.Listen("tcp", net.JoinHostPort("", strconv.Itoa(int(port))))
+	if err != nil {
+		return nil, errors.Wrap(err, "listen metrics port")
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", metrics.Handler())
+	server := &http.Server{Handler: mux, ReadHeaderTimeout: metricsReadHeaderTimeout} //nolint:exhaustruct
+	go func() {
+		if err := server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			logger.Error("Metrics server stopped", zap.Error(err))
+		}
+	}()
+	logger.Info("Serving metrics", zap.String("addr", listener.Addr().String()))
+
+	return func() {
+		ctx, cancel := context.WithTimeout(context.Background(), metricsShutdownTimeout)
+		defer cancel()
+
+		if err := server.Shutdown(ctx); err != nil {
+			logger.Error("Error stopping metrics server", zap.Error(err))
+		}
+	}, nil
+}
+
+// parserObserver follows a single parser worker, so the time it was blocked while parsing a job can
+// be left out of its busy time.
+type parserObserver struct {
+	metrics *pipelineMetrics
+	worker  string
+	waited  time.Duration
+}
+
+func (o *parserObserver) ObserveParsed(rows int) {
+	o.metrics.rowsParsed.Add(float64(rows))
+}
+
+func (o *parserObserver) ObserveWait(cause filehandler.WaitCause, waited time.Duration) {
+	o.waited += waited
+	o.metrics.parserWait.WithLabelValues(string(cause)).Observe(waited.Seconds())
+}
+
+func (o *parserObserver) ObserveJob(elapsed time.Duration) {
+	busy := max(elapsed-o.waited, 0)
+	o.waited = 0
+	o.metrics.workerBusy.WithLabelValues(stageParser, o.worker).Add(busy.Seconds())
+}
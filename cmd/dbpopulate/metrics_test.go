@@ -0,0 +1,62 @@
+package main
+
+import (
+	"b3challenge/internal/filehandler"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPipelineMetrics_Handler(t *testing.T) {
+	rejects, err := filehandler.NewRejectWriter(nil, filehandler.RejectFormatJSONL)
+	require.NoError(t, err)
+	require.NoError(t, rejects.Write(filehandler.Reject{
+		File: "a.txt", Line: 2, Raw: "", Reason: filehandler.ReasonInvalidPrice, Error: "",
+	}))
+
+	budget := filehandler.NewRowBudget(0)
+	require.NoError(t, budget.Acquire(context.Background(), 5))
+	metrics := newPipelineMetrics(rejects, budget)
+	dbCh := make(chan *filehandler.Batch, 2)
+	dbCh <- &filehandler.Batch{} //nolint:exhaustruct
+	metrics.TrackChannels(map[string]func() int{"dbCh": func() int { return len(dbCh) }})
+
+	parser := metrics.ParserObserver(0)
+	parser.ObserveParsed(3)
+	parser.ObserveWait(filehandler.WaitBudget, 500*time.Millisecond)
+	parser.ObserveWait(filehandler.WaitConsumer, time.Second)
+	parser.ObserveJob(3500 * time.Millisecond)
+	metrics.ObserveCommit(1, 500*time.Millisecond, 3, nil)
+	metrics.ObserveCommit(1, time.Second, 0, assert.AnError)
+
+	recorder := httptest.NewRecorder()
+	metrics.Handler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	require.Equal(t, http.StatusOK, recorder.Code)
+
+	body := recorder.Body.String()
+	for _, want := range []string{
+		"dbpopulate_rows_parsed_total 3",
+		"dbpopulate_rows_inserted_total 3",
+		`dbpopulate_rows_rejected_total{reason="invalid_price"} 1`,
+		`dbpopulate_channel_depth{channel="dbCh"} 1`,
+		"dbpopulate_rows_in_flight 5",
+		`dbpopulate_batch_commit_seconds_count{outcome="ok"} 1`,
+		`dbpopulate_batch_commit_seconds_count{outcome="failed"} 1`,
+		`dbpopulate_parser_wait_seconds_count{cause="budget"} 1`,
+		`dbpopulate_parser_wait_seconds_sum{cause="consumer"} 1`,
+		`dbpopulate_worker_busy_seconds_total{stage="parser",worker="0"} 2`,
+		`dbpopulate_worker_busy_seconds_total{stage="db",worker="1"} 1.5`,
+	} {
+		assert.Contains(t, body, want)
+	}
+
+	metrics.TrackChannels(nil)
+	recorder = httptest.NewRecorder()
+	metrics.Handler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	assert.NotContains(t, recorder.Body.String(), "dbpopulate_channel_depth")
+}
@@ -0,0 +1,181 @@
+package main
+
+import (
+	"b3challenge/internal/domain/entity"
+	"b3challenge/internal/filehandler"
+	"encoding/json"
+	"io"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const (
+	exitSuccess        = 0
+	exitTotalFailure   = 1
+	exitPartialFailure = 2
+)
+
+type runStatus string
+
+const (
+	runStatusSuccess runStatus = "success"
+	runStatusPartial runStatus = "partial"
+	runStatusFailed  runStatus = "failed"
+)
+
+type fileReport struct {
+	Path          string `json:"path"`
+	Status        string `json:"status"`
+	RowsParsed    int64  `json:"rows_parsed"`
+	RowsRejected  int64  `json:"rows_rejected"`
+	RowsFiltered  int64  `json:"rows_filtered"`
+	RowsInserted  int64  `json:"rows_inserted"`
+	FailedBatches int    `json:"failed_batches"`
+	Error         string `json:"error,omitempty"`
+}
+
+func newFileReport(path, status string, entry *ledgerEntry) fileReport {
+	report := fileReport{
+		Path:          path,
+		Status:        status,
+		RowsParsed:    entry.rowsParsed,
+		RowsRejected:  entry.rowsRejected,
+		RowsFiltered:  entry.rowsFiltered,
+		RowsInserted:  entry.rowsInserted,
+		FailedBatches: entry.failedBatches,
+		Error:         "",
+	}
+	switch {
+	case entry.parseErr != nil:
+		report.Error = entry.parseErr.Error()
+	case entry.promoteErr != nil:
+		report.Error = entry.promoteErr.Error()
+	}
+
+	return report
+}
+
+type reportTotals struct {
+	Files         int   `json:"files"`
+	FilesFailed   int   `json:"files_failed"`
+	FilesSkipped  int   `json:"files_skipped"`
+	RowsParsed    int64 `json:"rows_parsed"`
+	RowsRejected  int64 `json:"rows_rejected"`
+	RowsFiltered  int64 `json:"rows_filtered"`
+	RowsInserted  int64 `json:"rows_inserted"`
+	FailedBatches int   `json:"failed_batches"`
+}
+
+type runReport struct {
+	Status          runStatus                          `json:"status"`
+	ExitCode        int                                `json:"exit_code"`
+	DryRun          bool                               `json:"dry_run"`
+	StartedAt       time.Time                          `json:"started_at"`
+	FinishedAt      time.Time                          `json:"finished_at"`
+	DurationSeconds float64                            `json:"duration_seconds"`
+	RowsPerSecond   float64                            `json:"rows_per_second"`
+	Totals          reportTotals                       `json:"totals"`
+	Rejects         map[filehandler.RejectReason]int64 `json:"rejects_by_reason"`
+	Violations      map[filehandler.RejectReason]int64 `json:"validation_violations"`
+	InFlightLimit   int64                              `json:"in_flight_row_limit"`
+	PeakInFlight    int64                              `json:"peak_in_flight_rows"`
+	Files           []fileReport                       `json:"files"`
+	Errors          []string                           `json:"errors,omitempty"`
+	fatal           bool
+}
+
+func newRunReport(startedAt time.Time) *runReport {
+	return &runReport{
+		Status:          runStatusSuccess,
+		ExitCode:        exitSuccess,
+		DryRun:          false,
+		StartedAt:       startedAt,
+		FinishedAt:      time.Time{},
+		DurationSeconds: 0,
+		RowsPerSecond:   0,
+		Totals:          reportTotals{}, //nolint:exhaustruct
+		Rejects:         map[filehandler.RejectReason]int64{},
+		Violations:      map[filehandler.RejectReason]int64{},
+		InFlightLimit:   0,
+		PeakInFlight:    0,
+		Files:           []fileReport{},
+		Errors:          nil,
+		fatal:           false,
+	}
+}
+
+func (r *runReport) AddError(err error) {
+	r.Errors = append(r.Errors, err.Error())
+}
+
+func (r *runReport) Fail(err error) {
+	r.AddError(err)
+	r.fatal = true
+}
+
+func (r *runReport) AddFiles(files []fileReport) {
+	for _, file := range files {
+		r.Files = append(r.Files, file)
+		r.Totals.Files++
+		r.Totals.RowsParsed += file.RowsParsed
+		r.Totals.RowsRejected += file.RowsRejected
+		r.Totals.RowsFiltered += file.RowsFiltered
+		r.Totals.RowsInserted += file.RowsInserted
+		r.Totals.FailedBatches += file.FailedBatches
+
+		switch file.Status {
+		case string(entity.IngestionStatusFailed):
+			r.Totals.FilesFailed++
+		case fileStatusSkipped:
+			r.Totals.FilesSkipped++
+		}
+	}
+}
+
+func (r *runReport) SetRejects(counts map[filehandler.RejectReason]int64) {
+	r.Rejects = counts
+}
+
+func (r *runReport) SetViolations(counts map[filehandler.RejectReason]int64) {
+	r.Violations = counts
+}
+
+func (r *runReport) SetInFlight(limit, peak int64) {
+	r.InFlightLimit = limit
+	r.PeakInFlight = peak
+}
+
+func (r *runReport) finalize(finishedAt time.Time) {
+	r.FinishedAt = finishedAt
+	r.DurationSeconds = finishedAt.Sub(r.StartedAt).Seconds()
+	rows := r.Totals.RowsInserted
+	if r.DryRun {
+		rows = r.Totals.RowsParsed
+	}
+	if r.DurationSeconds > 0 {
+		r.RowsPerSecond = float64(rows) / r.DurationSeconds
+	}
+
+	attempted := r.Totals.Files - r.Totals.FilesSkipped
+	switch {
+	case r.fatal || (attempted > 0 && r.Totals.FilesFailed == attempted):
+		r.Status, r.ExitCode = runStatusFailed, exitTotalFailure
+	case r.Totals.FilesFailed > 0 || len(r.Errors) > 0:
+		r.Status, r.ExitCode = runStatusPartial, exitPartialFailure
+	default:
+		r.Status, r.ExitCode = runStatusSuccess, exitSuccess
+	}
+}
+
+func (r *runReport) Emit(out io.Writer, logger *zap.Logger) int {
+	r.finalize(time.Now())
+
+	encoder := json.NewEncoder(out)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(r); err != nil {
+		logger.Error("Error writing run report", zap.Error(err))
+	}
+
+	return r.ExitCode
+}
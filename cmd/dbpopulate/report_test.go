@@ -0,0 +1,88 @@
+package main
+
+import (
+	"b3challenge/internal/domain/entity"
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestRunReport_Emit(t *testing.T) {
+	succeeded := fileReport{Path: "a.txt", Status: string(entity.IngestionStatusSucceeded), RowsInserted: 10}
+	failed := fileReport{Path: "b.txt", Status: string(entity.IngestionStatusFailed), FailedBatches: 1}
+	skipped := fileReport{Path: "c.txt", Status: fileStatusSkipped}
+
+	tests := []struct {
+		name       string
+		files      []fileReport
+		errs       []error
+		fatal      error
+		wantStatus runStatus
+		wantCode   int
+	}{
+		{
+			name:       "all files loaded",
+			files:      []fileReport{succeeded, skipped},
+			wantStatus: runStatusSuccess,
+			wantCode:   exitSuccess,
+		},
+		{
+			name:       "nothing to do",
+			files:      []fileReport{skipped},
+			wantStatus: runStatusSuccess,
+			wantCode:   exitSuccess,
+		},
+		{
+			name:       "some files failed",
+			files:      []fileReport{succeeded, failed},
+			wantStatus: runStatusPartial,
+			wantCode:   exitPartialFailure,
+		},
+		{
+			name:       "run level error",
+			files:      []fileReport{succeeded},
+			errs:       []error{assert.AnError},
+			wantStatus: runStatusPartial,
+			wantCode:   exitPartialFailure,
+		},
+		{
+			name:       "every attempted file failed",
+			files:      []fileReport{failed, skipped},
+			wantStatus: runStatusFailed,
+			wantCode:   exitTotalFailure,
+		},
+		{
+			name:       "setup failure",
+			fatal:      assert.AnError,
+			wantStatus: runStatusFailed,
+			wantCode:   exitTotalFailure,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report := newRunReport(time.Now().Add(-time.Second))
+			report.AddFiles(tt.files)
+			for _, err := range tt.errs {
+				report.AddError(err)
+			}
+			if tt.fatal != nil {
+				report.Fail(tt.fatal)
+			}
+
+			var out bytes.Buffer
+			code := report.Emit(&out, zap.NewNop())
+			assert.Equal(t, tt.wantCode, code)
+
+			var got map[string]any
+			require.NoError(t, json.Unmarshal(out.Bytes(), &got))
+			assert.Equal(t, string(tt.wantStatus), got["status"])
+			assert.InDelta(t, float64(tt.wantCode), got["exit_code"], 0)
+			assert.Len(t, got["files"], len(tt.files))
+		})
+	}
+}
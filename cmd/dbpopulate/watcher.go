@@ -0,0 +1,167 @@
+package main
+
+import (
+	"b3challenge/internal/filehandler"
+	"context"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+type fileVersion struct {
+	size    int64
+	modTime time.Time
+}
+
+type pendingFile struct {
+	version   fileVersion
+	changedAt time.Time
+}
+
+type stableFiles struct {
+	include []string
+	exclude []string
+	settle  time.Duration
+	logger  *zap.Logger
+	pending map[string]pendingFile
+	loaded  map[string]fileVersion
+}
+
+func newStableFiles(include, exclude []string, settle time.Duration, logger *zap.Logger) *stableFiles {
+	return &stableFiles{
+		include: include,
+		exclude: exclude,
+		settle:  settle,
+		logger:  logger,
+		pending: make(map[string]pendingFile),
+		loaded:  make(map[string]fileVersion),
+	}
+}
+
+func (s *stableFiles) Observe(path string, now time.Time) {
+	selected, err := filehandler.IsSelectedFile(path, s.include, s.exclude)
+	if err != nil {
+		s.logger.Error("Error matching watched file", zap.String("file", path), zap.Error(err))
+
+		return
+	}
+	if !selected {
+		return
+	}
+
+	version, ok := statVersion(path)
+	if !ok {
+		delete(s.pending, path)
+
+		return
+	}
+	if loaded, ok := s.loaded[path]; ok && loaded == version {
+		return
+	}
+	if pending, ok := s.pending[path]; ok && pending.version == version {
+		return
+	}
+
+	s.pending[path] = pendingFile{version: version, changedAt: now}
+}
+
+func (s *stableFiles) Ready(now time.Time) []string {
+	var ready []string
+	for path, pending := range s.pending {
+		version, ok := statVersion(path)
+		switch {
+		case !ok:
+			delete(s.pending, path)
+		case version != pending.version:
+			s.pending[path] = pendingFile{version: version, changedAt: now}
+		case now.Sub(pending.changedAt) >= s.settle:
+			delete(s.pending, path)
+			s.loaded[path] = version
+			ready = append(ready, path)
+		}
+	}
+	sort.Strings(ready)
+
+	return ready
+}
+
+func statVersion(path string) (fileVersion, bool) {
+	info, err := os.Stat(path)
+	if err != nil || info.IsDir() {
+		return fileVersion{size: 0, modTime: time.Time{}}, false
+	}
+
+	return fileVersion{size: info.Size(), modTime: info.ModTime()}, true
+}
+
+func watchInputs(
+	ctx context.Context,
+	opts options,
+	settle time.Duration,
+	ingest func(ctx context.Context, files []string),
+	logger *zap.Logger,
+) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return errors.Wrap(err, "create watcher")
+	}
+	defer watcher.Close()
+
+	var dirs []string
+	for _, input := range opts.inputs {
+		info, err := os.Stat(input)
+		if err != nil || !info.IsDir() {
+			continue
+		}
+		if err := watcher.Add(input); err != nil {
+			return errors.Wrapf(err, "watch %s", input)
+		}
+		dirs = append(dirs, input)
+	}
+	if len(dirs) == 0 {
+		return errors.New("watch mode needs at least one input directory")
+	}
+
+	stable := newStableFiles(opts.include, opts.exclude, settle, logger)
+	existing, err := filehandler.FindInputFiles(dirs, opts.include, opts.exclude)
+	if err != nil {
+		return errors.Wrap(err, "initial scan")
+	}
+	for _, file := range existing {
+		stable.Observe(file, time.Now())
+	}
+
+	const minPollInterval = 100 * time.Millisecond
+	ticker := time.NewTicker(max(settle/2, minPollInterval)) //nolint:mnd
+	defer ticker.Stop()
+
+	logger.Info("Watching input directories for new files", zap.Strings("dirs", dirs), zap.Duration("settle", settle))
+
+	for {
+		select {
+		case <-ctx.Done():
+			logger.Info("Stopping watch mode.")
+
+			return nil
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return nil
+			}
+			stable.Observe(event.Name, time.Now())
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return nil
+			}
+			logger.Error("Watcher error", zap.Error(err))
+		case now := <-ticker.C:
+			if files := stable.Ready(now); len(files) > 0 {
+				logger.Info("New files are stable, loading them", zap.Strings("files", files))
+				ingest(ctx, files)
+			}
+		}
+	}
+}
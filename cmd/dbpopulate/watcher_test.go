@@ -0,0 +1,64 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestStableFiles(t *testing.T) {
+	const settle = 5 * time.Second
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "02-06-2025_NEGOCIOSAVISTA.txt")
+	ignored := filepath.Join(dir, "notes.md")
+	require.NoError(t, os.WriteFile(path, []byte("header\n"), 0o600))
+	require.NoError(t, os.WriteFile(ignored, []byte("notes"), 0o600))
+
+	start := time.Now()
+	stable := newStableFiles(nil, nil, settle, zap.NewNop())
+	stable.Observe(path, start)
+	stable.Observe(ignored, start)
+
+	assert.Empty(t, stable.Ready(start.Add(settle/2)), "file must stay unchanged for the settle period")
+
+	appendLine(t, path)
+	assert.Empty(t, stable.Ready(start.Add(settle)), "a write restarts the settle period")
+	assert.Empty(t, stable.Ready(start.Add(settle+settle/2)))
+	assert.Equal(t, []string{path}, stable.Ready(start.Add(2*settle)))
+
+	stable.Observe(path, start.Add(3*settle))
+	assert.Empty(t, stable.Ready(start.Add(5*settle)), "an unchanged file is not loaded twice")
+
+	appendLine(t, path)
+	stable.Observe(path, start.Add(6*settle))
+	assert.Equal(t, []string{path}, stable.Ready(start.Add(7*settle)), "a rewritten file is loaded again")
+}
+
+func TestStableFiles_Removed(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "02-06-2025_NEGOCIOSAVISTA.txt")
+	require.NoError(t, os.WriteFile(path, []byte("header\n"), 0o600))
+
+	start := time.Now()
+	stable := newStableFiles(nil, nil, time.Second, zap.NewNop())
+	stable.Observe(path, start)
+	require.NoError(t, os.Remove(path))
+
+	assert.Empty(t, stable.Ready(start.Add(time.Minute)))
+	assert.Empty(t, stable.pending)
+}
+
+func appendLine(t *testing.T, path string) {
+	t.Helper()
+
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0o600)
+	require.NoError(t, err)
+	_, err = file.WriteString("2025-06-02;ABC;0;1,0;1;100000000;1;1;2025-06-02;;\n")
+	require.NoError(t, err)
+	require.NoError(t, file.Close())
+}
@@ -0,0 +1,203 @@
+package main
+
+import (
+	"b3challenge/config"
+	"b3challenge/internal/adapter/db"
+	"b3challenge/internal/di"
+	"b3challenge/internal/domain/entity"
+	"b3challenge/internal/domain/usecase"
+	"compress/gzip"
+	"context"
+	"flag"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+const (
+	actionDetach  = "detach"
+	actionArchive = "archive"
+	actionDrop    = "drop"
+)
+
+func main() {
+	logger, err := setupLogger()
+	if err != nil {
+		panic("failed to create logger: " + err.Error())
+	}
+	defer logger.Sync() //nolint:errcheck
+
+	if err := config.LoadConfig(); err != nil {
+		logger.Fatal("Error loading env configs:", zap.Error(err))
+	}
+
+	const (
+		defaultRetentionMonths = 24
+		defaultEnsureAhead     = 1
+	)
+	retentionFlag := flag.Int("retention-months", defaultRetentionMonths, "months of trades kept besides the current one")
+	actionFlag := flag.String("action", actionArchive, "what to do with expired partitions: detach, archive or drop")
+	archiveDirFlag := flag.String("archive-dir", "archive", "directory where archived partitions are written")
+	ensureAheadFlag := flag.Int("ensure-ahead", defaultEnsureAhead, "months of partitions created ahead of the current one")
+	dryRunFlag := flag.Bool("dry-run", false, "only list what would be created or removed")
+	flag.Parse()
+
+	switch *actionFlag {
+	case actionDetach, actionArchive, actionDrop:
+	default:
+		logger.Fatal("Invalid -action", zap.String("action", *actionFlag))
+	}
+	if *retentionFlag < 0 || *ensureAheadFlag < 0 {
+		logger.Fatal("-retention-months and -ensure-ahead must not be negative")
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	dbClient, err := db.NewClient(config.GetDatabaseDSN())
+	if err != nil {
+		logger.Fatal("Error initializing database client", zap.Error(err))
+	}
+	defer dbClient.Close()
+
+	partitionsUC := di.NewContainer(dbClient.DB()).GetPartitionsUC()
+	now := time.Now()
+
+	if !*dryRunFlag {
+		names, err := partitionsUC.EnsureAhead(ctx, now, *ensureAheadFlag)
+		if err != nil {
+			logger.Fatal("Error creating partitions", zap.Error(err))
+		}
+		logger.Info("Partitions ready", zap.Strings("partitions", names))
+	}
+
+	expired, err := partitionsUC.ListExpired(ctx, now, *retentionFlag)
+	if err != nil {
+		logger.Fatal("Error listing partitions", zap.Error(err))
+	}
+	logger.Info("Expired partitions found",
+		zap.Int("count", len(expired)),
+		zap.Time("cutoff", usecase.RetentionCutoff(now, *retentionFlag)),
+	)
+
+	failed := 0
+	for _, partition := range expired {
+		if ctx.Err() != nil {
+			logger.Info("Received shutdown signal, stopping.")
+
+			break
+		}
+
+		if *dryRunFlag {
+			logger.Info("Would apply action", zap.String("action", *actionFlag), zap.String("partition", partition.Name))
+
+			continue
+		}
+
+		if err := applyAction(ctx, partitionsUC, partition, *actionFlag, *archiveDirFlag, logger); err != nil {
+			failed++
+			logger.Error("Error applying action",
+				zap.String("action", *actionFlag),
+				zap.String("partition", partition.Name),
+				zap.Error(err),
+			)
+		}
+	}
+
+	if failed > 0 {
+		logger.Error("Some partitions failed", zap.Int("failed", failed))
+		os.Exit(1) //nolint:gocritic
+	}
+}
+
+func applyAction(
+	ctx context.Context,
+	partitionsUC *usecase.PartitionsUC,
+	partition entity.TradePartition,
+	action, archiveDir string,
+	logger *zap.Logger,
+) error {
+	switch action {
+	case actionDetach:
+		if err := partitionsUC.Detach(ctx, partition); err != nil {
+			return errors.Wrap(err, "detach")
+		}
+	case actionDrop:
+		if err := partitionsUC.Drop(ctx, partition); err != nil {
+			return errors.Wrap(err, "drop")
+		}
+	case actionArchive:
+		path := filepath.Join(archiveDir, partition.Name+".csv.gz")
+
+		out, err := newArchiveFile(path)
+		if err != nil {
+			return errors.Wrap(err, "create archive")
+		}
+
+		rows, err := partitionsUC.Archive(ctx, partition, out)
+		if err != nil {
+			return errors.Wrap(err, "archive")
+		}
+		logger.Info("Partition archived", zap.String("file", path), zap.Int64("rows", rows))
+	}
+
+	logger.Info("Partition processed", zap.String("action", action), zap.String("partition", partition.Name))
+
+	return nil
+}
+
+type archiveFile struct {
+	file *os.File
+	*gzip.Writer
+}
+
+func newArchiveFile(path string) (*archiveFile, error) {
+	const dirPerm = 0o755
+	if err := os.MkdirAll(filepath.Dir(path), dirPerm); err != nil {
+		return nil, errors.Wrap(err, "mkdir")
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "create")
+	}
+
+	return &archiveFile{
+		file:   file,
+		Writer: gzip.NewWriter(file),
+	}, nil
+}
+
+func (a *archiveFile) Close() error {
+	if err := a.Writer.Close(); err != nil {
+		a.file.Close() //nolint:errcheck
+
+		return errors.Wrap(err, "gzip close")
+	}
+
+	if err := a.file.Sync(); err != nil {
+		a.file.Close() //nolint:errcheck
+
+		return errors.Wrap(err, "sync")
+	}
+
+	return errors.Wrap(a.file.Close(), "file close")
+}
+
+func setupLogger() (*zap.Logger, error) {
+	cfg := zap.NewDevelopmentConfig()
+	cfg.EncoderConfig.EncodeLevel = zapcore.CapitalColorLevelEncoder
+	cfg.EncoderConfig.EncodeTime = zapcore.ISO8601TimeEncoder
+	logger, err := cfg.Build()
+	if err != nil {
+		return nil, errors.Wrap(err, "zap")
+	}
+
+	return logger, nil
+}
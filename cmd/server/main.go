@@ -5,24 +5,89 @@ import (
 	"b3challenge/internal/adapter/db"
 	"b3challenge/internal/api"
 	"b3challenge/internal/di"
+	"b3challenge/internal/filehandler"
+	"context"
 	"log"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"go.uber.org/zap"
 )
 
+const shutdownTimeout = 30 * time.Second
+
 func main() {
 	if err := config.LoadConfig(); err != nil {
 		log.Fatalf("Error loading config: %v", err)
 	}
 
-	db, err := db.NewClient(config.GetDatabaseDSN())
+	logger, err := zap.NewProduction()
+	if err != nil {
+		log.Fatalf("Error creating logger: %v", err)
+	}
+	defer logger.Sync() //nolint:errcheck
+
+	dbClient, err := db.NewClient(config.GetDatabaseDSN())
 	if err != nil {
 		log.Fatalf("Error initializing database client: %v", err)
 	}
 
-	diContainer := di.NewContainer(db.DB())
+	ruleActions, err := filehandler.ParseRuleActions(config.GetValidationRules())
+	if err != nil {
+		log.Fatalf("Error loading validation rules: %v", err)
+	}
+
+	diContainer := di.NewContainer(dbClient.DB())
+	loader := filehandler.NewLoader(
+		config.GetUploadDir(),
+		filehandler.BatchWriter{
+			Trades:       nil,
+			StagedTrades: nil,
+			Quotes:       diContainer.GetQuotesUC().CreateDailyQuotes,
+			Instruments:  diContainer.GetInstrumentsUC().UpsertInstruments,
+			Events:       diContainer.GetCorporateEventsUC().CreateCorporateEvents,
+		},
+		diContainer.GetTradesUC().StageTrades,
+		filehandler.RetryPolicy{
+			Attempts:      config.GetDBRetryAttempts(),
+			BaseDelay:     config.GetDBRetryBaseDelay(),
+			MaxDelay:      config.GetDBRetryMaxDelay(),
+			MaxPoisonRows: config.GetDBPoisonRowLimit(),
+			Transient:     db.IsTransientError,
+			RowError:      db.IsRowError,
+		},
+		filehandler.NewValidator(ruleActions),
+		config.GetBatchSize(),
+		config.GetDBWorkersCount(),
+		logger,
+	)
+
+	jobs := diContainer.NewIngestionJobsUC(loader)
 
 	server := api.NewServer()
 	server.ConfigureRoutes(
 		diContainer.NewTradesHandler(),
+		diContainer.NewQuotesHandler(),
+		diContainer.NewInstrumentsHandler(),
+		diContainer.NewIngestionsHandler(jobs),
 	)
-	server.Start(config.GetAPIPort())
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	go server.Start(config.GetAPIPort())
+	<-ctx.Done()
+	logger.Info("Received shutdown signal, stopping server...")
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		logger.Error("Error stopping server", zap.Error(err))
+	}
+
+	// Uploads still loading are cancelled and recorded as failed before the process exits.
+	jobs.Shutdown()
+	logger.Info("Ingestion jobs stopped")
 }
@@ -2,6 +2,7 @@ package config
 
 import (
 	"runtime"
+	"time"
 
 	"github.com/pkg/errors"
 	"github.com/spf13/viper"
@@ -24,6 +25,15 @@ func LoadConfig() error {
 	return nil
 }
 
+func Set(key, value string) error {
+	viper.Set(key, value)
+	if err := viper.Unmarshal(&cfg); err != nil {
+		return errors.Wrap(err, "viper unmarshal")
+	}
+
+	return nil
+}
+
 var cfg *Config //nolint:gochecknoglobals
 
 type Config struct {
@@ -32,6 +42,19 @@ type Config struct {
 	ParserWorkersCount int    `mapstructure:"PARSER_WORKER_COUNT"`
 	DBWorkersCount     int    `mapstructure:"DB_WORKER_COUNT"`
 	BatchSize          int    `mapstructure:"BATCH_SIZE"`
+	ParserChunkSizeMB  int64  `mapstructure:"PARSER_CHUNK_SIZE_MB"`
+	ResumeIngestion    bool   `mapstructure:"RESUME_INGESTION"`
+	B3BaseURL          string `mapstructure:"B3_BASE_URL"`
+	RejectsPath        string `mapstructure:"REJECTS_PATH"`
+	WatchSettleSeconds int    `mapstructure:"WATCH_SETTLE_SECONDS"`
+	UploadDir          string `mapstructure:"UPLOAD_DIR"`
+	DBRetryAttempts    int    `mapstructure:"DB_RETRY_ATTEMPTS"`
+	DBRetryBaseDelayMS int    `mapstructure:"DB_RETRY_BASE_DELAY_MS"`
+	DBRetryMaxDelayMS  int    `mapstructure:"DB_RETRY_MAX_DELAY_MS"`
+	DBPoisonRowLimit   int    `mapstructure:"DB_POISON_ROW_LIMIT"`
+	ValidationRules    string `mapstructure:"VALIDATION_RULES"`
+	MetricsPort        uint16 `mapstructure:"METRICS_PORT"`
+	MaxInFlightRows    int64  `mapstructure:"MAX_IN_FLIGHT_ROWS"`
 }
 
 func GetAPIPort() uint16 {
@@ -60,6 +83,35 @@ func GetBatchSize() int {
 	return cfg.BatchSize
 }
 
+func GetParserChunkSize() int64 {
+	const (
+		defaultChunkSizeMB = 64
+		bytesPerMB         = 1 << 20
+	)
+
+	switch {
+	case cfg.ParserChunkSizeMB < 0:
+		return 0
+	case cfg.ParserChunkSizeMB == 0:
+		return defaultChunkSizeMB * bytesPerMB
+	default:
+		return cfg.ParserChunkSizeMB * bytesPerMB
+	}
+}
+
+func GetMaxInFlightRows() int64 {
+	const defaultMaxInFlightRows = 1_000_000
+
+	switch {
+	case cfg.MaxInFlightRows < 0:
+		return 0
+	case cfg.MaxInFlightRows == 0:
+		return defaultMaxInFlightRows
+	default:
+		return cfg.MaxInFlightRows
+	}
+}
+
 func GetDBWorkersCount() int {
 	if cfg.DBWorkersCount == 0 {
 		return runtime.NumCPU()
@@ -67,3 +119,89 @@ func GetDBWorkersCount() int {
 
 	return cfg.DBWorkersCount
 }
+
+func IsResumeIngestionEnabled() bool {
+	return cfg.ResumeIngestion
+}
+
+func GetRejectsPath() string {
+	return cfg.RejectsPath
+}
+
+func GetWatchSettle() time.Duration {
+	const defaultWatchSettle = 5 * time.Second
+
+	if cfg.WatchSettleSeconds <= 0 {
+		return defaultWatchSettle
+	}
+
+	return time.Duration(cfg.WatchSettleSeconds) * time.Second
+}
+
+func GetDBRetryAttempts() int {
+	const defaultRetryAttempts = 5
+
+	if cfg.DBRetryAttempts <= 0 {
+		return defaultRetryAttempts
+	}
+
+	return cfg.DBRetryAttempts
+}
+
+func GetDBRetryBaseDelay() time.Duration {
+	const defaultRetryBaseDelay = 200 * time.Millisecond
+
+	if cfg.DBRetryBaseDelayMS <= 0 {
+		return defaultRetryBaseDelay
+	}
+
+	return time.Duration(cfg.DBRetryBaseDelayMS) * time.Millisecond
+}
+
+func GetDBRetryMaxDelay() time.Duration {
+	const defaultRetryMaxDelay = 10 * time.Second
+
+	if cfg.DBRetryMaxDelayMS <= 0 {
+		return defaultRetryMaxDelay
+	}
+
+	return time.Duration(cfg.DBRetryMaxDelayMS) * time.Millisecond
+}
+
+func GetDBPoisonRowLimit() int {
+	const defaultPoisonRowLimit = 1000
+
+	if cfg.DBPoisonRowLimit <= 0 {
+		return defaultPoisonRowLimit
+	}
+
+	return cfg.DBPoisonRowLimit
+}
+
+func GetValidationRules() string {
+	return cfg.ValidationRules
+}
+
+func GetMetricsPort() uint16 {
+	return cfg.MetricsPort
+}
+
+func GetUploadDir() string {
+	const defaultUploadDir = "uploads"
+
+	if cfg.UploadDir == "" {
+		return defaultUploadDir
+	}
+
+	return cfg.UploadDir
+}
+
+func GetB3BaseURL() string {
+	const defaultB3BaseURL = "https://arquivos.b3.com.br/rapinegocios/tickercsv"
+
+	if cfg.B3BaseURL == "" {
+		return defaultB3BaseURL
+	}
+
+	return cfg.B3BaseURL
+}
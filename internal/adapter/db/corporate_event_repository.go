@@ -0,0 +1,69 @@
+package db
+
+import (
+	"b3challenge/internal/adapter/db/sqlc"
+	"b3challenge/internal/domain/entity"
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/pkg/errors"
+)
+
+const createCorporateEventsStaging = `CREATE TEMPORARY TABLE corporate_events_staging (
+    ticker TEXT NOT NULL,
+    event_type TEXT NOT NULL,
+    ex_date DATE NOT NULL,
+    factor DECIMAL(18, 8) NOT NULL,
+    amount DECIMAL(18, 8) NOT NULL
+) ON COMMIT DROP`
+
+type CorporateEventRepository struct {
+	db      *pgxpool.Pool
+	querier sqlc.Querier
+}
+
+func NewCorporateEventRepository(db *pgxpool.Pool) *CorporateEventRepository {
+	return &CorporateEventRepository{
+		db:      db,
+		querier: sqlc.New(db),
+	}
+}
+
+func (r *CorporateEventRepository) CreateCorporateEvents(
+	ctx context.Context,
+	events []entity.CorporateEvent,
+) (int64, error) {
+	params := sqlc.NewCopyCorporateEventsToStagingParams(events)
+
+	affected, err := mergeThroughStaging(ctx, r.db, createCorporateEventsStaging, func(q *sqlc.Queries) (int64, error) {
+		if _, err := q.CopyCorporateEventsToStaging(ctx, params); err != nil {
+			return 0, errors.Wrap(err, "copy")
+		}
+
+		return q.MergeCorporateEventsFromStaging(ctx)
+	})
+	if err != nil {
+		return 0, errors.Wrap(err, "create")
+	}
+
+	return affected, nil
+}
+
+func (r *CorporateEventRepository) ListCorporateEvents(
+	ctx context.Context,
+	ticker string,
+	after *time.Time,
+) ([]entity.CorporateEvent, error) {
+	rows, err := r.querier.ListCorporateEventsByTicker(ctx, sqlc.NewListCorporateEventsByTickerParams(ticker, after))
+	if err != nil {
+		return nil, errors.Wrap(err, "list")
+	}
+
+	events := make([]entity.CorporateEvent, 0, len(rows))
+	for _, row := range rows {
+		events = append(events, row.ToCorporateEvent())
+	}
+
+	return events, nil
+}
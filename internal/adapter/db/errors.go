@@ -0,0 +1,63 @@
+package db
+
+import (
+	"context"
+	"io"
+	"net"
+	"strings"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/pkg/errors"
+)
+
+const (
+	connectionExceptionClass = "08"
+	dataExceptionClass       = "22"
+	integrityConstraintClass = "23"
+)
+
+//nolint:gochecknoglobals
+var transientSQLStates = map[string]bool{
+	"40001": true, // serialization_failure
+	"40P01": true, // deadlock_detected
+	"53300": true, // too_many_connections
+	"55P03": true, // lock_not_available
+	"57P01": true, // admin_shutdown
+	"57P02": true, // crash_shutdown
+	"57P03": true, // cannot_connect_now
+}
+
+// IsTransientError reports whether err is worth retrying as is: lost or refused connections,
+// serialization failures, deadlocks and server restarts. Constraint violations, invalid data and
+// cancelled contexts are permanent.
+func IsTransientError(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		return transientSQLStates[pgErr.Code] || strings.HasPrefix(pgErr.Code, connectionExceptionClass)
+	}
+
+	var connectErr *pgconn.ConnectError
+	var netErr net.Error
+
+	return errors.As(err, &connectErr) ||
+		errors.As(err, &netErr) ||
+		errors.Is(err, io.ErrUnexpectedEOF) ||
+		pgconn.SafeToRetry(err) ||
+		pgconn.Timeout(err)
+}
+
+// IsRowError reports whether err was caused by the data of a specific row: data exceptions (SQLSTATE
+// class 22, e.g. numeric overflow or invalid text) and integrity constraint violations (class 23).
+// Only these are worth narrowing down to the offending rows; anything else fails the whole batch.
+func IsRowError(err error) bool {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) {
+		return false
+	}
+
+	return strings.HasPrefix(pgErr.Code, dataExceptionClass) || strings.HasPrefix(pgErr.Code, integrityConstraintClass)
+}
@@ -0,0 +1,63 @@
+package db
+
+import (
+	"context"
+	"io"
+	"net"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsTransientError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{name: "nil", err: nil, want: false},
+		{name: "deadlock", err: errors.Wrap(&pgconn.PgError{Code: "40P01"}, "merge trades"), want: true},
+		{name: "serialization failure", err: &pgconn.PgError{Code: "40001"}, want: true},
+		{name: "connection failure", err: &pgconn.PgError{Code: "08006"}, want: true},
+		{name: "admin shutdown", err: &pgconn.PgError{Code: "57P01"}, want: true},
+		{name: "numeric overflow", err: errors.Wrap(&pgconn.PgError{Code: "22003"}, "copy trades"), want: false},
+		{name: "not null violation", err: &pgconn.PgError{Code: "23502"}, want: false},
+		{name: "network", err: errors.Wrap(&net.OpError{Op: "read", Err: io.EOF}, "copy trades"), want: true},
+		{name: "unexpected eof", err: errors.Wrap(io.ErrUnexpectedEOF, "copy trades"), want: true},
+		{name: "cancelled", err: errors.Wrap(context.Canceled, "copy trades"), want: false},
+		{name: "plain", err: errors.New("invalid input"), want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, IsTransientError(tt.err))
+		})
+	}
+}
+
+func TestIsRowError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{name: "nil", err: nil, want: false},
+		{name: "numeric overflow", err: errors.Wrap(&pgconn.PgError{Code: "22003"}, "copy trades"), want: true},
+		{name: "invalid text", err: &pgconn.PgError{Code: "22P02"}, want: true},
+		{name: "not null violation", err: &pgconn.PgError{Code: "23502"}, want: true},
+		{name: "no partition", err: &pgconn.PgError{Code: "23514"}, want: true},
+		{name: "undefined table", err: errors.Wrap(&pgconn.PgError{Code: "42P01"}, "copy trades"), want: false},
+		{name: "disk full", err: &pgconn.PgError{Code: "53100"}, want: false},
+		{name: "partition detached", err: errors.Wrap(&pgconn.PgError{Code: "55000"}, "ensure partition"), want: false},
+		{name: "deadlock", err: &pgconn.PgError{Code: "40P01"}, want: false},
+		{name: "plain", err: errors.New("invalid input"), want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, IsRowError(tt.err))
+		})
+	}
+}
@@ -0,0 +1,103 @@
+package db
+
+import (
+	"b3challenge/internal/adapter/db/sqlc"
+	"b3challenge/internal/domain/entity"
+	"context"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/pkg/errors"
+)
+
+type IngestionRepository struct {
+	db      *pgxpool.Pool
+	querier sqlc.Querier
+}
+
+func NewIngestionRepository(db *pgxpool.Pool) *IngestionRepository {
+	return &IngestionRepository{
+		db:      db,
+		querier: sqlc.New(db),
+	}
+}
+
+func (r *IngestionRepository) CreateIngestion(ctx context.Context, ingestion *entity.Ingestion) (int32, error) {
+	params := sqlc.NewCreateIngestionParams(ingestion)
+
+	id, err := r.querier.CreateIngestion(ctx, params)
+	if err != nil {
+		return 0, errors.Wrap(err, "create")
+	}
+
+	return id, nil
+}
+
+func (r *IngestionRepository) FinishIngestion(ctx context.Context, ingestion *entity.Ingestion) error {
+	params := sqlc.NewFinishIngestionParams(ingestion)
+
+	if err := r.querier.FinishIngestion(ctx, params); err != nil {
+		return errors.Wrap(err, "finish")
+	}
+
+	return nil
+}
+
+func (r *IngestionRepository) GetIngestion(ctx context.Context, id int32) (*entity.Ingestion, error) {
+	ingestion, err := r.querier.GetIngestion(ctx, id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil //nolint:nilnil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "get")
+	}
+
+	return ingestion.ToIngestion(), nil
+}
+
+func (r *IngestionRepository) GetResumableIngestion(
+	ctx context.Context,
+	path, checksum string,
+) (*entity.Ingestion, error) {
+	params := sqlc.GetResumableIngestionParams{
+		Path:     path,
+		Checksum: checksum,
+	}
+
+	ingestion, err := r.querier.GetResumableIngestion(ctx, params)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil //nolint:nilnil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "get resumable")
+	}
+
+	return ingestion.ToIngestion(), nil
+}
+
+func (r *IngestionRepository) HasSucceededIngestion(ctx context.Context, path, checksum string) (bool, error) {
+	params := sqlc.HasSucceededIngestionParams{
+		Path:     path,
+		Checksum: checksum,
+	}
+
+	exists, err := r.querier.HasSucceededIngestion(ctx, params)
+	if err != nil {
+		return false, errors.Wrap(err, "has succeeded")
+	}
+
+	return exists, nil
+}
+
+func (r *IngestionRepository) UpdateIngestionCheckpoint(ctx context.Context, id int32, line int64) error {
+	params := sqlc.UpdateIngestionCheckpointParams{
+		ID:             id,
+		CheckpointLine: line,
+	}
+
+	if err := r.querier.UpdateIngestionCheckpoint(ctx, params); err != nil {
+		return errors.Wrap(err, "update checkpoint")
+	}
+
+	return nil
+}
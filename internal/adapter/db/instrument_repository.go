@@ -0,0 +1,68 @@
+package db
+
+import (
+	"b3challenge/internal/adapter/db/sqlc"
+	"b3challenge/internal/domain/entity"
+	"context"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/pkg/errors"
+)
+
+const createInstrumentsStaging = `CREATE TEMPORARY TABLE instruments_staging (
+    reference_date DATE NOT NULL,
+    ticker TEXT NOT NULL,
+    isin TEXT NOT NULL,
+    asset TEXT NOT NULL,
+    issuer_name TEXT NOT NULL,
+    security_type TEXT NOT NULL,
+    segment TEXT NOT NULL,
+    market TEXT NOT NULL,
+    specification TEXT NOT NULL,
+    currency TEXT NOT NULL,
+    lot_size BIGINT NOT NULL,
+    trading_start_date DATE,
+    trading_end_date DATE
+) ON COMMIT DROP`
+
+type InstrumentRepository struct {
+	db      *pgxpool.Pool
+	querier sqlc.Querier
+}
+
+func NewInstrumentRepository(db *pgxpool.Pool) *InstrumentRepository {
+	return &InstrumentRepository{
+		db:      db,
+		querier: sqlc.New(db),
+	}
+}
+
+func (r *InstrumentRepository) UpsertInstruments(ctx context.Context, instruments []entity.Instrument) (int64, error) {
+	params := sqlc.NewCopyInstrumentsToStagingParams(instruments)
+
+	affected, err := mergeThroughStaging(ctx, r.db, createInstrumentsStaging, func(q *sqlc.Queries) (int64, error) {
+		if _, err := q.CopyInstrumentsToStaging(ctx, params); err != nil {
+			return 0, errors.Wrap(err, "copy")
+		}
+
+		return q.MergeInstrumentsFromStaging(ctx)
+	})
+	if err != nil {
+		return 0, errors.Wrap(err, "upsert")
+	}
+
+	return affected, nil
+}
+
+func (r *InstrumentRepository) GetInstrument(ctx context.Context, ticker string) (*entity.Instrument, error) {
+	instrument, err := r.querier.GetInstrumentByTicker(ctx, ticker)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil //nolint:nilnil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "get")
+	}
+
+	return instrument.ToInstrument(), nil
+}
@@ -0,0 +1,33 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE trades
+    ADD COLUMN reference_date DATE,
+    ADD COLUMN update_action  SMALLINT NOT NULL DEFAULT 0,
+    ADD COLUMN trade_id       BIGINT,
+    ADD COLUMN session_type   SMALLINT NOT NULL DEFAULT 0,
+    ADD COLUMN buyer_code     INTEGER,
+    ADD COLUMN seller_code    INTEGER;
+
+-- Trades loaded before these columns existed carry no B3 identifiers: fall back to the trade date
+-- and to the surrogate id so the natural key stays unique.
+UPDATE trades
+SET reference_date = date,
+    trade_id       = id;
+
+ALTER TABLE trades
+    ALTER COLUMN reference_date SET NOT NULL,
+    ALTER COLUMN trade_id SET NOT NULL,
+    ALTER COLUMN update_action DROP DEFAULT,
+    ALTER COLUMN session_type DROP DEFAULT;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE trades
+    DROP COLUMN seller_code,
+    DROP COLUMN buyer_code,
+    DROP COLUMN session_type,
+    DROP COLUMN trade_id,
+    DROP COLUMN update_action,
+    DROP COLUMN reference_date;
+-- +goose StatementEnd
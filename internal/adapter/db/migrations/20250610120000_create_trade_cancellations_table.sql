@@ -0,0 +1,20 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE trade_cancellations
+(
+    id         SERIAL PRIMARY KEY,
+    ticker     TEXT   NOT NULL,
+    date       DATE   NOT NULL,
+    trade_id   BIGINT NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT now()
+);
+
+CREATE INDEX idx_trade_cancellations_key ON trade_cancellations (ticker, date, trade_id);
+CREATE INDEX idx_trades_key ON trades (ticker, date, trade_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX idx_trades_key;
+DROP TABLE trade_cancellations;
+-- +goose StatementEnd
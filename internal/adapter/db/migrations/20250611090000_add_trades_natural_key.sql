@@ -0,0 +1,31 @@
+-- +goose Up
+-- +goose StatementBegin
+DELETE FROM trades a
+USING trades b
+WHERE a.id > b.id
+  AND a.ticker = b.ticker
+  AND a.date = b.date
+  AND a.trade_id = b.trade_id;
+
+DELETE FROM trade_cancellations a
+USING trade_cancellations b
+WHERE a.id > b.id
+  AND a.ticker = b.ticker
+  AND a.date = b.date
+  AND a.trade_id = b.trade_id;
+
+DROP INDEX idx_trades_key;
+DROP INDEX idx_trade_cancellations_key;
+
+CREATE UNIQUE INDEX uq_trades_key ON trades (ticker, date, trade_id);
+CREATE UNIQUE INDEX uq_trade_cancellations_key ON trade_cancellations (ticker, date, trade_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX uq_trade_cancellations_key;
+DROP INDEX uq_trades_key;
+
+CREATE INDEX idx_trade_cancellations_key ON trade_cancellations (ticker, date, trade_id);
+CREATE INDEX idx_trades_key ON trades (ticker, date, trade_id);
+-- +goose StatementEnd
@@ -0,0 +1,25 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE ingestions
+(
+    id            SERIAL PRIMARY KEY,
+    path          TEXT        NOT NULL,
+    size_bytes    BIGINT      NOT NULL,
+    checksum      TEXT        NOT NULL,
+    status        TEXT        NOT NULL,
+    rows_parsed   BIGINT      NOT NULL DEFAULT 0,
+    rows_rejected BIGINT      NOT NULL DEFAULT 0,
+    rows_inserted BIGINT      NOT NULL DEFAULT 0,
+    started_at    TIMESTAMPTZ NOT NULL,
+    finished_at   TIMESTAMPTZ,
+    created_at    TIMESTAMPTZ DEFAULT now(),
+    updated_at    TIMESTAMPTZ DEFAULT now()
+);
+
+CREATE INDEX idx_ingestions_path_checksum ON ingestions (path, checksum);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE ingestions;
+-- +goose StatementEnd
@@ -0,0 +1,11 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE ingestions
+    ADD COLUMN checkpoint_line BIGINT NOT NULL DEFAULT 0;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE ingestions
+    DROP COLUMN checkpoint_line;
+-- +goose StatementEnd
@@ -0,0 +1,98 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION ensure_trades_partition(p_date DATE) RETURNS TEXT AS
+$$
+DECLARE
+    month_start    DATE := date_trunc('month', p_date)::DATE;
+    partition_name TEXT := format('trades_p%s', to_char(month_start, 'YYYYMM'));
+BEGIN
+    IF to_regclass(partition_name) IS NULL THEN
+        PERFORM pg_advisory_xact_lock(hashtext('ensure_trades_partition'));
+        EXECUTE format(
+            'CREATE TABLE IF NOT EXISTS %I PARTITION OF trades FOR VALUES FROM (%L) TO (%L)',
+            partition_name,
+            month_start,
+            (month_start + INTERVAL '1 month')::DATE
+        );
+    END IF;
+
+    RETURN partition_name;
+END;
+$$ LANGUAGE plpgsql;
+
+ALTER TABLE trades RENAME TO trades_unpartitioned;
+ALTER SEQUENCE trades_id_seq OWNED BY NONE;
+
+CREATE TABLE trades
+(
+    id             INTEGER        NOT NULL DEFAULT nextval('trades_id_seq'),
+    reference_date DATE           NOT NULL,
+    ticker         TEXT           NOT NULL,
+    update_action  SMALLINT       NOT NULL,
+    price          DECIMAL(18, 3) NOT NULL,
+    quantity       INTEGER        NOT NULL,
+    hour           TEXT           NOT NULL,
+    trade_id       BIGINT         NOT NULL,
+    session_type   SMALLINT       NOT NULL,
+    date           DATE           NOT NULL,
+    buyer_code     INTEGER,
+    seller_code    INTEGER,
+    created_at     TIMESTAMPTZ DEFAULT now(),
+    updated_at     TIMESTAMPTZ DEFAULT now(),
+    PRIMARY KEY (id, date)
+) PARTITION BY RANGE (date);
+
+SELECT ensure_trades_partition(month)
+FROM (SELECT DISTINCT date_trunc('month', date)::DATE AS month FROM trades_unpartitioned) months;
+
+INSERT INTO trades (
+    id, reference_date, ticker, update_action, price, quantity, hour,
+    trade_id, session_type, date, buyer_code, seller_code, created_at, updated_at
+)
+SELECT
+    id, reference_date, ticker, update_action, price, quantity, hour,
+    trade_id, session_type, date, buyer_code, seller_code, created_at, updated_at
+FROM trades_unpartitioned;
+
+DROP TABLE trades_unpartitioned;
+ALTER SEQUENCE trades_id_seq OWNED BY trades.id;
+
+CREATE INDEX idx_trades_ticker_date ON trades (ticker, date);
+CREATE INDEX idx_trades_date ON trades (date);
+CREATE UNIQUE INDEX uq_trades_key ON trades (ticker, date, trade_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE trades RENAME TO trades_partitioned;
+ALTER SEQUENCE trades_id_seq OWNED BY NONE;
+
+CREATE TABLE trades
+(
+    id             INTEGER        NOT NULL DEFAULT nextval('trades_id_seq') PRIMARY KEY,
+    reference_date DATE           NOT NULL,
+    ticker         TEXT           NOT NULL,
+    update_action  SMALLINT       NOT NULL,
+    price          DECIMAL(18, 3) NOT NULL,
+    quantity       INTEGER        NOT NULL,
+    hour           TEXT           NOT NULL,
+    trade_id       BIGINT         NOT NULL,
+    session_type   SMALLINT       NOT NULL,
+    date           DATE           NOT NULL,
+    buyer_code     INTEGER,
+    seller_code    INTEGER,
+    created_at     TIMESTAMPTZ DEFAULT now(),
+    updated_at     TIMESTAMPTZ DEFAULT now()
+);
+
+INSERT INTO trades
+SELECT * FROM trades_partitioned;
+
+DROP TABLE trades_partitioned;
+ALTER SEQUENCE trades_id_seq OWNED BY trades.id;
+DROP FUNCTION ensure_trades_partition(DATE);
+
+CREATE INDEX idx_trades_ticker ON trades (ticker);
+CREATE INDEX idx_trades_date ON trades (date);
+CREATE UNIQUE INDEX uq_trades_key ON trades (ticker, date, trade_id);
+-- +goose StatementEnd
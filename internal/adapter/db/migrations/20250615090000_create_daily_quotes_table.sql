@@ -0,0 +1,39 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE daily_quotes
+(
+    id                SERIAL PRIMARY KEY,
+    date              DATE           NOT NULL,
+    ticker            TEXT           NOT NULL,
+    bdi_code          SMALLINT       NOT NULL,
+    market_type       SMALLINT       NOT NULL,
+    company_name      TEXT           NOT NULL,
+    specification     TEXT           NOT NULL,
+    forward_term_days SMALLINT       NOT NULL DEFAULT 0,
+    currency          TEXT           NOT NULL,
+    open_price        DECIMAL(18, 2) NOT NULL,
+    max_price         DECIMAL(18, 2) NOT NULL,
+    min_price         DECIMAL(18, 2) NOT NULL,
+    avg_price         DECIMAL(18, 2) NOT NULL,
+    close_price       DECIMAL(18, 2) NOT NULL,
+    best_bid_price    DECIMAL(18, 2) NOT NULL,
+    best_ask_price    DECIMAL(18, 2) NOT NULL,
+    trades_count      INTEGER        NOT NULL,
+    quantity          BIGINT         NOT NULL,
+    volume            DECIMAL(20, 2) NOT NULL,
+    strike_price      DECIMAL(18, 2) NOT NULL,
+    expiration_date   DATE,
+    quote_factor      INTEGER        NOT NULL,
+    isin              TEXT           NOT NULL,
+    created_at        TIMESTAMPTZ DEFAULT now(),
+    updated_at        TIMESTAMPTZ DEFAULT now()
+);
+
+CREATE UNIQUE INDEX uq_daily_quotes_key ON daily_quotes (ticker, date, market_type, bdi_code, forward_term_days);
+CREATE INDEX idx_daily_quotes_date ON daily_quotes (date);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE daily_quotes;
+-- +goose StatementEnd
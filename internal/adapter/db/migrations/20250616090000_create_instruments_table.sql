@@ -0,0 +1,30 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE instruments
+(
+    id                 SERIAL PRIMARY KEY,
+    reference_date     DATE   NOT NULL,
+    ticker             TEXT   NOT NULL,
+    isin               TEXT   NOT NULL,
+    asset              TEXT   NOT NULL,
+    issuer_name        TEXT   NOT NULL,
+    security_type      TEXT   NOT NULL,
+    segment            TEXT   NOT NULL,
+    market             TEXT   NOT NULL,
+    specification      TEXT   NOT NULL,
+    currency           TEXT   NOT NULL,
+    lot_size           BIGINT NOT NULL,
+    trading_start_date DATE,
+    trading_end_date   DATE,
+    created_at         TIMESTAMPTZ DEFAULT now(),
+    updated_at         TIMESTAMPTZ DEFAULT now()
+);
+
+CREATE UNIQUE INDEX uq_instruments_ticker ON instruments (ticker);
+CREATE INDEX idx_instruments_isin ON instruments (isin);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE instruments;
+-- +goose StatementEnd
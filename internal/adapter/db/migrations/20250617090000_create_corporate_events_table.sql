@@ -0,0 +1,21 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE corporate_events
+(
+    id         SERIAL PRIMARY KEY,
+    ticker     TEXT           NOT NULL,
+    event_type TEXT           NOT NULL,
+    ex_date    DATE           NOT NULL,
+    factor     DECIMAL(18, 8) NOT NULL,
+    amount     DECIMAL(18, 8) NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT now(),
+    updated_at TIMESTAMPTZ DEFAULT now()
+);
+
+CREATE UNIQUE INDEX uq_corporate_events_key ON corporate_events (ticker, ex_date, event_type);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE corporate_events;
+-- +goose StatementEnd
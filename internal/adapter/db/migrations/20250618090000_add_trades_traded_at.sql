@@ -0,0 +1,29 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE trades ADD COLUMN traded_at TIMESTAMPTZ;
+
+UPDATE trades
+SET traded_at = CASE
+    WHEN hour ~ '^([01][0-9]|2[0-3])[0-5][0-9][0-5][0-9]$'
+        THEN (date + to_timestamp(hour, 'HH24MISS')::TIME) AT TIME ZONE 'America/Sao_Paulo'
+    ELSE date::TIMESTAMP AT TIME ZONE 'America/Sao_Paulo'
+END;
+
+ALTER TABLE trades ALTER COLUMN traded_at SET NOT NULL;
+ALTER TABLE trades DROP COLUMN hour;
+
+CREATE INDEX idx_trades_ticker_traded_at ON trades (ticker, traded_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX idx_trades_ticker_traded_at;
+
+ALTER TABLE trades ADD COLUMN hour TEXT;
+
+UPDATE trades
+SET hour = to_char(traded_at AT TIME ZONE 'America/Sao_Paulo', 'HH24MISS');
+
+ALTER TABLE trades ALTER COLUMN hour SET NOT NULL;
+ALTER TABLE trades DROP COLUMN traded_at;
+-- +goose StatementEnd
@@ -0,0 +1,26 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE trades_file_staging
+(
+    ingestion_id   INTEGER        NOT NULL REFERENCES ingestions (id) ON DELETE CASCADE,
+    line           BIGINT         NOT NULL,
+    reference_date DATE           NOT NULL,
+    ticker         TEXT           NOT NULL,
+    update_action  SMALLINT       NOT NULL,
+    price          DECIMAL(18, 3) NOT NULL,
+    quantity       INTEGER        NOT NULL,
+    traded_at      TIMESTAMPTZ    NOT NULL,
+    trade_id       BIGINT         NOT NULL,
+    session_type   SMALLINT       NOT NULL,
+    date           DATE           NOT NULL,
+    buyer_code     INTEGER,
+    seller_code    INTEGER
+);
+
+CREATE INDEX idx_trades_file_staging_ingestion_line ON trades_file_staging (ingestion_id, line);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE trades_file_staging;
+-- +goose StatementEnd
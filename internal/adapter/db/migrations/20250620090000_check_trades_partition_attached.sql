@@ -0,0 +1,66 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION ensure_trades_partition(p_date DATE) RETURNS TEXT AS
+$$
+DECLARE
+    month_start    DATE := date_trunc('month', p_date)::DATE;
+    partition_name TEXT := format('trades_p%s', to_char(month_start, 'YYYYMM'));
+    partition_oid  REGCLASS := to_regclass(partition_name);
+BEGIN
+    IF partition_oid IS NOT NULL AND EXISTS (
+        SELECT 1 FROM pg_inherits WHERE inhrelid = partition_oid AND inhparent = 'trades'::REGCLASS
+    ) THEN
+        RETURN partition_name;
+    END IF;
+
+    PERFORM pg_advisory_xact_lock(hashtext('ensure_trades_partition'));
+
+    partition_oid := to_regclass(partition_name);
+    IF partition_oid IS NULL THEN
+        EXECUTE format(
+            'CREATE TABLE %I PARTITION OF trades FOR VALUES FROM (%L) TO (%L)',
+            partition_name,
+            month_start,
+            (month_start + INTERVAL '1 month')::DATE
+        );
+    ELSIF NOT EXISTS (
+        SELECT 1 FROM pg_inherits WHERE inhrelid = partition_oid AND inhparent = 'trades'::REGCLASS
+    ) THEN
+        RAISE EXCEPTION 'partition % exists but is not attached to trades', partition_name
+            USING ERRCODE = 'object_not_in_prerequisite_state',
+                HINT = format(
+                    'Finish archiving it (drop it) or re-attach it with ALTER TABLE trades ATTACH PARTITION %I '
+                        || 'FOR VALUES FROM (%L) TO (%L) before loading trades of that month.',
+                    partition_name,
+                    month_start,
+                    (month_start + INTERVAL '1 month')::DATE
+                );
+    END IF;
+
+    RETURN partition_name;
+END;
+$$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION ensure_trades_partition(p_date DATE) RETURNS TEXT AS
+$$
+DECLARE
+    month_start    DATE := date_trunc('month', p_date)::DATE;
+    partition_name TEXT := format('trades_p%s', to_char(month_start, 'YYYYMM'));
+BEGIN
+    IF to_regclass(partition_name) IS NULL THEN
+        PERFORM pg_advisory_xact_lock(hashtext('ensure_trades_partition'));
+        EXECUTE format(
+            'CREATE TABLE IF NOT EXISTS %I PARTITION OF trades FOR VALUES FROM (%L) TO (%L)',
+            partition_name,
+            month_start,
+            (month_start + INTERVAL '1 month')::DATE
+        );
+    END IF;
+
+    RETURN partition_name;
+END;
+$$ LANGUAGE plpgsql;
+-- +goose StatementEnd
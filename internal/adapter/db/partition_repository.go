@@ -0,0 +1,95 @@
+package db
+
+import (
+	"b3challenge/internal/adapter/db/sqlc"
+	"b3challenge/internal/domain/entity"
+	"context"
+	"io"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/pkg/errors"
+)
+
+type PartitionRepository struct {
+	db      *pgxpool.Pool
+	querier sqlc.Querier
+}
+
+func NewPartitionRepository(db *pgxpool.Pool) *PartitionRepository {
+	return &PartitionRepository{
+		db:      db,
+		querier: sqlc.New(db),
+	}
+}
+
+func (r *PartitionRepository) EnsureTradePartition(ctx context.Context, date time.Time) (string, error) {
+	name, err := r.querier.EnsureTradesPartition(ctx, sqlc.NewDate(date))
+	if err != nil {
+		return "", errors.Wrap(err, "ensure partition")
+	}
+
+	return name, nil
+}
+
+func (r *PartitionRepository) ListTradePartitions(ctx context.Context) ([]entity.TradePartition, error) {
+	rows, err := r.querier.ListTradePartitions(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "list partitions")
+	}
+
+	partitions := make([]entity.TradePartition, 0, len(rows))
+	for _, row := range rows {
+		if partition := row.ToTradePartition(); partition != nil {
+			partitions = append(partitions, *partition)
+		}
+	}
+
+	return partitions, nil
+}
+
+func (r *PartitionRepository) DetachTradePartition(ctx context.Context, name string) error {
+	statement := "ALTER TABLE trades DETACH PARTITION " + pgx.Identifier{name}.Sanitize()
+	if _, err := r.db.Exec(ctx, statement); err != nil {
+		return errors.Wrap(err, "detach partition")
+	}
+
+	return nil
+}
+
+func (r *PartitionRepository) AttachTradePartition(ctx context.Context, partition entity.TradePartition) error {
+	statement := "ALTER TABLE trades ATTACH PARTITION " + pgx.Identifier{partition.Name}.Sanitize() +
+		" FOR VALUES FROM ('" + partition.From.Format(time.DateOnly) + "') TO ('" + partition.To.Format(time.DateOnly) + "')"
+	if _, err := r.db.Exec(ctx, statement); err != nil {
+		return errors.Wrap(err, "attach partition")
+	}
+
+	return nil
+}
+
+func (r *PartitionRepository) DropTradePartition(ctx context.Context, name string) error {
+	statement := "DROP TABLE IF EXISTS " + pgx.Identifier{name}.Sanitize()
+	if _, err := r.db.Exec(ctx, statement); err != nil {
+		return errors.Wrap(err, "drop partition")
+	}
+
+	return nil
+}
+
+func (r *PartitionRepository) ExportTradePartition(ctx context.Context, name string, out io.Writer) (int64, error) {
+	conn, err := r.db.Acquire(ctx)
+	if err != nil {
+		return 0, errors.Wrap(err, "acquire")
+	}
+	defer conn.Release()
+
+	statement := "COPY " + pgx.Identifier{name}.Sanitize() + " TO STDOUT WITH (FORMAT csv, HEADER true)"
+
+	tag, err := conn.Conn().PgConn().CopyTo(ctx, out, statement)
+	if err != nil {
+		return 0, errors.Wrap(err, "copy partition")
+	}
+
+	return tag.RowsAffected(), nil
+}
@@ -0,0 +1,83 @@
+package db
+
+import (
+	"b3challenge/internal/adapter/db/sqlc"
+	"b3challenge/internal/domain/entity"
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/pkg/errors"
+)
+
+const createDailyQuotesStaging = `CREATE TEMPORARY TABLE daily_quotes_staging (
+    date DATE NOT NULL,
+    ticker TEXT NOT NULL,
+    bdi_code SMALLINT NOT NULL,
+    market_type SMALLINT NOT NULL,
+    company_name TEXT NOT NULL,
+    specification TEXT NOT NULL,
+    forward_term_days SMALLINT NOT NULL,
+    currency TEXT NOT NULL,
+    open_price DECIMAL(18, 2) NOT NULL,
+    max_price DECIMAL(18, 2) NOT NULL,
+    min_price DECIMAL(18, 2) NOT NULL,
+    avg_price DECIMAL(18, 2) NOT NULL,
+    close_price DECIMAL(18, 2) NOT NULL,
+    best_bid_price DECIMAL(18, 2) NOT NULL,
+    best_ask_price DECIMAL(18, 2) NOT NULL,
+    trades_count INTEGER NOT NULL,
+    quantity BIGINT NOT NULL,
+    volume DECIMAL(20, 2) NOT NULL,
+    strike_price DECIMAL(18, 2) NOT NULL,
+    expiration_date DATE,
+    quote_factor INTEGER NOT NULL,
+    isin TEXT NOT NULL
+) ON COMMIT DROP`
+
+type QuoteRepository struct {
+	db      *pgxpool.Pool
+	querier sqlc.Querier
+}
+
+func NewQuoteRepository(db *pgxpool.Pool) *QuoteRepository {
+	return &QuoteRepository{
+		db:      db,
+		querier: sqlc.New(db),
+	}
+}
+
+func (r *QuoteRepository) CreateDailyQuotes(ctx context.Context, quotes []entity.DailyQuote) (int64, error) {
+	params := sqlc.NewCopyDailyQuotesToStagingParams(quotes)
+
+	affected, err := mergeThroughStaging(ctx, r.db, createDailyQuotesStaging, func(q *sqlc.Queries) (int64, error) {
+		if _, err := q.CopyDailyQuotesToStaging(ctx, params); err != nil {
+			return 0, errors.Wrap(err, "copy")
+		}
+
+		return q.MergeDailyQuotesFromStaging(ctx)
+	})
+	if err != nil {
+		return 0, errors.Wrap(err, "create")
+	}
+
+	return affected, nil
+}
+
+func (r *QuoteRepository) ListDailyQuotes(
+	ctx context.Context,
+	ticker string,
+	from, to *time.Time,
+) ([]entity.DailyQuote, error) {
+	rows, err := r.querier.ListDailyQuotesByTicker(ctx, sqlc.NewListDailyQuotesByTickerParams(ticker, from, to))
+	if err != nil {
+		return nil, errors.Wrap(err, "list")
+	}
+
+	quotes := make([]entity.DailyQuote, 0, len(rows))
+	for _, row := range rows {
+		quotes = append(quotes, row.ToDailyQuote())
+	}
+
+	return quotes, nil
+}
@@ -9,13 +9,13 @@ import (
 	"context"
 )
 
-// iteratorForCreateTrades implements pgx.CopyFromSource.
-type iteratorForCreateTrades struct {
-	rows                 []CreateTradesParams
+// iteratorForCopyCorporateEventsToStaging implements pgx.CopyFromSource.
+type iteratorForCopyCorporateEventsToStaging struct {
+	rows                 []CopyCorporateEventsToStagingParams
 	skippedFirstNextCall bool
 }
 
-func (r *iteratorForCreateTrades) Next() bool {
+func (r *iteratorForCopyCorporateEventsToStaging) Next() bool {
 	if len(r.rows) == 0 {
 		return false
 	}
@@ -27,20 +27,237 @@ func (r *iteratorForCreateTrades) Next() bool {
 	return len(r.rows) > 0
 }
 
-func (r iteratorForCreateTrades) Values() ([]interface{}, error) {
+func (r iteratorForCopyCorporateEventsToStaging) Values() ([]interface{}, error) {
 	return []interface{}{
-		r.rows[0].Hour,
+		r.rows[0].Ticker,
+		r.rows[0].EventType,
+		r.rows[0].ExDate,
+		r.rows[0].Factor,
+		r.rows[0].Amount,
+	}, nil
+}
+
+func (r iteratorForCopyCorporateEventsToStaging) Err() error {
+	return nil
+}
+
+func (q *Queries) CopyCorporateEventsToStaging(ctx context.Context, arg []CopyCorporateEventsToStagingParams) (int64, error) {
+	return q.db.CopyFrom(ctx, []string{"corporate_events_staging"}, []string{"ticker", "event_type", "ex_date", "factor", "amount"}, &iteratorForCopyCorporateEventsToStaging{rows: arg})
+}
+
+// iteratorForCopyDailyQuotesToStaging implements pgx.CopyFromSource.
+type iteratorForCopyDailyQuotesToStaging struct {
+	rows                 []CopyDailyQuotesToStagingParams
+	skippedFirstNextCall bool
+}
+
+func (r *iteratorForCopyDailyQuotesToStaging) Next() bool {
+	if len(r.rows) == 0 {
+		return false
+	}
+	if !r.skippedFirstNextCall {
+		r.skippedFirstNextCall = true
+		return true
+	}
+	r.rows = r.rows[1:]
+	return len(r.rows) > 0
+}
+
+func (r iteratorForCopyDailyQuotesToStaging) Values() ([]interface{}, error) {
+	return []interface{}{
+		r.rows[0].Date,
+		r.rows[0].Ticker,
+		r.rows[0].BdiCode,
+		r.rows[0].MarketType,
+		r.rows[0].CompanyName,
+		r.rows[0].Specification,
+		r.rows[0].ForwardTermDays,
+		r.rows[0].Currency,
+		r.rows[0].OpenPrice,
+		r.rows[0].MaxPrice,
+		r.rows[0].MinPrice,
+		r.rows[0].AvgPrice,
+		r.rows[0].ClosePrice,
+		r.rows[0].BestBidPrice,
+		r.rows[0].BestAskPrice,
+		r.rows[0].TradesCount,
+		r.rows[0].Quantity,
+		r.rows[0].Volume,
+		r.rows[0].StrikePrice,
+		r.rows[0].ExpirationDate,
+		r.rows[0].QuoteFactor,
+		r.rows[0].Isin,
+	}, nil
+}
+
+func (r iteratorForCopyDailyQuotesToStaging) Err() error {
+	return nil
+}
+
+func (q *Queries) CopyDailyQuotesToStaging(ctx context.Context, arg []CopyDailyQuotesToStagingParams) (int64, error) {
+	return q.db.CopyFrom(ctx, []string{"daily_quotes_staging"}, []string{"date", "ticker", "bdi_code", "market_type", "company_name", "specification", "forward_term_days", "currency", "open_price", "max_price", "min_price", "avg_price", "close_price", "best_bid_price", "best_ask_price", "trades_count", "quantity", "volume", "strike_price", "expiration_date", "quote_factor", "isin"}, &iteratorForCopyDailyQuotesToStaging{rows: arg})
+}
+
+// iteratorForCopyInstrumentsToStaging implements pgx.CopyFromSource.
+type iteratorForCopyInstrumentsToStaging struct {
+	rows                 []CopyInstrumentsToStagingParams
+	skippedFirstNextCall bool
+}
+
+func (r *iteratorForCopyInstrumentsToStaging) Next() bool {
+	if len(r.rows) == 0 {
+		return false
+	}
+	if !r.skippedFirstNextCall {
+		r.skippedFirstNextCall = true
+		return true
+	}
+	r.rows = r.rows[1:]
+	return len(r.rows) > 0
+}
+
+func (r iteratorForCopyInstrumentsToStaging) Values() ([]interface{}, error) {
+	return []interface{}{
+		r.rows[0].ReferenceDate,
+		r.rows[0].Ticker,
+		r.rows[0].Isin,
+		r.rows[0].Asset,
+		r.rows[0].IssuerName,
+		r.rows[0].SecurityType,
+		r.rows[0].Segment,
+		r.rows[0].Market,
+		r.rows[0].Specification,
+		r.rows[0].Currency,
+		r.rows[0].LotSize,
+		r.rows[0].TradingStartDate,
+		r.rows[0].TradingEndDate,
+	}, nil
+}
+
+func (r iteratorForCopyInstrumentsToStaging) Err() error {
+	return nil
+}
+
+func (q *Queries) CopyInstrumentsToStaging(ctx context.Context, arg []CopyInstrumentsToStagingParams) (int64, error) {
+	return q.db.CopyFrom(ctx, []string{"instruments_staging"}, []string{"reference_date", "ticker", "isin", "asset", "issuer_name", "security_type", "segment", "market", "specification", "currency", "lot_size", "trading_start_date", "trading_end_date"}, &iteratorForCopyInstrumentsToStaging{rows: arg})
+}
+
+// iteratorForCopyTradeCancellationsToStaging implements pgx.CopyFromSource.
+type iteratorForCopyTradeCancellationsToStaging struct {
+	rows                 []CopyTradeCancellationsToStagingParams
+	skippedFirstNextCall bool
+}
+
+func (r *iteratorForCopyTradeCancellationsToStaging) Next() bool {
+	if len(r.rows) == 0 {
+		return false
+	}
+	if !r.skippedFirstNextCall {
+		r.skippedFirstNextCall = true
+		return true
+	}
+	r.rows = r.rows[1:]
+	return len(r.rows) > 0
+}
+
+func (r iteratorForCopyTradeCancellationsToStaging) Values() ([]interface{}, error) {
+	return []interface{}{
+		r.rows[0].Ticker,
 		r.rows[0].Date,
+		r.rows[0].TradeID,
+	}, nil
+}
+
+func (r iteratorForCopyTradeCancellationsToStaging) Err() error {
+	return nil
+}
+
+func (q *Queries) CopyTradeCancellationsToStaging(ctx context.Context, arg []CopyTradeCancellationsToStagingParams) (int64, error) {
+	return q.db.CopyFrom(ctx, []string{"trade_cancellations_staging"}, []string{"ticker", "date", "trade_id"}, &iteratorForCopyTradeCancellationsToStaging{rows: arg})
+}
+
+// iteratorForCopyTradesToFileStaging implements pgx.CopyFromSource.
+type iteratorForCopyTradesToFileStaging struct {
+	rows                 []CopyTradesToFileStagingParams
+	skippedFirstNextCall bool
+}
+
+func (r *iteratorForCopyTradesToFileStaging) Next() bool {
+	if len(r.rows) == 0 {
+		return false
+	}
+	if !r.skippedFirstNextCall {
+		r.skippedFirstNextCall = true
+		return true
+	}
+	r.rows = r.rows[1:]
+	return len(r.rows) > 0
+}
+
+func (r iteratorForCopyTradesToFileStaging) Values() ([]interface{}, error) {
+	return []interface{}{
+		r.rows[0].IngestionID,
+		r.rows[0].Line,
+		r.rows[0].ReferenceDate,
 		r.rows[0].Ticker,
+		r.rows[0].UpdateAction,
 		r.rows[0].Price,
 		r.rows[0].Quantity,
+		r.rows[0].TradedAt,
+		r.rows[0].TradeID,
+		r.rows[0].SessionType,
+		r.rows[0].Date,
+		r.rows[0].BuyerCode,
+		r.rows[0].SellerCode,
+	}, nil
+}
+
+func (r iteratorForCopyTradesToFileStaging) Err() error {
+	return nil
+}
+
+func (q *Queries) CopyTradesToFileStaging(ctx context.Context, arg []CopyTradesToFileStagingParams) (int64, error) {
+	return q.db.CopyFrom(ctx, []string{"trades_file_staging"}, []string{"ingestion_id", "line", "reference_date", "ticker", "update_action", "price", "quantity", "traded_at", "trade_id", "session_type", "date", "buyer_code", "seller_code"}, &iteratorForCopyTradesToFileStaging{rows: arg})
+}
+
+// iteratorForCopyTradesToStaging implements pgx.CopyFromSource.
+type iteratorForCopyTradesToStaging struct {
+	rows                 []CopyTradesToStagingParams
+	skippedFirstNextCall bool
+}
+
+func (r *iteratorForCopyTradesToStaging) Next() bool {
+	if len(r.rows) == 0 {
+		return false
+	}
+	if !r.skippedFirstNextCall {
+		r.skippedFirstNextCall = true
+		return true
+	}
+	r.rows = r.rows[1:]
+	return len(r.rows) > 0
+}
+
+func (r iteratorForCopyTradesToStaging) Values() ([]interface{}, error) {
+	return []interface{}{
+		r.rows[0].ReferenceDate,
+		r.rows[0].Ticker,
+		r.rows[0].UpdateAction,
+		r.rows[0].Price,
+		r.rows[0].Quantity,
+		r.rows[0].TradedAt,
+		r.rows[0].TradeID,
+		r.rows[0].SessionType,
+		r.rows[0].Date,
+		r.rows[0].BuyerCode,
+		r.rows[0].SellerCode,
 	}, nil
 }
 
-func (r iteratorForCreateTrades) Err() error {
+func (r iteratorForCopyTradesToStaging) Err() error {
 	return nil
 }
 
-func (q *Queries) CreateTrades(ctx context.Context, arg []CreateTradesParams) (int64, error) {
-	return q.db.CopyFrom(ctx, []string{"trades"}, []string{"hour", "date", "ticker", "price", "quantity"}, &iteratorForCreateTrades{rows: arg})
+func (q *Queries) CopyTradesToStaging(ctx context.Context, arg []CopyTradesToStagingParams) (int64, error) {
+	return q.db.CopyFrom(ctx, []string{"trades_staging"}, []string{"reference_date", "ticker", "update_action", "price", "quantity", "traded_at", "trade_id", "session_type", "date", "buyer_code", "seller_code"}, &iteratorForCopyTradesToStaging{rows: arg})
 }
@@ -8,13 +8,186 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
-type Trade struct {
+type CorporateEvent struct {
 	ID        int32
-	Hour      string
-	Date      pgtype.Date
 	Ticker    string
-	Price     pgtype.Numeric
-	Quantity  int32
+	EventType string
+	ExDate    pgtype.Date
+	Factor    pgtype.Numeric
+	Amount    pgtype.Numeric
 	CreatedAt pgtype.Timestamptz
 	UpdatedAt pgtype.Timestamptz
 }
+
+type CorporateEventsStaging struct {
+	Ticker    string
+	EventType string
+	ExDate    pgtype.Date
+	Factor    pgtype.Numeric
+	Amount    pgtype.Numeric
+}
+
+type DailyQuote struct {
+	ID              int32
+	Date            pgtype.Date
+	Ticker          string
+	BdiCode         int16
+	MarketType      int16
+	CompanyName     string
+	Specification   string
+	ForwardTermDays int16
+	Currency        string
+	OpenPrice       pgtype.Numeric
+	MaxPrice        pgtype.Numeric
+	MinPrice        pgtype.Numeric
+	AvgPrice        pgtype.Numeric
+	ClosePrice      pgtype.Numeric
+	BestBidPrice    pgtype.Numeric
+	BestAskPrice    pgtype.Numeric
+	TradesCount     int32
+	Quantity        int64
+	Volume          pgtype.Numeric
+	StrikePrice     pgtype.Numeric
+	ExpirationDate  pgtype.Date
+	QuoteFactor     int32
+	Isin            string
+	CreatedAt       pgtype.Timestamptz
+	UpdatedAt       pgtype.Timestamptz
+}
+
+type DailyQuotesStaging struct {
+	Date            pgtype.Date
+	Ticker          string
+	BdiCode         int16
+	MarketType      int16
+	CompanyName     string
+	Specification   string
+	ForwardTermDays int16
+	Currency        string
+	OpenPrice       pgtype.Numeric
+	MaxPrice        pgtype.Numeric
+	MinPrice        pgtype.Numeric
+	AvgPrice        pgtype.Numeric
+	ClosePrice      pgtype.Numeric
+	BestBidPrice    pgtype.Numeric
+	BestAskPrice    pgtype.Numeric
+	TradesCount     int32
+	Quantity        int64
+	Volume          pgtype.Numeric
+	StrikePrice     pgtype.Numeric
+	ExpirationDate  pgtype.Date
+	QuoteFactor     int32
+	Isin            string
+}
+
+type Ingestion struct {
+	ID             int32
+	Path           string
+	SizeBytes      int64
+	Checksum       string
+	Status         string
+	RowsParsed     int64
+	RowsRejected   int64
+	RowsInserted   int64
+	StartedAt      pgtype.Timestamptz
+	FinishedAt     pgtype.Timestamptz
+	CreatedAt      pgtype.Timestamptz
+	UpdatedAt      pgtype.Timestamptz
+	CheckpointLine int64
+}
+
+type Instrument struct {
+	ID               int32
+	ReferenceDate    pgtype.Date
+	Ticker           string
+	Isin             string
+	Asset            string
+	IssuerName       string
+	SecurityType     string
+	Segment          string
+	Market           string
+	Specification    string
+	Currency         string
+	LotSize          int64
+	TradingStartDate pgtype.Date
+	TradingEndDate   pgtype.Date
+	CreatedAt        pgtype.Timestamptz
+	UpdatedAt        pgtype.Timestamptz
+}
+
+type InstrumentsStaging struct {
+	ReferenceDate    pgtype.Date
+	Ticker           string
+	Isin             string
+	Asset            string
+	IssuerName       string
+	SecurityType     string
+	Segment          string
+	Market           string
+	Specification    string
+	Currency         string
+	LotSize          int64
+	TradingStartDate pgtype.Date
+	TradingEndDate   pgtype.Date
+}
+
+type TradeCancellation struct {
+	ID        int32
+	Ticker    string
+	Date      pgtype.Date
+	TradeID   int64
+	CreatedAt pgtype.Timestamptz
+}
+
+type TradeCancellationsStaging struct {
+	Ticker  string
+	Date    pgtype.Date
+	TradeID int64
+}
+
+type Trade struct {
+	ID            int32
+	ReferenceDate pgtype.Date
+	Ticker        string
+	UpdateAction  int16
+	Price         pgtype.Numeric
+	Quantity      int32
+	TradeID       int64
+	SessionType   int16
+	Date          pgtype.Date
+	BuyerCode     pgtype.Int4
+	SellerCode    pgtype.Int4
+	CreatedAt     pgtype.Timestamptz
+	UpdatedAt     pgtype.Timestamptz
+	TradedAt      pgtype.Timestamptz
+}
+
+type TradesFileStaging struct {
+	IngestionID   int32
+	Line          int64
+	ReferenceDate pgtype.Date
+	Ticker        string
+	UpdateAction  int16
+	Price         pgtype.Numeric
+	Quantity      int32
+	TradedAt      pgtype.Timestamptz
+	TradeID       int64
+	SessionType   int16
+	Date          pgtype.Date
+	BuyerCode     pgtype.Int4
+	SellerCode    pgtype.Int4
+}
+
+type TradesStaging struct {
+	ReferenceDate pgtype.Date
+	Ticker        string
+	UpdateAction  int16
+	Price         pgtype.Numeric
+	Quantity      int32
+	TradedAt      pgtype.Timestamptz
+	TradeID       int64
+	SessionType   int16
+	Date          pgtype.Date
+	BuyerCode     pgtype.Int4
+	SellerCode    pgtype.Int4
+}
@@ -6,11 +6,43 @@ package sqlc
 
 import (
 	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
 )
 
 type Querier interface {
-	CreateTrades(ctx context.Context, arg []CreateTradesParams) (int64, error)
+	AdoptStagedTrades(ctx context.Context, arg AdoptStagedTradesParams) (int64, error)
+	ApplyTradeCancellations(ctx context.Context) (int64, error)
+	CopyCorporateEventsToStaging(ctx context.Context, arg []CopyCorporateEventsToStagingParams) (int64, error)
+	CopyDailyQuotesToStaging(ctx context.Context, arg []CopyDailyQuotesToStagingParams) (int64, error)
+	CopyInstrumentsToStaging(ctx context.Context, arg []CopyInstrumentsToStagingParams) (int64, error)
+	CopyTradeCancellationsToStaging(ctx context.Context, arg []CopyTradeCancellationsToStagingParams) (int64, error)
+	CopyTradesToFileStaging(ctx context.Context, arg []CopyTradesToFileStagingParams) (int64, error)
+	CopyTradesToStaging(ctx context.Context, arg []CopyTradesToStagingParams) (int64, error)
+	CreateIngestion(ctx context.Context, arg CreateIngestionParams) (int32, error)
+	DeleteStagedTrades(ctx context.Context, ingestionID int32) (int64, error)
+	DeleteStaleStagedTrades(ctx context.Context, arg DeleteStaleStagedTradesParams) (int64, error)
+	DeleteTradeCancellationsByDate(ctx context.Context, tradeDate pgtype.Date) (int64, error)
+	DeleteTradesByDate(ctx context.Context, tradeDate pgtype.Date) (int64, error)
+	EnsureTradesPartition(ctx context.Context, tradeDate pgtype.Date) (string, error)
+	FinishIngestion(ctx context.Context, arg FinishIngestionParams) error
+	GetIngestion(ctx context.Context, id int32) (Ingestion, error)
+	GetInstrumentByTicker(ctx context.Context, ticker string) (Instrument, error)
+	GetResumableIngestion(ctx context.Context, arg GetResumableIngestionParams) (Ingestion, error)
+	HasSucceededIngestion(ctx context.Context, arg HasSucceededIngestionParams) (bool, error)
+	ListCorporateEventsByTicker(ctx context.Context, arg ListCorporateEventsByTickerParams) ([]ListCorporateEventsByTickerRow, error)
+	ListDailyQuotesByTicker(ctx context.Context, arg ListDailyQuotesByTickerParams) ([]DailyQuote, error)
+	ListStagedTradeMonths(ctx context.Context, ingestionID int32) ([]pgtype.Date, error)
 	ListTradeInfoByTickerAndDate(ctx context.Context, arg ListTradeInfoByTickerAndDateParams) ([]ListTradeInfoByTickerAndDateRow, error)
+	ListTradePartitions(ctx context.Context) ([]ListTradePartitionsRow, error)
+	MergeCorporateEventsFromStaging(ctx context.Context) (int64, error)
+	MergeDailyQuotesFromStaging(ctx context.Context) (int64, error)
+	MergeInstrumentsFromStaging(ctx context.Context) (int64, error)
+	MergeTradeCancellationsFromStaging(ctx context.Context) (int64, error)
+	MergeTradesFromStaging(ctx context.Context) (int64, error)
+	PromoteStagedTradeCancellations(ctx context.Context, ingestionID int32) (int64, error)
+	PromoteStagedTrades(ctx context.Context, ingestionID int32) (int64, error)
+	UpdateIngestionCheckpoint(ctx context.Context, arg UpdateIngestionCheckpointParams) error
 }
 
 var _ Querier = (*Queries)(nil)
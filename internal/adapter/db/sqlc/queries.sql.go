@@ -11,12 +11,66 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
-type CreateTradesParams struct {
-	Hour     string
-	Date     pgtype.Date
-	Ticker   string
-	Price    pgtype.Numeric
-	Quantity int32
+const applyTradeCancellations = `-- name: ApplyTradeCancellations :execrows
+DELETE FROM trades t
+USING trade_cancellations c
+WHERE t.ticker = c.ticker
+  AND t.date = c.date
+  AND t.trade_id = c.trade_id
+`
+
+func (q *Queries) ApplyTradeCancellations(ctx context.Context) (int64, error) {
+	result, err := q.db.Exec(ctx, applyTradeCancellations)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+type CopyTradeCancellationsToStagingParams struct {
+	Ticker  string
+	Date    pgtype.Date
+	TradeID int64
+}
+
+type CopyTradesToStagingParams struct {
+	ReferenceDate pgtype.Date
+	Ticker        string
+	UpdateAction  int16
+	Price         pgtype.Numeric
+	Quantity      int32
+	TradedAt      pgtype.Timestamptz
+	TradeID       int64
+	SessionType   int16
+	Date          pgtype.Date
+	BuyerCode     pgtype.Int4
+	SellerCode    pgtype.Int4
+}
+
+const deleteTradeCancellationsByDate = `-- name: DeleteTradeCancellationsByDate :execrows
+DELETE FROM trade_cancellations
+WHERE date = $1
+`
+
+func (q *Queries) DeleteTradeCancellationsByDate(ctx context.Context, tradeDate pgtype.Date) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteTradeCancellationsByDate, tradeDate)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const deleteTradesByDate = `-- name: DeleteTradesByDate :execrows
+DELETE FROM trades
+WHERE date = $1
+`
+
+func (q *Queries) DeleteTradesByDate(ctx context.Context, tradeDate pgtype.Date) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteTradesByDate, tradeDate)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
 }
 
 const listTradeInfoByTickerAndDate = `-- name: ListTradeInfoByTickerAndDate :many
@@ -26,7 +80,7 @@ SELECT
     quantity
 FROM trades
 WHERE ticker = $1
-  AND ($2::date IS NULL OR date >= $2::date)
+  AND date >= COALESCE($2::date, '-infinity'::date)
 `
 
 type ListTradeInfoByTickerAndDateParams struct {
@@ -59,3 +113,38 @@ func (q *Queries) ListTradeInfoByTickerAndDate(ctx context.Context, arg ListTrad
 	}
 	return items, nil
 }
+
+const mergeTradeCancellationsFromStaging = `-- name: MergeTradeCancellationsFromStaging :execrows
+INSERT INTO trade_cancellations (ticker, date, trade_id)
+SELECT ticker, date, trade_id
+FROM trade_cancellations_staging
+ON CONFLICT (ticker, date, trade_id) DO NOTHING
+`
+
+func (q *Queries) MergeTradeCancellationsFromStaging(ctx context.Context) (int64, error) {
+	result, err := q.db.Exec(ctx, mergeTradeCancellationsFromStaging)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const mergeTradesFromStaging = `-- name: MergeTradesFromStaging :execrows
+INSERT INTO trades (
+    reference_date, ticker, update_action, price, quantity, traded_at,
+    trade_id, session_type, date, buyer_code, seller_code
+)
+SELECT
+    reference_date, ticker, update_action, price, quantity, traded_at,
+    trade_id, session_type, date, buyer_code, seller_code
+FROM trades_staging
+ON CONFLICT (ticker, date, trade_id) DO NOTHING
+`
+
+func (q *Queries) MergeTradesFromStaging(ctx context.Context) (int64, error) {
+	result, err := q.db.Exec(ctx, mergeTradesFromStaging)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
@@ -0,0 +1,37 @@
+-- name: CopyCorporateEventsToStaging :copyfrom
+INSERT INTO corporate_events_staging (ticker, event_type, ex_date, factor, amount)
+VALUES ($1, $2, $3, $4, $5);
+
+-- name: ListCorporateEventsByTicker :many
+SELECT e.*,
+       COALESCE(
+               (SELECT q.close_price
+                FROM daily_quotes q
+                WHERE q.ticker = e.ticker
+                  AND q.market_type = 10
+                  AND q.date < e.ex_date
+                ORDER BY q.date DESC
+                LIMIT 1),
+               (SELECT t.price
+                FROM trades t
+                WHERE t.ticker = e.ticker
+                  AND t.date < e.ex_date
+                ORDER BY t.date DESC, t.traded_at DESC
+                LIMIT 1),
+               0
+       )::DECIMAL AS reference_price
+FROM corporate_events e
+WHERE e.ticker = @ticker
+  AND e.ex_date > COALESCE(@after_date::date, '-infinity'::date)
+ORDER BY e.ex_date, e.event_type;
+
+-- name: MergeCorporateEventsFromStaging :execrows
+INSERT INTO corporate_events (ticker, event_type, ex_date, factor, amount)
+SELECT DISTINCT ON (ticker, ex_date, event_type) ticker, event_type, ex_date, factor, amount
+FROM corporate_events_staging
+ORDER BY ticker, ex_date, event_type
+ON CONFLICT (ticker, ex_date, event_type) DO UPDATE
+SET factor     = EXCLUDED.factor,
+    amount     = EXCLUDED.amount,
+    updated_at = now()
+WHERE (corporate_events.factor, corporate_events.amount) IS DISTINCT FROM (EXCLUDED.factor, EXCLUDED.amount);
@@ -0,0 +1,44 @@
+-- name: CreateIngestion :one
+INSERT INTO ingestions (path, size_bytes, checksum, status, started_at, checkpoint_line)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING id;
+
+-- name: FinishIngestion :exec
+UPDATE ingestions
+SET status        = $2,
+    rows_parsed   = $3,
+    rows_rejected = $4,
+    rows_inserted = $5,
+    finished_at   = $6,
+    updated_at    = now()
+WHERE id = $1;
+
+-- name: GetIngestion :one
+SELECT *
+FROM ingestions
+WHERE id = $1;
+
+-- name: GetResumableIngestion :one
+SELECT *
+FROM ingestions
+WHERE path = $1
+  AND checksum = $2
+  AND status <> 'succeeded'
+  AND checkpoint_line > 0
+ORDER BY id DESC
+LIMIT 1;
+
+-- name: HasSucceededIngestion :one
+SELECT EXISTS (
+    SELECT 1
+    FROM ingestions
+    WHERE path = $1
+      AND checksum = $2
+      AND status = 'succeeded'
+);
+
+-- name: UpdateIngestionCheckpoint :exec
+UPDATE ingestions
+SET checkpoint_line = GREATEST(checkpoint_line, $2),
+    updated_at      = now()
+WHERE id = $1;
\ No newline at end of file
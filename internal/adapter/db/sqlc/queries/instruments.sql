@@ -0,0 +1,37 @@
+-- name: CopyInstrumentsToStaging :copyfrom
+INSERT INTO instruments_staging (
+    reference_date, ticker, isin, asset, issuer_name, security_type, segment, market,
+    specification, currency, lot_size, trading_start_date, trading_end_date
+)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13);
+
+-- name: GetInstrumentByTicker :one
+SELECT *
+FROM instruments
+WHERE ticker = @ticker;
+
+-- name: MergeInstrumentsFromStaging :execrows
+INSERT INTO instruments (
+    reference_date, ticker, isin, asset, issuer_name, security_type, segment, market,
+    specification, currency, lot_size, trading_start_date, trading_end_date
+)
+SELECT DISTINCT ON (ticker)
+    reference_date, ticker, isin, asset, issuer_name, security_type, segment, market,
+    specification, currency, lot_size, trading_start_date, trading_end_date
+FROM instruments_staging
+ORDER BY ticker, reference_date DESC
+ON CONFLICT (ticker) DO UPDATE
+SET reference_date     = EXCLUDED.reference_date,
+    isin               = EXCLUDED.isin,
+    asset              = EXCLUDED.asset,
+    issuer_name        = EXCLUDED.issuer_name,
+    security_type      = EXCLUDED.security_type,
+    segment            = EXCLUDED.segment,
+    market             = EXCLUDED.market,
+    specification      = EXCLUDED.specification,
+    currency           = EXCLUDED.currency,
+    lot_size           = EXCLUDED.lot_size,
+    trading_start_date = EXCLUDED.trading_start_date,
+    trading_end_date   = EXCLUDED.trading_end_date,
+    updated_at         = now()
+WHERE instruments.reference_date <= EXCLUDED.reference_date;
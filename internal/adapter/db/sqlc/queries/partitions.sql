@@ -0,0 +1,12 @@
+-- name: EnsureTradesPartition :one
+SELECT ensure_trades_partition(@trade_date::date)::text AS name;
+
+-- name: ListTradePartitions :many
+SELECT
+    c.relname::text AS name,
+    pg_get_expr(c.relpartbound, c.oid)::text AS bound
+FROM pg_inherits i
+JOIN pg_class c ON c.oid = i.inhrelid
+JOIN pg_class p ON p.oid = i.inhparent
+WHERE p.relname = 'trades'
+ORDER BY c.relname;
\ No newline at end of file
@@ -1,6 +1,28 @@
--- name: CreateTrades :copyfrom
-INSERT INTO trades (hour, date, ticker, price, quantity)
-VALUES ($1, $2, $3, $4, $5);
+-- name: ApplyTradeCancellations :execrows
+DELETE FROM trades t
+USING trade_cancellations c
+WHERE t.ticker = c.ticker
+  AND t.date = c.date
+  AND t.trade_id = c.trade_id;
+
+-- name: CopyTradeCancellationsToStaging :copyfrom
+INSERT INTO trade_cancellations_staging (ticker, date, trade_id)
+VALUES ($1, $2, $3);
+
+-- name: CopyTradesToStaging :copyfrom
+INSERT INTO trades_staging (
+    reference_date, ticker, update_action, price, quantity, traded_at,
+    trade_id, session_type, date, buyer_code, seller_code
+)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11);
+
+-- name: DeleteTradeCancellationsByDate :execrows
+DELETE FROM trade_cancellations
+WHERE date = @trade_date;
+
+-- name: DeleteTradesByDate :execrows
+DELETE FROM trades
+WHERE date = @trade_date;
 
 -- name: ListTradeInfoByTickerAndDate :many
 SELECT
@@ -9,4 +31,21 @@ SELECT
     quantity
 FROM trades
 WHERE ticker = @ticker
-  AND (@trade_date::date IS NULL OR date >= @trade_date::date);
\ No newline at end of file
+  AND date >= COALESCE(@trade_date::date, '-infinity'::date);
+
+-- name: MergeTradeCancellationsFromStaging :execrows
+INSERT INTO trade_cancellations (ticker, date, trade_id)
+SELECT ticker, date, trade_id
+FROM trade_cancellations_staging
+ON CONFLICT (ticker, date, trade_id) DO NOTHING;
+
+-- name: MergeTradesFromStaging :execrows
+INSERT INTO trades (
+    reference_date, ticker, update_action, price, quantity, traded_at,
+    trade_id, session_type, date, buyer_code, seller_code
+)
+SELECT
+    reference_date, ticker, update_action, price, quantity, traded_at,
+    trade_id, session_type, date, buyer_code, seller_code
+FROM trades_staging
+ON CONFLICT (ticker, date, trade_id) DO NOTHING;
\ No newline at end of file
@@ -0,0 +1,28 @@
+-- name: CopyDailyQuotesToStaging :copyfrom
+INSERT INTO daily_quotes_staging (
+    date, ticker, bdi_code, market_type, company_name, specification, forward_term_days, currency,
+    open_price, max_price, min_price, avg_price, close_price, best_bid_price, best_ask_price,
+    trades_count, quantity, volume, strike_price, expiration_date, quote_factor, isin
+)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22);
+
+-- name: ListDailyQuotesByTicker :many
+SELECT *
+FROM daily_quotes
+WHERE ticker = @ticker
+  AND date >= COALESCE(@from_date::date, '-infinity'::date)
+  AND date <= COALESCE(@to_date::date, 'infinity'::date)
+ORDER BY date, market_type, bdi_code, forward_term_days;
+
+-- name: MergeDailyQuotesFromStaging :execrows
+INSERT INTO daily_quotes (
+    date, ticker, bdi_code, market_type, company_name, specification, forward_term_days, currency,
+    open_price, max_price, min_price, avg_price, close_price, best_bid_price, best_ask_price,
+    trades_count, quantity, volume, strike_price, expiration_date, quote_factor, isin
+)
+SELECT
+    date, ticker, bdi_code, market_type, company_name, specification, forward_term_days, currency,
+    open_price, max_price, min_price, avg_price, close_price, best_bid_price, best_ask_price,
+    trades_count, quantity, volume, strike_price, expiration_date, quote_factor, isin
+FROM daily_quotes_staging
+ON CONFLICT (ticker, date, market_type, bdi_code, forward_term_days) DO NOTHING;
@@ -0,0 +1,54 @@
+-- name: AdoptStagedTrades :execrows
+UPDATE trades_file_staging s
+SET ingestion_id = @ingestion_id
+FROM ingestions i
+WHERE s.ingestion_id = i.id
+  AND i.id <> @ingestion_id
+  AND i.path = @path
+  AND i.checksum = @checksum
+  AND s.line <= @checkpoint_line;
+
+-- name: CopyTradesToFileStaging :copyfrom
+INSERT INTO trades_file_staging (
+    ingestion_id, line, reference_date, ticker, update_action, price, quantity,
+    traded_at, trade_id, session_type, date, buyer_code, seller_code
+)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13);
+
+-- name: DeleteStagedTrades :execrows
+DELETE FROM trades_file_staging
+WHERE ingestion_id = $1;
+
+-- name: DeleteStaleStagedTrades :execrows
+DELETE FROM trades_file_staging s
+USING ingestions i
+WHERE s.ingestion_id = i.id
+  AND i.id <> @ingestion_id
+  AND i.path = @path;
+
+-- name: ListStagedTradeMonths :many
+SELECT DISTINCT date_trunc('month', date)::date AS month
+FROM trades_file_staging
+WHERE ingestion_id = $1
+ORDER BY month;
+
+-- name: PromoteStagedTradeCancellations :execrows
+INSERT INTO trade_cancellations (ticker, date, trade_id)
+SELECT ticker, date, trade_id
+FROM trades_file_staging
+WHERE ingestion_id = $1
+  AND update_action = 2
+ON CONFLICT (ticker, date, trade_id) DO NOTHING;
+
+-- name: PromoteStagedTrades :execrows
+INSERT INTO trades (
+    reference_date, ticker, update_action, price, quantity, traded_at,
+    trade_id, session_type, date, buyer_code, seller_code
+)
+SELECT
+    reference_date, ticker, update_action, price, quantity, traded_at,
+    trade_id, session_type, date, buyer_code, seller_code
+FROM trades_file_staging
+WHERE ingestion_id = $1
+  AND update_action <> 2
+ON CONFLICT (ticker, date, trade_id) DO NOTHING;
@@ -0,0 +1,76 @@
+-- Temporary tables created per transaction by the repositories (see
+-- db.TradeRepository). They are only declared here so sqlc can type the
+-- queries that copy into and merge from them; goose never runs this file.
+CREATE TEMPORARY TABLE trades_staging
+(
+    reference_date DATE           NOT NULL,
+    ticker         TEXT           NOT NULL,
+    update_action  SMALLINT       NOT NULL,
+    price          DECIMAL(18, 3) NOT NULL,
+    quantity       INTEGER        NOT NULL,
+    traded_at      TIMESTAMPTZ    NOT NULL,
+    trade_id       BIGINT         NOT NULL,
+    session_type   SMALLINT       NOT NULL,
+    date           DATE           NOT NULL,
+    buyer_code     INTEGER,
+    seller_code    INTEGER
+);
+
+CREATE TEMPORARY TABLE trade_cancellations_staging
+(
+    ticker   TEXT   NOT NULL,
+    date     DATE   NOT NULL,
+    trade_id BIGINT NOT NULL
+);
+
+CREATE TEMPORARY TABLE daily_quotes_staging
+(
+    date              DATE           NOT NULL,
+    ticker            TEXT           NOT NULL,
+    bdi_code          SMALLINT       NOT NULL,
+    market_type       SMALLINT       NOT NULL,
+    company_name      TEXT           NOT NULL,
+    specification     TEXT           NOT NULL,
+    forward_term_days SMALLINT       NOT NULL,
+    currency          TEXT           NOT NULL,
+    open_price        DECIMAL(18, 2) NOT NULL,
+    max_price         DECIMAL(18, 2) NOT NULL,
+    min_price         DECIMAL(18, 2) NOT NULL,
+    avg_price         DECIMAL(18, 2) NOT NULL,
+    close_price       DECIMAL(18, 2) NOT NULL,
+    best_bid_price    DECIMAL(18, 2) NOT NULL,
+    best_ask_price    DECIMAL(18, 2) NOT NULL,
+    trades_count      INTEGER        NOT NULL,
+    quantity          BIGINT         NOT NULL,
+    volume            DECIMAL(20, 2) NOT NULL,
+    strike_price      DECIMAL(18, 2) NOT NULL,
+    expiration_date   DATE,
+    quote_factor      INTEGER        NOT NULL,
+    isin              TEXT           NOT NULL
+);
+
+CREATE TEMPORARY TABLE instruments_staging
+(
+    reference_date     DATE   NOT NULL,
+    ticker             TEXT   NOT NULL,
+    isin               TEXT   NOT NULL,
+    asset              TEXT   NOT NULL,
+    issuer_name        TEXT   NOT NULL,
+    security_type      TEXT   NOT NULL,
+    segment            TEXT   NOT NULL,
+    market             TEXT   NOT NULL,
+    specification      TEXT   NOT NULL,
+    currency           TEXT   NOT NULL,
+    lot_size           BIGINT NOT NULL,
+    trading_start_date DATE,
+    trading_end_date   DATE
+);
+
+CREATE TEMPORARY TABLE corporate_events_staging
+(
+    ticker     TEXT           NOT NULL,
+    event_type TEXT           NOT NULL,
+    ex_date    DATE           NOT NULL,
+    factor     DECIMAL(18, 8) NOT NULL,
+    amount     DECIMAL(18, 8) NOT NULL
+);
@@ -2,24 +2,25 @@ package sqlc
 
 import (
 	"b3challenge/internal/domain/entity"
+	"regexp"
 	"time"
 
 	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/shopspring/decimal"
 )
 
-func NewToCreateTradesParams(trades []entity.Trade) []CreateTradesParams {
-	var params []CreateTradesParams
+func NewToCopyTradesToStagingParams(trades []entity.Trade) []CopyTradesToStagingParams {
+	params := make([]CopyTradesToStagingParams, 0, len(trades))
 
 	for _, trade := range trades {
-		params = append(params, CreateTradesParams{
-			Hour: trade.Hour,
-			Date: pgtype.Date{
-				Time:             trade.Date,
+		params = append(params, CopyTradesToStagingParams{
+			ReferenceDate: pgtype.Date{
+				Time:             trade.ReferenceDate,
 				Valid:            true,
 				InfinityModifier: 0,
 			},
-			Ticker: trade.Ticker,
+			Ticker:       trade.Ticker,
+			UpdateAction: int16(trade.UpdateAction),
 			Price: pgtype.Numeric{
 				Int:              trade.Price.Coefficient(),
 				Exp:              trade.Price.Exponent(),
@@ -27,13 +28,162 @@ func NewToCreateTradesParams(trades []entity.Trade) []CreateTradesParams {
 				InfinityModifier: 0,
 				NaN:              false,
 			},
-			Quantity: trade.Quantity,
+			Quantity:    trade.Quantity,
+			TradedAt:    newTimestamptz(&trade.TradedAt),
+			TradeID:     trade.TradeID,
+			SessionType: trade.SessionType,
+			Date: pgtype.Date{
+				Time:             trade.Date,
+				Valid:            true,
+				InfinityModifier: 0,
+			},
+			BuyerCode:  newInt4(trade.BuyerCode),
+			SellerCode: newInt4(trade.SellerCode),
 		})
 	}
 
 	return params
 }
 
+func NewToCopyTradeCancellationsToStagingParams(trades []entity.Trade) []CopyTradeCancellationsToStagingParams {
+	params := make([]CopyTradeCancellationsToStagingParams, 0, len(trades))
+
+	for _, trade := range trades {
+		params = append(params, CopyTradeCancellationsToStagingParams{
+			Ticker: trade.Ticker,
+			Date: pgtype.Date{
+				Time:             trade.Date,
+				Valid:            true,
+				InfinityModifier: 0,
+			},
+			TradeID: trade.TradeID,
+		})
+	}
+
+	return params
+}
+
+func NewCopyTradesToFileStagingParams(
+	ingestionID int32,
+	trades []entity.Trade,
+	lines []int64,
+) []CopyTradesToFileStagingParams {
+	staged := NewToCopyTradesToStagingParams(trades)
+	params := make([]CopyTradesToFileStagingParams, 0, len(staged))
+
+	for i, trade := range staged {
+		params = append(params, CopyTradesToFileStagingParams{
+			IngestionID:   ingestionID,
+			Line:          lines[i],
+			ReferenceDate: trade.ReferenceDate,
+			Ticker:        trade.Ticker,
+			UpdateAction:  trade.UpdateAction,
+			Price:         trade.Price,
+			Quantity:      trade.Quantity,
+			TradedAt:      trade.TradedAt,
+			TradeID:       trade.TradeID,
+			SessionType:   trade.SessionType,
+			Date:          trade.Date,
+			BuyerCode:     trade.BuyerCode,
+			SellerCode:    trade.SellerCode,
+		})
+	}
+
+	return params
+}
+
+func NewAdoptStagedTradesParams(ingestion *entity.Ingestion) AdoptStagedTradesParams {
+	return AdoptStagedTradesParams{
+		IngestionID:    ingestion.ID,
+		Path:           ingestion.Path,
+		Checksum:       ingestion.Checksum,
+		CheckpointLine: ingestion.CheckpointLine,
+	}
+}
+
+func NewCreateIngestionParams(ingestion *entity.Ingestion) CreateIngestionParams {
+	return CreateIngestionParams{
+		Path:           ingestion.Path,
+		SizeBytes:      ingestion.SizeBytes,
+		Checksum:       ingestion.Checksum,
+		Status:         string(ingestion.Status),
+		StartedAt:      newTimestamptz(&ingestion.StartedAt),
+		CheckpointLine: ingestion.CheckpointLine,
+	}
+}
+
+func NewFinishIngestionParams(ingestion *entity.Ingestion) FinishIngestionParams {
+	return FinishIngestionParams{
+		ID:           ingestion.ID,
+		Status:       string(ingestion.Status),
+		RowsParsed:   ingestion.RowsParsed,
+		RowsRejected: ingestion.RowsRejected,
+		RowsInserted: ingestion.RowsInserted,
+		FinishedAt:   newTimestamptz(ingestion.FinishedAt),
+	}
+}
+
+func (i *Ingestion) ToIngestion() *entity.Ingestion {
+	var finishedAt *time.Time
+	if i.FinishedAt.Valid {
+		finishedAt = &i.FinishedAt.Time
+	}
+
+	return &entity.Ingestion{
+		ID:             i.ID,
+		CreatedAt:      i.CreatedAt.Time,
+		UpdatedAt:      i.UpdatedAt.Time,
+		Path:           i.Path,
+		SizeBytes:      i.SizeBytes,
+		Checksum:       i.Checksum,
+		Status:         entity.IngestionStatus(i.Status),
+		RowsParsed:     i.RowsParsed,
+		RowsRejected:   i.RowsRejected,
+		RowsInserted:   i.RowsInserted,
+		StartedAt:      i.StartedAt.Time,
+		FinishedAt:     finishedAt,
+		CheckpointLine: i.CheckpointLine,
+	}
+}
+
+func NewDate(value time.Time) pgtype.Date {
+	return pgtype.Date{
+		Time:             value,
+		InfinityModifier: 0,
+		Valid:            true,
+	}
+}
+
+func newTimestamptz(value *time.Time) pgtype.Timestamptz {
+	if value == nil {
+		return pgtype.Timestamptz{
+			Time:             time.Time{},
+			InfinityModifier: 0,
+			Valid:            false,
+		}
+	}
+
+	return pgtype.Timestamptz{
+		Time:             *value,
+		InfinityModifier: 0,
+		Valid:            true,
+	}
+}
+
+func newInt4(value *int32) pgtype.Int4 {
+	if value == nil {
+		return pgtype.Int4{
+			Int32: 0,
+			Valid: false,
+		}
+	}
+
+	return pgtype.Int4{
+		Int32: *value,
+		Valid: true,
+	}
+}
+
 func NewListTradeInfoByTickerAndDateParams(ticker string, date *time.Time) ListTradeInfoByTickerAndDateParams {
 	params := ListTradeInfoByTickerAndDateParams{
 		Ticker: ticker,
@@ -63,3 +213,225 @@ func (tr *ListTradeInfoByTickerAndDateRow) ToTradeInfo(ticker string) entity.Tra
 		Quantity: int(tr.Quantity),
 	}
 }
+
+var partitionBoundRegexp = regexp.MustCompile(`FROM \('([0-9-]+)'\) TO \('([0-9-]+)'\)`)
+
+func (pr *ListTradePartitionsRow) ToTradePartition() *entity.TradePartition {
+	const boundGroups = 3
+
+	matches := partitionBoundRegexp.FindStringSubmatch(pr.Bound)
+	if len(matches) != boundGroups {
+		return nil
+	}
+
+	from, err := time.Parse(time.DateOnly, matches[1])
+	if err != nil {
+		return nil
+	}
+	to, err := time.Parse(time.DateOnly, matches[2])
+	if err != nil {
+		return nil
+	}
+
+	return &entity.TradePartition{
+		Name: pr.Name,
+		From: from,
+		To:   to,
+	}
+}
+
+func NewCopyDailyQuotesToStagingParams(quotes []entity.DailyQuote) []CopyDailyQuotesToStagingParams {
+	params := make([]CopyDailyQuotesToStagingParams, 0, len(quotes))
+
+	for _, quote := range quotes {
+		params = append(params, CopyDailyQuotesToStagingParams{
+			Date:            NewDate(quote.Date),
+			Ticker:          quote.Ticker,
+			BdiCode:         int16(quote.BDICode),
+			MarketType:      int16(quote.MarketType),
+			CompanyName:     quote.CompanyName,
+			Specification:   quote.Specification,
+			ForwardTermDays: quote.ForwardTermDays,
+			Currency:        quote.Currency,
+			OpenPrice:       newNumeric(quote.OpenPrice),
+			MaxPrice:        newNumeric(quote.MaxPrice),
+			MinPrice:        newNumeric(quote.MinPrice),
+			AvgPrice:        newNumeric(quote.AvgPrice),
+			ClosePrice:      newNumeric(quote.ClosePrice),
+			BestBidPrice:    newNumeric(quote.BestBidPrice),
+			BestAskPrice:    newNumeric(quote.BestAskPrice),
+			TradesCount:     quote.TradesCount,
+			Quantity:        quote.Quantity,
+			Volume:          newNumeric(quote.Volume),
+			StrikePrice:     newNumeric(quote.StrikePrice),
+			ExpirationDate:  newOptionalDate(quote.ExpirationDate),
+			QuoteFactor:     quote.QuoteFactor,
+			Isin:            quote.ISIN,
+		})
+	}
+
+	return params
+}
+
+func NewListDailyQuotesByTickerParams(ticker string, from, to *time.Time) ListDailyQuotesByTickerParams {
+	return ListDailyQuotesByTickerParams{
+		Ticker:   ticker,
+		FromDate: newOptionalDate(from),
+		ToDate:   newOptionalDate(to),
+	}
+}
+
+func (q *DailyQuote) ToDailyQuote() entity.DailyQuote {
+	var expirationDate *time.Time
+	if q.ExpirationDate.Valid {
+		expirationDate = &q.ExpirationDate.Time
+	}
+
+	return entity.DailyQuote{
+		ID:              q.ID,
+		CreatedAt:       q.CreatedAt.Time,
+		UpdatedAt:       q.UpdatedAt.Time,
+		Date:            q.Date.Time,
+		Ticker:          q.Ticker,
+		BDICode:         entity.BDICode(q.BdiCode),
+		MarketType:      entity.MarketType(q.MarketType),
+		CompanyName:     q.CompanyName,
+		Specification:   q.Specification,
+		ForwardTermDays: q.ForwardTermDays,
+		Currency:        q.Currency,
+		OpenPrice:       toDecimal(q.OpenPrice),
+		MaxPrice:        toDecimal(q.MaxPrice),
+		MinPrice:        toDecimal(q.MinPrice),
+		AvgPrice:        toDecimal(q.AvgPrice),
+		ClosePrice:      toDecimal(q.ClosePrice),
+		BestBidPrice:    toDecimal(q.BestBidPrice),
+		BestAskPrice:    toDecimal(q.BestAskPrice),
+		TradesCount:     q.TradesCount,
+		Quantity:        q.Quantity,
+		Volume:          toDecimal(q.Volume),
+		StrikePrice:     toDecimal(q.StrikePrice),
+		ExpirationDate:  expirationDate,
+		QuoteFactor:     q.QuoteFactor,
+		ISIN:            q.Isin,
+	}
+}
+
+func newNumeric(value decimal.Decimal) pgtype.Numeric {
+	return pgtype.Numeric{
+		Int:              value.Coefficient(),
+		Exp:              value.Exponent(),
+		Valid:            true,
+		InfinityModifier: 0,
+		NaN:              false,
+	}
+}
+
+func toDecimal(value pgtype.Numeric) decimal.Decimal {
+	if !value.Valid {
+		return decimal.Zero
+	}
+
+	return decimal.NewFromBigInt(value.Int, value.Exp)
+}
+
+func newOptionalDate(value *time.Time) pgtype.Date {
+	if value == nil {
+		return pgtype.Date{
+			Time:             time.Time{},
+			InfinityModifier: 0,
+			Valid:            false,
+		}
+	}
+
+	return NewDate(*value)
+}
+
+func NewCopyInstrumentsToStagingParams(instruments []entity.Instrument) []CopyInstrumentsToStagingParams {
+	params := make([]CopyInstrumentsToStagingParams, 0, len(instruments))
+
+	for _, instrument := range instruments {
+		params = append(params, CopyInstrumentsToStagingParams{
+			ReferenceDate:    NewDate(instrument.ReferenceDate),
+			Ticker:           instrument.Ticker,
+			Isin:             instrument.ISIN,
+			Asset:            instrument.Asset,
+			IssuerName:       instrument.IssuerName,
+			SecurityType:     instrument.SecurityType,
+			Segment:          instrument.Segment,
+			Market:           instrument.Market,
+			Specification:    instrument.Specification,
+			Currency:         instrument.Currency,
+			LotSize:          instrument.LotSize,
+			TradingStartDate: newOptionalDate(instrument.TradingStartDate),
+			TradingEndDate:   newOptionalDate(instrument.TradingEndDate),
+		})
+	}
+
+	return params
+}
+
+func (i *Instrument) ToInstrument() *entity.Instrument {
+	return &entity.Instrument{
+		ID:               i.ID,
+		CreatedAt:        i.CreatedAt.Time,
+		UpdatedAt:        i.UpdatedAt.Time,
+		ReferenceDate:    i.ReferenceDate.Time,
+		Ticker:           i.Ticker,
+		ISIN:             i.Isin,
+		Asset:            i.Asset,
+		IssuerName:       i.IssuerName,
+		SecurityType:     i.SecurityType,
+		Segment:          i.Segment,
+		Market:           i.Market,
+		Specification:    i.Specification,
+		Currency:         i.Currency,
+		LotSize:          i.LotSize,
+		TradingStartDate: toOptionalTime(i.TradingStartDate),
+		TradingEndDate:   toOptionalTime(i.TradingEndDate),
+	}
+}
+
+func toOptionalTime(value pgtype.Date) *time.Time {
+	if !value.Valid {
+		return nil
+	}
+
+	return &value.Time
+}
+
+func NewCopyCorporateEventsToStagingParams(events []entity.CorporateEvent) []CopyCorporateEventsToStagingParams {
+	params := make([]CopyCorporateEventsToStagingParams, 0, len(events))
+
+	for _, event := range events {
+		params = append(params, CopyCorporateEventsToStagingParams{
+			Ticker:    event.Ticker,
+			EventType: string(event.Type),
+			ExDate:    NewDate(event.ExDate),
+			Factor:    newNumeric(event.Factor),
+			Amount:    newNumeric(event.Amount),
+		})
+	}
+
+	return params
+}
+
+func NewListCorporateEventsByTickerParams(ticker string, after *time.Time) ListCorporateEventsByTickerParams {
+	return ListCorporateEventsByTickerParams{
+		Ticker:    ticker,
+		AfterDate: newOptionalDate(after),
+	}
+}
+
+func (r *ListCorporateEventsByTickerRow) ToCorporateEvent() entity.CorporateEvent {
+	return entity.CorporateEvent{
+		ID:             r.ID,
+		CreatedAt:      r.CreatedAt.Time,
+		UpdatedAt:      r.UpdatedAt.Time,
+		Ticker:         r.Ticker,
+		Type:           entity.CorporateEventType(r.EventType),
+		ExDate:         r.ExDate.Time,
+		Factor:         toDecimal(r.Factor),
+		Amount:         toDecimal(r.Amount),
+		ReferencePrice: toDecimal(r.ReferencePrice),
+	}
+}
@@ -6,37 +6,116 @@ import (
 	"testing"
 	"time"
 
+	"github.com/AlekSi/pointer"
 	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
-func TestNewToCreateTradesParams_Single(t *testing.T) {
+func TestNewToCopyTradesToStagingParams_Single(t *testing.T) {
 	price := decimal.RequireFromString("1.23")
 	trade := entity.Trade{
-		Hour:     "131001",
-		Date:     time.Date(2025, 6, 8, 0, 0, 0, 0, time.UTC),
-		Ticker:   "ABC123",
-		Price:    price,
-		Quantity: 42,
-	}
-
-	got := NewToCreateTradesParams([]entity.Trade{trade})
-	want := []CreateTradesParams{{
-		Hour:   trade.Hour,
-		Date:   pgtype.Date{Time: trade.Date, Valid: true},
-		Ticker: trade.Ticker,
+		ReferenceDate: time.Date(2025, 6, 9, 0, 0, 0, 0, time.UTC),
+		Ticker:        "ABC123",
+		UpdateAction:  0,
+		Price:         price,
+		Quantity:      42,
+		TradedAt:      time.Date(2025, 6, 8, 13, 10, 1, 250*int(time.Millisecond), entity.B3Location),
+		TradeID:       1234,
+		SessionType:   1,
+		Date:          time.Date(2025, 6, 8, 0, 0, 0, 0, time.UTC),
+		BuyerCode:     pointer.ToInt32(3),
+		SellerCode:    nil,
+	}
+
+	got := NewToCopyTradesToStagingParams([]entity.Trade{trade})
+	want := []CopyTradesToStagingParams{{
+		ReferenceDate: pgtype.Date{Time: trade.ReferenceDate, Valid: true},
+		Ticker:        trade.Ticker,
+		UpdateAction:  0,
 		Price: pgtype.Numeric{
 			Int:   big.NewInt(123),
 			Exp:   -2,
 			Valid: true,
 		},
-		Quantity: int32(trade.Quantity),
+		Quantity:    int32(trade.Quantity),
+		TradedAt:    pgtype.Timestamptz{Time: trade.TradedAt, Valid: true},
+		TradeID:     1234,
+		SessionType: 1,
+		Date:        pgtype.Date{Time: trade.Date, Valid: true},
+		BuyerCode:   pgtype.Int4{Int32: 3, Valid: true},
+		SellerCode:  pgtype.Int4{Valid: false},
+	}}
+
+	assert.Equal(t, want, got)
+}
+
+func TestNewToCopyTradeCancellationsToStagingParams(t *testing.T) {
+	trade := entity.Trade{
+		Ticker:       "ABC123",
+		UpdateAction: entity.UpdateActionCancel,
+		TradeID:      1234,
+		Date:         time.Date(2025, 6, 8, 0, 0, 0, 0, time.UTC),
+	}
+
+	got := NewToCopyTradeCancellationsToStagingParams([]entity.Trade{trade})
+	want := []CopyTradeCancellationsToStagingParams{{
+		Ticker:  trade.Ticker,
+		Date:    pgtype.Date{Time: trade.Date, Valid: true},
+		TradeID: 1234,
 	}}
 
 	assert.Equal(t, want, got)
 }
 
+func TestNewCopyTradesToFileStagingParams(t *testing.T) {
+	trades := []entity.Trade{
+		{
+			ReferenceDate: time.Date(2025, 6, 9, 0, 0, 0, 0, time.UTC),
+			Ticker:        "PETR4",
+			UpdateAction:  entity.UpdateActionNew,
+			Price:         decimal.RequireFromString("32.15"),
+			Quantity:      100,
+			TradedAt:      time.Date(2025, 6, 9, 10, 0, 0, 0, entity.B3Location),
+			TradeID:       10,
+			SessionType:   1,
+			Date:          time.Date(2025, 6, 9, 0, 0, 0, 0, time.UTC),
+			BuyerCode:     nil,
+			SellerCode:    pointer.ToInt32(8),
+		},
+		{
+			ReferenceDate: time.Date(2025, 6, 9, 0, 0, 0, 0, time.UTC),
+			Ticker:        "PETR4",
+			UpdateAction:  entity.UpdateActionCancel,
+			Price:         decimal.RequireFromString("32.15"),
+			Quantity:      100,
+			TradedAt:      time.Date(2025, 6, 9, 10, 0, 0, 0, entity.B3Location),
+			TradeID:       10,
+			SessionType:   1,
+			Date:          time.Date(2025, 6, 9, 0, 0, 0, 0, time.UTC),
+			BuyerCode:     nil,
+			SellerCode:    nil,
+		},
+	}
+
+	got := NewCopyTradesToFileStagingParams(7, trades, []int64{2, 5})
+	staged := NewToCopyTradesToStagingParams(trades)
+
+	require.Len(t, got, 2)
+	for i, params := range got {
+		assert.Equal(t, int32(7), params.IngestionID)
+		assert.Equal(t, staged[i].Ticker, params.Ticker)
+		assert.Equal(t, staged[i].UpdateAction, params.UpdateAction)
+		assert.Equal(t, staged[i].Price, params.Price)
+		assert.Equal(t, staged[i].TradedAt, params.TradedAt)
+		assert.Equal(t, staged[i].SellerCode, params.SellerCode)
+	}
+	assert.Equal(t, int64(2), got[0].Line)
+	assert.Equal(t, int64(5), got[1].Line)
+	assert.Equal(t, int16(entity.UpdateActionCancel), got[1].UpdateAction)
+}
+
 func TestNewListTradeInfoByTickerAndDateParams(t *testing.T) {
 	const ticker = "ABC123"
 	date := time.Date(2025, 6, 8, 0, 0, 0, 0, time.UTC)
@@ -75,6 +154,12 @@ func TestNewListTradeInfoByTickerAndDateParams(t *testing.T) {
 	}
 }
 
+func TestNewDate(t *testing.T) {
+	date := time.Date(2025, 6, 8, 0, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, pgtype.Date{Time: date, Valid: true}, NewDate(date))
+}
+
 func TestListTradeInfoByTickerAndDateRow_ToTradeInfo(t *testing.T) {
 	const ticker = "XYZ789"
 	d := time.Date(2025, 6, 7, 0, 0, 0, 0, time.UTC)
@@ -103,3 +188,330 @@ func TestListTradeInfoByTickerAndDateRow_ToTradeInfo(t *testing.T) {
 
 	assert.Equal(t, want, got)
 }
+
+func TestNewCreateIngestionParams(t *testing.T) {
+	startedAt := time.Date(2025, 6, 12, 10, 0, 0, 0, time.UTC)
+	ingestion := &entity.Ingestion{
+		Path:           "b3Data/02-06-2025_NEGOCIOSAVISTA.txt",
+		SizeBytes:      1024,
+		Checksum:       "abc",
+		Status:         entity.IngestionStatusRunning,
+		StartedAt:      startedAt,
+		CheckpointLine: 42,
+	}
+
+	got := NewCreateIngestionParams(ingestion)
+	want := CreateIngestionParams{
+		Path:           ingestion.Path,
+		SizeBytes:      1024,
+		Checksum:       "abc",
+		Status:         "running",
+		StartedAt:      pgtype.Timestamptz{Time: startedAt, Valid: true},
+		CheckpointLine: 42,
+	}
+
+	assert.Equal(t, want, got)
+}
+
+func TestNewAdoptStagedTradesParams(t *testing.T) {
+	ingestion := &entity.Ingestion{
+		ID:             3,
+		Path:           "b3Data/02-06-2025_NEGOCIOSAVISTA.txt",
+		Checksum:       "abc",
+		CheckpointLine: 42,
+	}
+
+	got := NewAdoptStagedTradesParams(ingestion)
+	want := AdoptStagedTradesParams{
+		IngestionID:    3,
+		Path:           ingestion.Path,
+		Checksum:       "abc",
+		CheckpointLine: 42,
+	}
+
+	assert.Equal(t, want, got)
+}
+
+func TestNewFinishIngestionParams(t *testing.T) {
+	finishedAt := time.Date(2025, 6, 12, 10, 5, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		ingestion *entity.Ingestion
+		want      FinishIngestionParams
+	}{
+		{
+			name: "finished",
+			ingestion: &entity.Ingestion{
+				ID:           7,
+				Status:       entity.IngestionStatusSucceeded,
+				RowsParsed:   10,
+				RowsRejected: 1,
+				RowsInserted: 9,
+				FinishedAt:   &finishedAt,
+			},
+			want: FinishIngestionParams{
+				ID:           7,
+				Status:       "succeeded",
+				RowsParsed:   10,
+				RowsRejected: 1,
+				RowsInserted: 9,
+				FinishedAt:   pgtype.Timestamptz{Time: finishedAt, Valid: true},
+			},
+		},
+		{
+			name: "without finish time",
+			ingestion: &entity.Ingestion{
+				ID:     7,
+				Status: entity.IngestionStatusFailed,
+			},
+			want: FinishIngestionParams{
+				ID:         7,
+				Status:     "failed",
+				FinishedAt: pgtype.Timestamptz{Valid: false},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NewFinishIngestionParams(tt.ingestion)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestIngestion_ToIngestion(t *testing.T) {
+	startedAt := time.Date(2025, 6, 12, 10, 0, 0, 0, time.UTC)
+
+	row := &Ingestion{
+		ID:             3,
+		Path:           "file.txt",
+		SizeBytes:      10,
+		Checksum:       "abc",
+		Status:         "failed",
+		RowsParsed:     5,
+		RowsRejected:   1,
+		RowsInserted:   4,
+		StartedAt:      pgtype.Timestamptz{Time: startedAt, Valid: true},
+		FinishedAt:     pgtype.Timestamptz{Valid: false},
+		CheckpointLine: 6,
+	}
+
+	want := &entity.Ingestion{
+		ID:             3,
+		Path:           "file.txt",
+		SizeBytes:      10,
+		Checksum:       "abc",
+		Status:         entity.IngestionStatusFailed,
+		RowsParsed:     5,
+		RowsRejected:   1,
+		RowsInserted:   4,
+		StartedAt:      startedAt,
+		FinishedAt:     nil,
+		CheckpointLine: 6,
+	}
+
+	assert.Equal(t, want, row.ToIngestion())
+}
+
+func TestListTradePartitionsRow_ToTradePartition(t *testing.T) {
+	tests := []struct {
+		name string
+		row  ListTradePartitionsRow
+		want *entity.TradePartition
+	}{
+		{
+			name: "monthly range",
+			row: ListTradePartitionsRow{
+				Name:  "trades_p202506",
+				Bound: "FOR VALUES FROM ('2025-06-01') TO ('2025-07-01')",
+			},
+			want: &entity.TradePartition{
+				Name: "trades_p202506",
+				From: time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC),
+				To:   time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name: "default partition",
+			row:  ListTradePartitionsRow{Name: "trades_default", Bound: "DEFAULT"},
+			want: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.row.ToTradePartition())
+		})
+	}
+}
+
+func TestDailyQuoteTranslation(t *testing.T) {
+	expiration := time.Date(2025, 6, 20, 0, 0, 0, 0, time.UTC)
+	quote := entity.DailyQuote{
+		Date:            time.Date(2025, 6, 2, 0, 0, 0, 0, time.UTC),
+		Ticker:          "PETRF320",
+		BDICode:         78,
+		MarketType:      entity.MarketTypeCallOption,
+		CompanyName:     "PETR",
+		Specification:   "PN      N2",
+		ForwardTermDays: 0,
+		Currency:        "R$",
+		OpenPrice:       decimal.New(42, -2),
+		MaxPrice:        decimal.New(51, -2),
+		MinPrice:        decimal.New(38, -2),
+		AvgPrice:        decimal.New(45, -2),
+		ClosePrice:      decimal.New(47, -2),
+		BestBidPrice:    decimal.New(46, -2),
+		BestAskPrice:    decimal.New(48, -2),
+		TradesCount:     812,
+		Quantity:        1500000,
+		Volume:          decimal.New(67500000, -2),
+		StrikePrice:     decimal.New(3200, -2),
+		ExpirationDate:  &expiration,
+		QuoteFactor:     1,
+		ISIN:            "BRPETRACNPR6",
+	}
+
+	params := NewCopyDailyQuotesToStagingParams([]entity.DailyQuote{quote})
+	assert.Len(t, params, 1)
+	assert.Equal(t, int16(78), params[0].BdiCode)
+	assert.Equal(t, int16(70), params[0].MarketType)
+	assert.Equal(t, pgtype.Numeric{Int: big.NewInt(3200), Exp: -2, Valid: true}, params[0].StrikePrice)
+	assert.Equal(t, pgtype.Date{Time: expiration, Valid: true}, params[0].ExpirationDate)
+
+	stored := DailyQuotesStaging(params[0])
+	row := DailyQuote{
+		ID:              7,
+		Date:            stored.Date,
+		Ticker:          stored.Ticker,
+		BdiCode:         stored.BdiCode,
+		MarketType:      stored.MarketType,
+		CompanyName:     stored.CompanyName,
+		Specification:   stored.Specification,
+		ForwardTermDays: stored.ForwardTermDays,
+		Currency:        stored.Currency,
+		OpenPrice:       stored.OpenPrice,
+		MaxPrice:        stored.MaxPrice,
+		MinPrice:        stored.MinPrice,
+		AvgPrice:        stored.AvgPrice,
+		ClosePrice:      stored.ClosePrice,
+		BestBidPrice:    stored.BestBidPrice,
+		BestAskPrice:    stored.BestAskPrice,
+		TradesCount:     stored.TradesCount,
+		Quantity:        stored.Quantity,
+		Volume:          stored.Volume,
+		StrikePrice:     stored.StrikePrice,
+		ExpirationDate:  stored.ExpirationDate,
+		QuoteFactor:     stored.QuoteFactor,
+		Isin:            stored.Isin,
+	}
+	quote.ID = 7
+	assert.Equal(t, quote, row.ToDailyQuote())
+
+	row.ExpirationDate = pgtype.Date{}
+	assert.Nil(t, row.ToDailyQuote().ExpirationDate)
+}
+
+func TestNewListDailyQuotesByTickerParams(t *testing.T) {
+	from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	got := NewListDailyQuotesByTickerParams("PETR4", &from, nil)
+	assert.Equal(t, ListDailyQuotesByTickerParams{
+		Ticker:   "PETR4",
+		FromDate: pgtype.Date{Time: from, Valid: true},
+		ToDate:   pgtype.Date{Valid: false},
+	}, got)
+}
+
+func TestInstrumentTranslation(t *testing.T) {
+	start := time.Date(2000, 1, 3, 0, 0, 0, 0, time.UTC)
+	instrument := entity.Instrument{
+		ReferenceDate:    time.Date(2025, 6, 2, 0, 0, 0, 0, time.UTC),
+		Ticker:           "PETR4",
+		ISIN:             "BRPETRACNPR6",
+		Asset:            "PETR",
+		IssuerName:       "PETROLEO BRASILEIRO S.A. PETROBRAS",
+		SecurityType:     "SHARES",
+		Segment:          "CASH",
+		Market:           "EQUITY-CASH",
+		Specification:    "PN      N2",
+		Currency:         "BRL",
+		LotSize:          100,
+		TradingStartDate: &start,
+		TradingEndDate:   nil,
+	}
+
+	params := NewCopyInstrumentsToStagingParams([]entity.Instrument{instrument})
+	assert.Equal(t, []CopyInstrumentsToStagingParams{{
+		ReferenceDate:    pgtype.Date{Time: instrument.ReferenceDate, Valid: true},
+		Ticker:           "PETR4",
+		Isin:             "BRPETRACNPR6",
+		Asset:            "PETR",
+		IssuerName:       "PETROLEO BRASILEIRO S.A. PETROBRAS",
+		SecurityType:     "SHARES",
+		Segment:          "CASH",
+		Market:           "EQUITY-CASH",
+		Specification:    "PN      N2",
+		Currency:         "BRL",
+		LotSize:          100,
+		TradingStartDate: pgtype.Date{Time: start, Valid: true},
+		TradingEndDate:   pgtype.Date{Valid: false},
+	}}, params)
+
+	row := Instrument{
+		ID:               3,
+		ReferenceDate:    params[0].ReferenceDate,
+		Ticker:           params[0].Ticker,
+		Isin:             params[0].Isin,
+		Asset:            params[0].Asset,
+		IssuerName:       params[0].IssuerName,
+		SecurityType:     params[0].SecurityType,
+		Segment:          params[0].Segment,
+		Market:           params[0].Market,
+		Specification:    params[0].Specification,
+		Currency:         params[0].Currency,
+		LotSize:          params[0].LotSize,
+		TradingStartDate: params[0].TradingStartDate,
+		TradingEndDate:   params[0].TradingEndDate,
+	}
+	instrument.ID = 3
+	assert.Equal(t, &instrument, row.ToInstrument())
+}
+
+func TestCorporateEventTranslation(t *testing.T) {
+	exDate := time.Date(2025, 6, 3, 0, 0, 0, 0, time.UTC)
+	event := entity.CorporateEvent{
+		Ticker: "PETR4",
+		Type:   entity.CorporateEventCashDividend,
+		ExDate: exDate,
+		Factor: decimal.Zero,
+		Amount: decimal.New(145, -2),
+	}
+
+	params := NewCopyCorporateEventsToStagingParams([]entity.CorporateEvent{event})
+	require.Len(t, params, 1)
+	assert.Equal(t, "cash_dividend", params[0].EventType)
+	assert.Equal(t, pgtype.Date{Time: exDate, Valid: true}, params[0].ExDate)
+
+	row := ListCorporateEventsByTickerRow{
+		ID:             7,
+		Ticker:         params[0].Ticker,
+		EventType:      params[0].EventType,
+		ExDate:         params[0].ExDate,
+		Factor:         params[0].Factor,
+		Amount:         params[0].Amount,
+		ReferencePrice: pgtype.Numeric{Int: big.NewInt(3190), Exp: -2, Valid: true},
+	}
+	got := row.ToCorporateEvent()
+	assert.Equal(t, int32(7), got.ID)
+	assert.Equal(t, entity.CorporateEventCashDividend, got.Type)
+	assert.True(t, event.Amount.Equal(got.Amount))
+	assert.True(t, decimal.New(3190, -2).Equal(got.ReferencePrice))
+
+	assert.Equal(t, ListCorporateEventsByTickerParams{
+		Ticker:    "PETR4",
+		AfterDate: pgtype.Date{Valid: false},
+	}, NewListCorporateEventsByTickerParams("PETR4", nil))
+}
@@ -4,35 +4,228 @@ import (
 	"b3challenge/internal/adapter/db/sqlc"
 	"b3challenge/internal/domain/entity"
 	"context"
+	"sync"
 	"time"
 
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/pkg/errors"
 )
 
+const (
+	createTradesStaging = `CREATE TEMPORARY TABLE trades_staging (
+    reference_date DATE NOT NULL,
+    ticker TEXT NOT NULL,
+    update_action SMALLINT NOT NULL,
+    price DECIMAL(18, 3) NOT NULL,
+    quantity INTEGER NOT NULL,
+    traded_at TIMESTAMPTZ NOT NULL,
+    trade_id BIGINT NOT NULL,
+    session_type SMALLINT NOT NULL,
+    date DATE NOT NULL,
+    buyer_code INTEGER,
+    seller_code INTEGER
+) ON COMMIT DROP`
+	createTradeCancellationsStaging = `CREATE TEMPORARY TABLE trade_cancellations_staging (
+    ticker TEXT NOT NULL,
+    date DATE NOT NULL,
+    trade_id BIGINT NOT NULL
+) ON COMMIT DROP`
+)
+
 type TradeRepository struct {
-	db      *pgxpool.Pool
-	querier sqlc.Querier
+	db         *pgxpool.Pool
+	querier    sqlc.Querier
+	partitions sync.Map
 }
 
 func NewTradeRepository(db *pgxpool.Pool) *TradeRepository {
 	return &TradeRepository{
-		db:      db,
-		querier: sqlc.New(db),
+		db:         db,
+		querier:    sqlc.New(db),
+		partitions: sync.Map{},
 	}
 }
 
 func (r *TradeRepository) CreateTrades(ctx context.Context, trades []entity.Trade) (int64, error) {
-	params := sqlc.NewToCreateTradesParams(trades)
+	months := tradeMonths(trades)
+	if err := r.ensurePartitions(ctx, months); err != nil {
+		return 0, errors.Wrap(err, "ensure partitions")
+	}
+
+	params := sqlc.NewToCopyTradesToStagingParams(trades)
 
-	affected, err := r.querier.CreateTrades(ctx, params)
+	affected, err := mergeThroughStaging(ctx, r.db, createTradesStaging, func(q *sqlc.Queries) (int64, error) {
+		if _, err := q.CopyTradesToStaging(ctx, params); err != nil {
+			return 0, errors.Wrap(err, "copy")
+		}
+
+		return q.MergeTradesFromStaging(ctx)
+	})
 	if err != nil {
+		r.forgetPartitions(months)
+
 		return 0, errors.Wrap(err, "create")
 	}
 
 	return affected, nil
 }
 
+func (r *TradeRepository) CreateTradeCancellations(ctx context.Context, trades []entity.Trade) (int64, error) {
+	params := sqlc.NewToCopyTradeCancellationsToStagingParams(trades)
+
+	affected, err := mergeThroughStaging(ctx, r.db, createTradeCancellationsStaging, func(q *sqlc.Queries) (int64, error) {
+		if _, err := q.CopyTradeCancellationsToStaging(ctx, params); err != nil {
+			return 0, errors.Wrap(err, "copy")
+		}
+
+		return q.MergeTradeCancellationsFromStaging(ctx)
+	})
+	if err != nil {
+		return 0, errors.Wrap(err, "create cancellations")
+	}
+
+	return affected, nil
+}
+
+// StageTrades copies trades into the file staging table, where they stay invisible to readers until
+// PromoteStagedTrades moves the whole file into trades.
+func (r *TradeRepository) StageTrades(
+	ctx context.Context,
+	ingestionID int32,
+	trades []entity.Trade,
+	lines []int64,
+) (int64, error) {
+	params := sqlc.NewCopyTradesToFileStagingParams(ingestionID, trades, lines)
+
+	copied, err := r.querier.CopyTradesToFileStaging(ctx, params)
+	if err != nil {
+		return 0, errors.Wrap(err, "copy")
+	}
+
+	return copied, nil
+}
+
+// ResetStagedTrades hands the rows staged by earlier ingestions of the same file up to the ingestion
+// checkpoint over to ingestion and drops every other row staged for that path.
+func (r *TradeRepository) ResetStagedTrades(ctx context.Context, ingestion *entity.Ingestion) (int64, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return 0, errors.Wrap(err, "begin")
+	}
+	defer tx.Rollback(ctx) //nolint:errcheck
+
+	queries := sqlc.New(tx)
+	adopted, err := queries.AdoptStagedTrades(ctx, sqlc.NewAdoptStagedTradesParams(ingestion))
+	if err != nil {
+		return 0, errors.Wrap(err, "adopt")
+	}
+
+	stale := sqlc.DeleteStaleStagedTradesParams{IngestionID: ingestion.ID, Path: ingestion.Path}
+	if _, err := queries.DeleteStaleStagedTrades(ctx, stale); err != nil {
+		return 0, errors.Wrap(err, "delete stale")
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return 0, errors.Wrap(err, "commit")
+	}
+
+	return adopted, nil
+}
+
+func (r *TradeRepository) PromoteStagedTrades(ctx context.Context, ingestionID int32) (int64, int64, error) {
+	staged, err := r.querier.ListStagedTradeMonths(ctx, ingestionID)
+	if err != nil {
+		return 0, 0, errors.Wrap(err, "list months")
+	}
+	months := make([]time.Time, 0, len(staged))
+	for _, month := range staged {
+		months = append(months, month.Time)
+	}
+	if err := r.ensurePartitions(ctx, months); err != nil {
+		return 0, 0, errors.Wrap(err, "ensure partitions")
+	}
+
+	created, cancelled, err := r.promoteStagedTrades(ctx, ingestionID)
+	if err != nil {
+		r.forgetPartitions(months)
+
+		return 0, 0, err
+	}
+
+	return created, cancelled, nil
+}
+
+func (r *TradeRepository) promoteStagedTrades(ctx context.Context, ingestionID int32) (int64, int64, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return 0, 0, errors.Wrap(err, "begin")
+	}
+	defer tx.Rollback(ctx) //nolint:errcheck
+
+	queries := sqlc.New(tx)
+	created, err := queries.PromoteStagedTrades(ctx, ingestionID)
+	if err != nil {
+		return 0, 0, errors.Wrap(err, "promote trades")
+	}
+
+	cancelled, err := queries.PromoteStagedTradeCancellations(ctx, ingestionID)
+	if err != nil {
+		return 0, 0, errors.Wrap(err, "promote cancellations")
+	}
+
+	if _, err := queries.DeleteStagedTrades(ctx, ingestionID); err != nil {
+		return 0, 0, errors.Wrap(err, "delete staged")
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return 0, 0, errors.Wrap(err, "commit")
+	}
+
+	return created, cancelled, nil
+}
+
+func (r *TradeRepository) DiscardStagedTrades(ctx context.Context, ingestionID int32) (int64, error) {
+	affected, err := r.querier.DeleteStagedTrades(ctx, ingestionID)
+	if err != nil {
+		return 0, errors.Wrap(err, "delete staged")
+	}
+
+	return affected, nil
+}
+
+func (r *TradeRepository) ApplyTradeCancellations(ctx context.Context) (int64, error) {
+	affected, err := r.querier.ApplyTradeCancellations(ctx)
+	if err != nil {
+		return 0, errors.Wrap(err, "apply cancellations")
+	}
+
+	return affected, nil
+}
+
+func (r *TradeRepository) DeleteTradesByDate(ctx context.Context, date time.Time) (int64, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return 0, errors.Wrap(err, "begin")
+	}
+	defer tx.Rollback(ctx) //nolint:errcheck
+
+	queries := sqlc.New(tx)
+	if _, err := queries.DeleteTradeCancellationsByDate(ctx, sqlc.NewDate(date)); err != nil {
+		return 0, errors.Wrap(err, "delete cancellations")
+	}
+
+	affected, err := queries.DeleteTradesByDate(ctx, sqlc.NewDate(date))
+	if err != nil {
+		return 0, errors.Wrap(err, "delete trades")
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return 0, errors.Wrap(err, "commit")
+	}
+
+	return affected, nil
+}
+
 func (r *TradeRepository) ListTradeInfoByTickerAndDate(
 	ctx context.Context,
 	ticker string,
@@ -52,3 +245,69 @@ func (r *TradeRepository) ListTradeInfoByTickerAndDate(
 
 	return result, nil
 }
+
+func tradeMonths(trades []entity.Trade) []time.Time {
+	var months []time.Time
+	for i := range trades {
+		month := time.Date(trades[i].Date.Year(), trades[i].Date.Month(), 1, 0, 0, 0, 0, time.UTC)
+		if len(months) > 0 && month.Equal(months[len(months)-1]) {
+			continue
+		}
+		months = append(months, month)
+	}
+
+	return months
+}
+
+// ensurePartitions makes sure the partition of every month exists and is attached to trades. Months
+// already checked are cached; ensure_trades_partition fails when the partition was detached.
+func (r *TradeRepository) ensurePartitions(ctx context.Context, months []time.Time) error {
+	for _, month := range months {
+		if _, ok := r.partitions.Load(month); ok {
+			continue
+		}
+
+		if _, err := r.querier.EnsureTradesPartition(ctx, sqlc.NewDate(month)); err != nil {
+			return errors.Wrap(err, "ensure partition")
+		}
+		r.partitions.Store(month, struct{}{})
+	}
+
+	return nil
+}
+
+// forgetPartitions drops months from the cache after a failed write, so a partition detached since
+// it was cached is checked again on the next write instead of failing with "no partition found".
+func (r *TradeRepository) forgetPartitions(months []time.Time) {
+	for _, month := range months {
+		r.partitions.Delete(month)
+	}
+}
+
+func mergeThroughStaging(
+	ctx context.Context,
+	db *pgxpool.Pool,
+	createStaging string,
+	merge func(q *sqlc.Queries) (int64, error),
+) (int64, error) {
+	tx, err := db.Begin(ctx)
+	if err != nil {
+		return 0, errors.Wrap(err, "begin")
+	}
+	defer tx.Rollback(ctx) //nolint:errcheck
+
+	if _, err := tx.Exec(ctx, createStaging); err != nil {
+		return 0, errors.Wrap(err, "create staging")
+	}
+
+	affected, err := merge(sqlc.New(tx))
+	if err != nil {
+		return 0, errors.Wrap(err, "merge")
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return 0, errors.Wrap(err, "commit")
+	}
+
+	return affected, nil
+}
@@ -12,9 +12,11 @@ var (
 )
 
 type ComputeTickerMetricsRequest struct {
-	Ticker     string     `query:"ticker"`
-	TradeDate  *string    `query:"trade_date"`
-	ParsedDate *time.Time `query:"-"`
+	Ticker            string     `query:"ticker"`
+	TradeDate         *string    `query:"trade_date"`
+	IncludeInstrument bool       `query:"include_instrument"`
+	Adjusted          bool       `query:"adjusted"`
+	ParsedDate        *time.Time `query:"-"`
 }
 
 func (r *ComputeTickerMetricsRequest) Validate() error {
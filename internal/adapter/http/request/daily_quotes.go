@@ -0,0 +1,54 @@
+package request
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+var (
+	ErrInvalidFromDate  = errors.New("invalid from date, must be in format YYYY-MM-DD")
+	ErrInvalidToDate    = errors.New("invalid to date, must be in format YYYY-MM-DD")
+	ErrInvalidDateRange = errors.New("invalid date range, from must not be after to")
+)
+
+type ListDailyQuotesRequest struct {
+	Ticker     string     `query:"ticker"`
+	From       *string    `query:"from"`
+	To         *string    `query:"to"`
+	Adjusted   bool       `query:"adjusted"`
+	ParsedFrom *time.Time `query:"-"`
+	ParsedTo   *time.Time `query:"-"`
+}
+
+func (r *ListDailyQuotesRequest) Validate() error {
+	if r.Ticker == "" {
+		return ErrTickerIsRequired
+	}
+
+	var err error
+	if r.ParsedFrom, err = parseOptionalDate(r.From); err != nil {
+		return errors.Wrap(ErrInvalidFromDate, err.Error())
+	}
+	if r.ParsedTo, err = parseOptionalDate(r.To); err != nil {
+		return errors.Wrap(ErrInvalidToDate, err.Error())
+	}
+	if r.ParsedFrom != nil && r.ParsedTo != nil && r.ParsedFrom.After(*r.ParsedTo) {
+		return ErrInvalidDateRange
+	}
+
+	return nil
+}
+
+func parseOptionalDate(raw *string) (*time.Time, error) {
+	if raw == nil || *raw == "" {
+		return nil, nil //nolint:nilnil
+	}
+
+	parsed, err := time.Parse(time.DateOnly, *raw)
+	if err != nil {
+		return nil, errors.Wrap(err, "parse date")
+	}
+
+	return &parsed, nil
+}
@@ -0,0 +1,82 @@
+package request
+
+import (
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+const uploadFormField = "file"
+
+var (
+	ErrMissingUploadFile = errors.New("missing upload, send a multipart \"file\" field or a raw body")
+	ErrMissingFileName   = errors.New("missing file name, use the filename query parameter or Content-Disposition")
+	ErrInvalidIngestion  = errors.New("invalid ingestion id")
+)
+
+type IngestionIDRequest struct {
+	ID int32 `param:"id"`
+}
+
+func (r *IngestionIDRequest) Validate() error {
+	if r.ID <= 0 {
+		return ErrInvalidIngestion
+	}
+
+	return nil
+}
+
+// OpenIngestionUpload streams the uploaded file without buffering it: the first
+// "file" part of a multipart request, or the raw request body otherwise.
+func OpenIngestionUpload(req *http.Request) (string, io.Reader, error) {
+	mediaType, _, _ := mime.ParseMediaType(req.Header.Get("Content-Type"))
+	if mediaType != "multipart/form-data" {
+		name := req.URL.Query().Get("filename")
+		if name == "" {
+			name = dispositionFileName(req.Header.Get("Content-Disposition"))
+		}
+		if name == "" {
+			return "", nil, ErrMissingFileName
+		}
+
+		return name, req.Body, nil
+	}
+
+	reader, err := req.MultipartReader()
+	if err != nil {
+		return "", nil, errors.Wrap(ErrMissingUploadFile, err.Error())
+	}
+	for {
+		part, err := reader.NextPart()
+		if errors.Is(err, io.EOF) {
+			return "", nil, ErrMissingUploadFile
+		}
+		if err != nil {
+			return "", nil, errors.Wrap(ErrMissingUploadFile, err.Error())
+		}
+		if part.FormName() != uploadFormField {
+			continue
+		}
+		if strings.TrimSpace(part.FileName()) == "" {
+			return "", nil, ErrMissingFileName
+		}
+
+		return part.FileName(), part, nil
+	}
+}
+
+func dispositionFileName(header string) string {
+	if header == "" {
+		return ""
+	}
+
+	_, params, err := mime.ParseMediaType(header)
+	if err != nil {
+		return ""
+	}
+
+	return params["filename"]
+}
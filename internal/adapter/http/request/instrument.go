@@ -0,0 +1,13 @@
+package request
+
+type InstrumentRequest struct {
+	Ticker string `param:"ticker"`
+}
+
+func (r *InstrumentRequest) Validate() error {
+	if r.Ticker == "" {
+		return ErrTickerIsRequired
+	}
+
+	return nil
+}
@@ -3,9 +3,10 @@ package response
 import "github.com/shopspring/decimal"
 
 type ComputeTickerMetricsResponse struct {
-	Ticker         string  `json:"ticker"`
-	MaxRangeValue  float64 `json:"max_range_value"`
-	MaxDailyVolume int     `json:"max_daily_volume"`
+	Ticker         string              `json:"ticker"`
+	MaxRangeValue  float64             `json:"max_range_value"`
+	MaxDailyVolume int                 `json:"max_daily_volume"`
+	Instrument     *InstrumentResponse `exhaustruct:"optional" json:"instrument,omitempty"`
 }
 
 func NewComputeTickerMetricsResponse(
@@ -0,0 +1,74 @@
+package response
+
+import (
+	"b3challenge/internal/domain/entity"
+	"time"
+)
+
+type DailyQuoteResponse struct {
+	Date                  string  `json:"date"`
+	BDICode               int16   `json:"bdi_code"`
+	BDIDescription        string  `json:"bdi_description"`
+	MarketType            int16   `json:"market_type"`
+	MarketTypeDescription string  `json:"market_type_description"`
+	CompanyName           string  `json:"company_name"`
+	Specification         string  `json:"specification"`
+	ForwardTermDays       int16   `json:"forward_term_days"`
+	Currency              string  `json:"currency"`
+	OpenPrice             float64 `json:"open_price"`
+	MaxPrice              float64 `json:"max_price"`
+	MinPrice              float64 `json:"min_price"`
+	AvgPrice              float64 `json:"avg_price"`
+	ClosePrice            float64 `json:"close_price"`
+	BestBidPrice          float64 `json:"best_bid_price"`
+	BestAskPrice          float64 `json:"best_ask_price"`
+	TradesCount           int32   `json:"trades_count"`
+	Quantity              int64   `json:"quantity"`
+	Volume                float64 `json:"volume"`
+	StrikePrice           float64 `json:"strike_price"`
+	ExpirationDate        *string `json:"expiration_date"`
+	QuoteFactor           int32   `json:"quote_factor"`
+	ISIN                  string  `json:"isin"`
+}
+
+type DailyQuotesResponse struct {
+	Ticker string               `json:"ticker"`
+	Quotes []DailyQuoteResponse `json:"quotes"`
+}
+
+func NewDailyQuotesResponse(ticker string, quotes []entity.DailyQuote) DailyQuotesResponse {
+	res := DailyQuotesResponse{
+		Ticker: ticker,
+		Quotes: make([]DailyQuoteResponse, 0, len(quotes)),
+	}
+
+	for _, quote := range quotes {
+		res.Quotes = append(res.Quotes, DailyQuoteResponse{
+			Date:                  quote.Date.Format(time.DateOnly),
+			BDICode:               int16(quote.BDICode),
+			BDIDescription:        quote.BDICode.String(),
+			MarketType:            int16(quote.MarketType),
+			MarketTypeDescription: quote.MarketType.String(),
+			CompanyName:           quote.CompanyName,
+			Specification:         quote.Specification,
+			ForwardTermDays:       quote.ForwardTermDays,
+			Currency:              quote.Currency,
+			OpenPrice:             quote.OpenPrice.InexactFloat64(),
+			MaxPrice:              quote.MaxPrice.InexactFloat64(),
+			MinPrice:              quote.MinPrice.InexactFloat64(),
+			AvgPrice:              quote.AvgPrice.InexactFloat64(),
+			ClosePrice:            quote.ClosePrice.InexactFloat64(),
+			BestBidPrice:          quote.BestBidPrice.InexactFloat64(),
+			BestAskPrice:          quote.BestAskPrice.InexactFloat64(),
+			TradesCount:           quote.TradesCount,
+			Quantity:              quote.Quantity,
+			Volume:                quote.Volume.InexactFloat64(),
+			StrikePrice:           quote.StrikePrice.InexactFloat64(),
+			ExpirationDate:        formatOptionalDate(quote.ExpirationDate),
+			QuoteFactor:           quote.QuoteFactor,
+			ISIN:                  quote.ISIN,
+		})
+	}
+
+	return res
+}
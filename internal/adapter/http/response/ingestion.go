@@ -0,0 +1,35 @@
+package response
+
+import (
+	"b3challenge/internal/domain/entity"
+	"path/filepath"
+	"time"
+)
+
+type IngestionResponse struct {
+	ID           int32      `json:"id"`
+	File         string     `json:"file"`
+	SizeBytes    int64      `json:"size_bytes"`
+	Checksum     string     `json:"checksum"`
+	Status       string     `json:"status"`
+	RowsParsed   int64      `json:"rows_parsed"`
+	RowsRejected int64      `json:"rows_rejected"`
+	RowsInserted int64      `json:"rows_inserted"`
+	StartedAt    time.Time  `json:"started_at"`
+	FinishedAt   *time.Time `json:"finished_at"`
+}
+
+func NewIngestionResponse(ingestion *entity.Ingestion) IngestionResponse {
+	return IngestionResponse{
+		ID:           ingestion.ID,
+		File:         filepath.Base(ingestion.Path),
+		SizeBytes:    ingestion.SizeBytes,
+		Checksum:     ingestion.Checksum,
+		Status:       string(ingestion.Status),
+		RowsParsed:   ingestion.RowsParsed,
+		RowsRejected: ingestion.RowsRejected,
+		RowsInserted: ingestion.RowsInserted,
+		StartedAt:    ingestion.StartedAt,
+		FinishedAt:   ingestion.FinishedAt,
+	}
+}
@@ -0,0 +1,50 @@
+package response
+
+import (
+	"b3challenge/internal/domain/entity"
+	"time"
+)
+
+type InstrumentResponse struct {
+	Ticker           string  `json:"ticker"`
+	ISIN             string  `json:"isin"`
+	Asset            string  `json:"asset"`
+	IssuerName       string  `json:"issuer_name"`
+	SecurityType     string  `json:"security_type"`
+	Segment          string  `json:"segment"`
+	Market           string  `json:"market"`
+	Specification    string  `json:"specification"`
+	Currency         string  `json:"currency"`
+	LotSize          int64   `json:"lot_size"`
+	TradingStartDate *string `json:"trading_start_date"`
+	TradingEndDate   *string `json:"trading_end_date"`
+	ReferenceDate    string  `json:"reference_date"`
+}
+
+func NewInstrumentResponse(instrument *entity.Instrument) *InstrumentResponse {
+	return &InstrumentResponse{
+		Ticker:           instrument.Ticker,
+		ISIN:             instrument.ISIN,
+		Asset:            instrument.Asset,
+		IssuerName:       instrument.IssuerName,
+		SecurityType:     instrument.SecurityType,
+		Segment:          instrument.Segment,
+		Market:           instrument.Market,
+		Specification:    instrument.Specification,
+		Currency:         instrument.Currency,
+		LotSize:          instrument.LotSize,
+		TradingStartDate: formatOptionalDate(instrument.TradingStartDate),
+		TradingEndDate:   formatOptionalDate(instrument.TradingEndDate),
+		ReferenceDate:    instrument.ReferenceDate.Format(time.DateOnly),
+	}
+}
+
+func formatOptionalDate(value *time.Time) *string {
+	if value == nil {
+		return nil
+	}
+
+	formatted := value.Format(time.DateOnly)
+
+	return &formatted
+}
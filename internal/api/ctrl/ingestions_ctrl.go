@@ -0,0 +1,100 @@
+package ctrl
+
+import (
+	"b3challenge/internal/adapter/http/request"
+	"b3challenge/internal/adapter/http/response"
+	"b3challenge/internal/domain/entity"
+	"b3challenge/internal/domain/usecase"
+	"context"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"github.com/pkg/errors"
+)
+
+//go:generate mockgen -source=ingestions_ctrl.go -destination=ingestions_ctrl_mock.go -package=ctrl IngestionJobsUC
+type IngestionJobsUC interface {
+	Start(ctx context.Context, name string, body io.Reader) (*entity.Ingestion, error)
+	Get(ctx context.Context, id int32) (*entity.Ingestion, error)
+	Cancel(ctx context.Context, id int32) (*entity.Ingestion, error)
+}
+
+type IngestionsCtrl struct {
+	uc IngestionJobsUC
+}
+
+func NewIngestionsCtrl(uc IngestionJobsUC) *IngestionsCtrl {
+	return &IngestionsCtrl{
+		uc: uc,
+	}
+}
+
+func (h *IngestionsCtrl) CreateIngestion(c echo.Context) error {
+	name, body, err := request.OpenIngestionUpload(c.Request())
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	ingestion, err := h.uc.Start(c.Request().Context(), name, body)
+	if errors.Is(err, usecase.ErrUnsupportedFile) {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "internal server error: "+err.Error())
+	}
+
+	c.Response().Header().Set(echo.HeaderLocation, "/ingestions/"+strconv.Itoa(int(ingestion.ID)))
+
+	return c.JSON(http.StatusAccepted, response.NewIngestionResponse(ingestion))
+}
+
+func (h *IngestionsCtrl) GetIngestion(c echo.Context) error {
+	id, err := bindIngestionID(c)
+	if err != nil {
+		return err
+	}
+
+	ingestion, err := h.uc.Get(c.Request().Context(), id)
+	if errors.Is(err, usecase.ErrIngestionNotFound) {
+		return echo.NewHTTPError(http.StatusNotFound, err.Error())
+	}
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "internal server error: "+err.Error())
+	}
+
+	return c.JSON(http.StatusOK, response.NewIngestionResponse(ingestion))
+}
+
+func (h *IngestionsCtrl) CancelIngestion(c echo.Context) error {
+	id, err := bindIngestionID(c)
+	if err != nil {
+		return err
+	}
+
+	ingestion, err := h.uc.Cancel(c.Request().Context(), id)
+	switch {
+	case errors.Is(err, usecase.ErrIngestionNotFound):
+		return echo.NewHTTPError(http.StatusNotFound, err.Error())
+	case errors.Is(err, usecase.ErrIngestionNotRunning):
+		return echo.NewHTTPError(http.StatusConflict, err.Error())
+	case err != nil:
+		return echo.NewHTTPError(http.StatusInternalServerError, "internal server error: "+err.Error())
+	}
+
+	return c.JSON(http.StatusAccepted, response.NewIngestionResponse(ingestion))
+}
+
+func bindIngestionID(c echo.Context) (int32, error) {
+	var req request.IngestionIDRequest
+	if err := (&echo.DefaultBinder{}).BindPathParams(c, &req); err != nil {
+		return 0, echo.NewHTTPError(http.StatusBadRequest, request.ErrInvalidIngestion.Error())
+	}
+
+	if err := req.Validate(); err != nil {
+		return 0, echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	return req.ID, nil
+}
@@ -0,0 +1,88 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ingestions_ctrl.go
+//
+// Generated by this command:
+//
+//	mockgen -source=ingestions_ctrl.go -destination=ingestions_ctrl_mock.go -package=ctrl IngestionJobsUC
+//
+
+// Package ctrl is a generated GoMock package.
+package ctrl
+
+import (
+	entity "b3challenge/internal/domain/entity"
+	context "context"
+	io "io"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockIngestionJobsUC is a mock of IngestionJobsUC interface.
+type MockIngestionJobsUC struct {
+	ctrl     *gomock.Controller
+	recorder *MockIngestionJobsUCMockRecorder
+	isgomock struct{}
+}
+
+// MockIngestionJobsUCMockRecorder is the mock recorder for MockIngestionJobsUC.
+type MockIngestionJobsUCMockRecorder struct {
+	mock *MockIngestionJobsUC
+}
+
+// NewMockIngestionJobsUC creates a new mock instance.
+func NewMockIngestionJobsUC(ctrl *gomock.Controller) *MockIngestionJobsUC {
+	mock := &MockIngestionJobsUC{ctrl: ctrl}
+	mock.recorder = &MockIngestionJobsUCMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIngestionJobsUC) EXPECT() *MockIngestionJobsUCMockRecorder {
+	return m.recorder
+}
+
+// Cancel mocks base method.
+func (m *MockIngestionJobsUC) Cancel(ctx context.Context, id int32) (*entity.Ingestion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Cancel", ctx, id)
+	ret0, _ := ret[0].(*entity.Ingestion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Cancel indicates an expected call of Cancel.
+func (mr *MockIngestionJobsUCMockRecorder) Cancel(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Cancel", reflect.TypeOf((*MockIngestionJobsUC)(nil).Cancel), ctx, id)
+}
+
+// Get mocks base method.
+func (m *MockIngestionJobsUC) Get(ctx context.Context, id int32) (*entity.Ingestion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, id)
+	ret0, _ := ret[0].(*entity.Ingestion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockIngestionJobsUCMockRecorder) Get(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockIngestionJobsUC)(nil).Get), ctx, id)
+}
+
+// Start mocks base method.
+func (m *MockIngestionJobsUC) Start(ctx context.Context, name string, body io.Reader) (*entity.Ingestion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Start", ctx, name, body)
+	ret0, _ := ret[0].(*entity.Ingestion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Start indicates an expected call of Start.
+func (mr *MockIngestionJobsUCMockRecorder) Start(ctx, name, body any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Start", reflect.TypeOf((*MockIngestionJobsUC)(nil).Start), ctx, name, body)
+}
@@ -0,0 +1,290 @@
+package ctrl
+
+import (
+	"b3challenge/internal/domain/entity"
+	"b3challenge/internal/domain/usecase"
+	"bytes"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+const fileName = "02-06-2025_NEGOCIOSAVISTA.txt"
+
+func newTestIngestion(status entity.IngestionStatus) *entity.Ingestion {
+	return &entity.Ingestion{
+		ID:           1,
+		Path:         "uploads/123_" + fileName,
+		SizeBytes:    10,
+		Checksum:     "abc",
+		Status:       status,
+		RowsParsed:   3,
+		RowsRejected: 1,
+		RowsInserted: 2,
+		StartedAt:    time.Date(2025, 6, 14, 10, 0, 0, 0, time.UTC),
+	}
+}
+
+const runningIngestionJSON = `{
+	"id": 1,
+	"file": "123_02-06-2025_NEGOCIOSAVISTA.txt",
+	"size_bytes": 10,
+	"checksum": "abc",
+	"status": "running",
+	"rows_parsed": 3,
+	"rows_rejected": 1,
+	"rows_inserted": 2,
+	"started_at": "2025-06-14T10:00:00Z",
+	"finished_at": null
+}`
+
+func newMultipartRequest(t *testing.T, field, name, content string) *http.Request {
+	t.Helper()
+
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	require.NoError(t, writer.WriteField("note", "ignored"))
+	part, err := writer.CreateFormFile(field, name)
+	require.NoError(t, err)
+	_, err = part.Write([]byte(content))
+	require.NoError(t, err)
+	require.NoError(t, writer.Close())
+
+	req := httptest.NewRequest(http.MethodPost, "/ingestions", &body)
+	req.Header.Set(echo.HeaderContentType, writer.FormDataContentType())
+
+	return req
+}
+
+func TestIngestionsCtrl_CreateIngestion(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	expectUpload := func(content string) IngestionJobsUC {
+		uc := NewMockIngestionJobsUC(ctrl)
+		uc.EXPECT().Start(gomock.Any(), fileName, gomock.Any()).DoAndReturn(
+			func(_ any, _ string, body io.Reader) (*entity.Ingestion, error) {
+				got, err := io.ReadAll(body)
+				assert.NoError(t, err)
+				assert.Equal(t, content, string(got))
+
+				return newTestIngestion(entity.IngestionStatusRunning), nil
+			},
+		)
+		return uc
+	}
+
+	tests := []struct {
+		name     string
+		req      *http.Request
+		uc       IngestionJobsUC
+		wantCode int
+		wantBody string
+	}{
+		{
+			name:     "multipart upload",
+			req:      newMultipartRequest(t, "file", fileName, "rows"),
+			uc:       expectUpload("rows"),
+			wantCode: http.StatusAccepted,
+			wantBody: runningIngestionJSON,
+		},
+		{
+			name: "raw upload",
+			req: func() *http.Request {
+				req := httptest.NewRequest(http.MethodPost, "/ingestions?filename="+fileName, strings.NewReader("raw rows"))
+				req.Header.Set(echo.HeaderContentType, "application/octet-stream")
+				return req
+			}(),
+			uc:       expectUpload("raw rows"),
+			wantCode: http.StatusAccepted,
+			wantBody: runningIngestionJSON,
+		},
+		{
+			name: "raw upload with content disposition",
+			req: func() *http.Request {
+				req := httptest.NewRequest(http.MethodPost, "/ingestions", strings.NewReader("raw rows"))
+				req.Header.Set(echo.HeaderContentDisposition, `attachment; filename="`+fileName+`"`)
+				return req
+			}(),
+			uc:       expectUpload("raw rows"),
+			wantCode: http.StatusAccepted,
+			wantBody: runningIngestionJSON,
+		},
+		{
+			name:     "raw upload without file name",
+			req:      httptest.NewRequest(http.MethodPost, "/ingestions", strings.NewReader("raw rows")),
+			uc:       NewMockIngestionJobsUC(ctrl),
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name:     "multipart without file field",
+			req:      newMultipartRequest(t, "other", fileName, "rows"),
+			uc:       NewMockIngestionJobsUC(ctrl),
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name: "unsupported file",
+			req:  newMultipartRequest(t, "file", "report.pdf", "rows"),
+			uc: func() IngestionJobsUC {
+				uc := NewMockIngestionJobsUC(ctrl)
+				uc.EXPECT().Start(gomock.Any(), "report.pdf", gomock.Any()).Return(nil, usecase.ErrUnsupportedFile)
+				return uc
+			}(),
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name: "internal server error",
+			req:  newMultipartRequest(t, "file", fileName, "rows"),
+			uc: func() IngestionJobsUC {
+				uc := NewMockIngestionJobsUC(ctrl)
+				uc.EXPECT().Start(gomock.Any(), fileName, gomock.Any()).Return(nil, assert.AnError)
+				return uc
+			}(),
+			wantCode: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			rec := httptest.NewRecorder()
+			c := e.NewContext(tt.req, rec)
+			h := NewIngestionsCtrl(tt.uc)
+
+			assertResponse(t, h.CreateIngestion(c), rec, tt.wantCode, tt.wantBody)
+			if tt.wantCode == http.StatusAccepted {
+				assert.Equal(t, "/ingestions/1", rec.Header().Get(echo.HeaderLocation))
+			}
+		})
+	}
+}
+
+func TestIngestionsCtrl_GetIngestion(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	tests := []struct {
+		name     string
+		id       string
+		uc       IngestionJobsUC
+		wantCode int
+		wantBody string
+	}{
+		{
+			name: "found",
+			id:   "1",
+			uc: func() IngestionJobsUC {
+				uc := NewMockIngestionJobsUC(ctrl)
+				uc.EXPECT().Get(gomock.Any(), int32(1)).Return(newTestIngestion(entity.IngestionStatusRunning), nil)
+				return uc
+			}(),
+			wantCode: http.StatusOK,
+			wantBody: runningIngestionJSON,
+		},
+		{
+			name:     "invalid id",
+			id:       "abc",
+			uc:       NewMockIngestionJobsUC(ctrl),
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name: "not found",
+			id:   "2",
+			uc: func() IngestionJobsUC {
+				uc := NewMockIngestionJobsUC(ctrl)
+				uc.EXPECT().Get(gomock.Any(), int32(2)).Return(nil, usecase.ErrIngestionNotFound)
+				return uc
+			}(),
+			wantCode: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			rec := httptest.NewRecorder()
+			c := e.NewContext(httptest.NewRequest(http.MethodGet, "/ingestions/"+tt.id, nil), rec)
+			c.SetParamNames("id")
+			c.SetParamValues(tt.id)
+			h := NewIngestionsCtrl(tt.uc)
+
+			assertResponse(t, h.GetIngestion(c), rec, tt.wantCode, tt.wantBody)
+		})
+	}
+}
+
+func TestIngestionsCtrl_CancelIngestion(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	tests := []struct {
+		name     string
+		uc       IngestionJobsUC
+		wantCode int
+	}{
+		{
+			name: "cancelled",
+			uc: func() IngestionJobsUC {
+				uc := NewMockIngestionJobsUC(ctrl)
+				uc.EXPECT().Cancel(gomock.Any(), int32(1)).Return(newTestIngestion(entity.IngestionStatusRunning), nil)
+				return uc
+			}(),
+			wantCode: http.StatusAccepted,
+		},
+		{
+			name: "not running",
+			uc: func() IngestionJobsUC {
+				uc := NewMockIngestionJobsUC(ctrl)
+				uc.EXPECT().Cancel(gomock.Any(), int32(1)).Return(nil, usecase.ErrIngestionNotRunning)
+				return uc
+			}(),
+			wantCode: http.StatusConflict,
+		},
+		{
+			name: "not found",
+			uc: func() IngestionJobsUC {
+				uc := NewMockIngestionJobsUC(ctrl)
+				uc.EXPECT().Cancel(gomock.Any(), int32(1)).Return(nil, usecase.ErrIngestionNotFound)
+				return uc
+			}(),
+			wantCode: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			rec := httptest.NewRecorder()
+			c := e.NewContext(httptest.NewRequest(http.MethodDelete, "/ingestions/1", nil), rec)
+			c.SetParamNames("id")
+			c.SetParamValues("1")
+			h := NewIngestionsCtrl(tt.uc)
+
+			assertResponse(t, h.CancelIngestion(c), rec, tt.wantCode, "")
+		})
+	}
+}
+
+func assertResponse(t *testing.T, err error, rec *httptest.ResponseRecorder, wantCode int, wantBody string) {
+	t.Helper()
+
+	if wantCode >= http.StatusBadRequest {
+		var httpErr *echo.HTTPError
+		require.ErrorAs(t, err, &httpErr)
+		assert.Equal(t, wantCode, httpErr.Code)
+
+		return
+	}
+
+	require.NoError(t, err)
+	assert.Equal(t, wantCode, rec.Code)
+	if wantBody != "" {
+		assert.JSONEq(t, wantBody, rec.Body.String())
+	}
+}
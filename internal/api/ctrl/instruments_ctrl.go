@@ -0,0 +1,49 @@
+package ctrl
+
+import (
+	"b3challenge/internal/adapter/http/request"
+	"b3challenge/internal/adapter/http/response"
+	"b3challenge/internal/domain/entity"
+	"b3challenge/internal/domain/usecase"
+	"context"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/pkg/errors"
+)
+
+//go:generate mockgen -source=instruments_ctrl.go -destination=instruments_ctrl_mock.go -package=ctrl InstrumentsUC
+type InstrumentsUC interface {
+	GetInstrument(ctx context.Context, ticker string) (*entity.Instrument, error)
+}
+
+type InstrumentsCtrl struct {
+	uc InstrumentsUC
+}
+
+func NewInstrumentsCtrl(uc InstrumentsUC) *InstrumentsCtrl {
+	return &InstrumentsCtrl{
+		uc: uc,
+	}
+}
+
+func (h *InstrumentsCtrl) GetInstrument(c echo.Context) error {
+	var req request.InstrumentRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	if err := req.Validate(); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	instrument, err := h.uc.GetInstrument(c.Request().Context(), req.Ticker)
+	if errors.Is(err, usecase.ErrInstrumentNotFound) {
+		return echo.NewHTTPError(http.StatusNotFound, err.Error())
+	}
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "internal server error: "+err.Error())
+	}
+
+	return c.JSON(http.StatusOK, response.NewInstrumentResponse(instrument))
+}
@@ -0,0 +1,57 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: instruments_ctrl.go
+//
+// Generated by this command:
+//
+//	mockgen -source=instruments_ctrl.go -destination=instruments_ctrl_mock.go -package=ctrl InstrumentsUC
+//
+
+// Package ctrl is a generated GoMock package.
+package ctrl
+
+import (
+	entity "b3challenge/internal/domain/entity"
+	context "context"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockInstrumentsUC is a mock of InstrumentsUC interface.
+type MockInstrumentsUC struct {
+	ctrl     *gomock.Controller
+	recorder *MockInstrumentsUCMockRecorder
+	isgomock struct{}
+}
+
+// MockInstrumentsUCMockRecorder is the mock recorder for MockInstrumentsUC.
+type MockInstrumentsUCMockRecorder struct {
+	mock *MockInstrumentsUC
+}
+
+// NewMockInstrumentsUC creates a new mock instance.
+func NewMockInstrumentsUC(ctrl *gomock.Controller) *MockInstrumentsUC {
+	mock := &MockInstrumentsUC{ctrl: ctrl}
+	mock.recorder = &MockInstrumentsUCMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockInstrumentsUC) EXPECT() *MockInstrumentsUCMockRecorder {
+	return m.recorder
+}
+
+// GetInstrument mocks base method.
+func (m *MockInstrumentsUC) GetInstrument(ctx context.Context, ticker string) (*entity.Instrument, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInstrument", ctx, ticker)
+	ret0, _ := ret[0].(*entity.Instrument)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetInstrument indicates an expected call of GetInstrument.
+func (mr *MockInstrumentsUCMockRecorder) GetInstrument(ctx, ticker any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInstrument", reflect.TypeOf((*MockInstrumentsUC)(nil).GetInstrument), ctx, ticker)
+}
@@ -0,0 +1,109 @@
+package ctrl
+
+import (
+	"b3challenge/internal/domain/entity"
+	"b3challenge/internal/domain/usecase"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+const instrumentJSON = `{
+	"ticker": "VALE3",
+	"isin": "BRVALEACNOR0",
+	"asset": "VALE",
+	"issuer_name": "VALE S.A.",
+	"security_type": "SHARES",
+	"segment": "CASH",
+	"market": "EQUITY-CASH",
+	"specification": "ON NM",
+	"currency": "BRL",
+	"lot_size": 100,
+	"trading_start_date": "2002-01-02",
+	"trading_end_date": null,
+	"reference_date": "2025-06-02"
+}`
+
+func TestInstrumentsCtrl_GetInstrument(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	tradingStart := time.Date(2002, 1, 2, 0, 0, 0, 0, time.UTC)
+	instrument := &entity.Instrument{
+		ReferenceDate:    time.Date(2025, 6, 2, 0, 0, 0, 0, time.UTC),
+		Ticker:           "VALE3",
+		ISIN:             "BRVALEACNOR0",
+		Asset:            "VALE",
+		IssuerName:       "VALE S.A.",
+		SecurityType:     "SHARES",
+		Segment:          "CASH",
+		Market:           "EQUITY-CASH",
+		Specification:    "ON NM",
+		Currency:         "BRL",
+		LotSize:          100,
+		TradingStartDate: &tradingStart,
+		TradingEndDate:   nil,
+	}
+
+	tests := []struct {
+		name     string
+		ticker   string
+		uc       InstrumentsUC
+		wantCode int
+		wantBody string
+	}{
+		{
+			name:   "successful request",
+			ticker: "VALE3",
+			uc: func() InstrumentsUC {
+				uc := NewMockInstrumentsUC(ctrl)
+				uc.EXPECT().GetInstrument(gomock.Any(), "VALE3").Return(instrument, nil)
+				return uc
+			}(),
+			wantCode: http.StatusOK,
+			wantBody: instrumentJSON,
+		},
+		{
+			name:     "missing ticker",
+			ticker:   "",
+			uc:       NewMockInstrumentsUC(ctrl),
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name:   "not found",
+			ticker: "XXXX3",
+			uc: func() InstrumentsUC {
+				uc := NewMockInstrumentsUC(ctrl)
+				uc.EXPECT().GetInstrument(gomock.Any(), "XXXX3").Return(nil, usecase.ErrInstrumentNotFound)
+				return uc
+			}(),
+			wantCode: http.StatusNotFound,
+		},
+		{
+			name:   "internal server error",
+			ticker: "VALE3",
+			uc: func() InstrumentsUC {
+				uc := NewMockInstrumentsUC(ctrl)
+				uc.EXPECT().GetInstrument(gomock.Any(), "VALE3").Return(nil, assert.AnError)
+				return uc
+			}(),
+			wantCode: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			rec := httptest.NewRecorder()
+			c := e.NewContext(httptest.NewRequest(http.MethodGet, "/instruments/"+tt.ticker, nil), rec)
+			c.SetParamNames("ticker")
+			c.SetParamValues(tt.ticker)
+			h := NewInstrumentsCtrl(tt.uc)
+
+			assertResponse(t, h.GetInstrument(c), rec, tt.wantCode, tt.wantBody)
+		})
+	}
+}
@@ -0,0 +1,45 @@
+package ctrl
+
+import (
+	"b3challenge/internal/adapter/http/request"
+	"b3challenge/internal/adapter/http/response"
+	"b3challenge/internal/domain/entity"
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+//go:generate mockgen -source=quotes_ctrl.go -destination=quotes_ctrl_mock.go -package=ctrl QuotesUC
+type QuotesUC interface {
+	ListDailyQuotes(ctx context.Context, ticker string, from, to *time.Time, adjusted bool) ([]entity.DailyQuote, error)
+}
+
+type QuotesCtrl struct {
+	uc QuotesUC
+}
+
+func NewQuotesCtrl(uc QuotesUC) *QuotesCtrl {
+	return &QuotesCtrl{
+		uc: uc,
+	}
+}
+
+func (h *QuotesCtrl) ListDailyQuotes(c echo.Context) error {
+	var req request.ListDailyQuotesRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	if err := req.Validate(); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	quotes, err := h.uc.ListDailyQuotes(c.Request().Context(), req.Ticker, req.ParsedFrom, req.ParsedTo, req.Adjusted)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "internal server error: "+err.Error())
+	}
+
+	return c.JSON(http.StatusOK, response.NewDailyQuotesResponse(req.Ticker, quotes))
+}
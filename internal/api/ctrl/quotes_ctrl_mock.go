@@ -0,0 +1,58 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: quotes_ctrl.go
+//
+// Generated by this command:
+//
+//	mockgen -source=quotes_ctrl.go -destination=quotes_ctrl_mock.go -package=ctrl QuotesUC
+//
+
+// Package ctrl is a generated GoMock package.
+package ctrl
+
+import (
+	entity "b3challenge/internal/domain/entity"
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockQuotesUC is a mock of QuotesUC interface.
+type MockQuotesUC struct {
+	ctrl     *gomock.Controller
+	recorder *MockQuotesUCMockRecorder
+	isgomock struct{}
+}
+
+// MockQuotesUCMockRecorder is the mock recorder for MockQuotesUC.
+type MockQuotesUCMockRecorder struct {
+	mock *MockQuotesUC
+}
+
+// NewMockQuotesUC creates a new mock instance.
+func NewMockQuotesUC(ctrl *gomock.Controller) *MockQuotesUC {
+	mock := &MockQuotesUC{ctrl: ctrl}
+	mock.recorder = &MockQuotesUCMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockQuotesUC) EXPECT() *MockQuotesUCMockRecorder {
+	return m.recorder
+}
+
+// ListDailyQuotes mocks base method.
+func (m *MockQuotesUC) ListDailyQuotes(ctx context.Context, ticker string, from, to *time.Time, adjusted bool) ([]entity.DailyQuote, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDailyQuotes", ctx, ticker, from, to, adjusted)
+	ret0, _ := ret[0].([]entity.DailyQuote)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDailyQuotes indicates an expected call of ListDailyQuotes.
+func (mr *MockQuotesUCMockRecorder) ListDailyQuotes(ctx, ticker, from, to, adjusted any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDailyQuotes", reflect.TypeOf((*MockQuotesUC)(nil).ListDailyQuotes), ctx, ticker, from, to, adjusted)
+}
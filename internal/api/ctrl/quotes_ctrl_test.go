@@ -0,0 +1,142 @@
+package ctrl
+
+import (
+	"b3challenge/internal/domain/entity"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+const dailyQuotesJSON = `{
+	"ticker": "PETRF320",
+	"quotes": [{
+		"date": "2025-06-02",
+		"bdi_code": 78,
+		"bdi_description": "OPCOES DE COMPRA",
+		"market_type": 70,
+		"market_type_description": "OPCOES DE COMPRA",
+		"company_name": "PETR",
+		"specification": "PN N2",
+		"forward_term_days": 0,
+		"currency": "R$",
+		"open_price": 0.42,
+		"max_price": 0.51,
+		"min_price": 0.38,
+		"avg_price": 0.45,
+		"close_price": 0.47,
+		"best_bid_price": 0.46,
+		"best_ask_price": 0.48,
+		"trades_count": 812,
+		"quantity": 1500000,
+		"volume": 675000,
+		"strike_price": 32,
+		"expiration_date": "2025-06-20",
+		"quote_factor": 1,
+		"isin": "BRPETRACNPR6"
+	}]
+}`
+
+func TestQuotesCtrl_ListDailyQuotes(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	date := time.Date(2025, 6, 2, 0, 0, 0, 0, time.UTC)
+	expiration := time.Date(2025, 6, 20, 0, 0, 0, 0, time.UTC)
+	quote := entity.DailyQuote{
+		Date:           date,
+		Ticker:         "PETRF320",
+		BDICode:        78,
+		MarketType:     entity.MarketTypeCallOption,
+		CompanyName:    "PETR",
+		Specification:  "PN N2",
+		Currency:       "R$",
+		OpenPrice:      decimal.New(42, -2),
+		MaxPrice:       decimal.New(51, -2),
+		MinPrice:       decimal.New(38, -2),
+		AvgPrice:       decimal.New(45, -2),
+		ClosePrice:     decimal.New(47, -2),
+		BestBidPrice:   decimal.New(46, -2),
+		BestAskPrice:   decimal.New(48, -2),
+		TradesCount:    812,
+		Quantity:       1500000,
+		Volume:         decimal.New(67500000, -2),
+		StrikePrice:    decimal.New(3200, -2),
+		ExpirationDate: &expiration,
+		QuoteFactor:    1,
+		ISIN:           "BRPETRACNPR6",
+	}
+
+	tests := []struct {
+		name     string
+		query    string
+		uc       QuotesUC
+		wantCode int
+		wantBody string
+	}{
+		{
+			name:  "successful request",
+			query: "ticker=PETRF320&from=2025-06-02&to=2025-06-02&adjusted=true",
+			uc: func() QuotesUC {
+				uc := NewMockQuotesUC(ctrl)
+				uc.EXPECT().ListDailyQuotes(gomock.Any(), "PETRF320", &date, &date, true).Return([]entity.DailyQuote{quote}, nil)
+				return uc
+			}(),
+			wantCode: http.StatusOK,
+			wantBody: dailyQuotesJSON,
+		},
+		{
+			name:  "no quotes",
+			query: "ticker=PETR4",
+			uc: func() QuotesUC {
+				uc := NewMockQuotesUC(ctrl)
+				uc.EXPECT().ListDailyQuotes(gomock.Any(), "PETR4", nil, nil, false).Return(nil, nil)
+				return uc
+			}(),
+			wantCode: http.StatusOK,
+			wantBody: `{"ticker": "PETR4", "quotes": []}`,
+		},
+		{
+			name:     "missing ticker",
+			query:    "from=2025-06-02",
+			uc:       NewMockQuotesUC(ctrl),
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name:     "invalid date",
+			query:    "ticker=PETR4&to=02-06-2025",
+			uc:       NewMockQuotesUC(ctrl),
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name:     "inverted range",
+			query:    "ticker=PETR4&from=2025-06-03&to=2025-06-02",
+			uc:       NewMockQuotesUC(ctrl),
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name:  "internal server error",
+			query: "ticker=PETR4",
+			uc: func() QuotesUC {
+				uc := NewMockQuotesUC(ctrl)
+				uc.EXPECT().ListDailyQuotes(gomock.Any(), "PETR4", nil, nil, false).Return(nil, assert.AnError)
+				return uc
+			}(),
+			wantCode: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			rec := httptest.NewRecorder()
+			c := e.NewContext(httptest.NewRequest(http.MethodGet, "/daily-quotes?"+tt.query, nil), rec)
+			h := NewQuotesCtrl(tt.uc)
+
+			assertResponse(t, h.ListDailyQuotes(c), rec, tt.wantCode, tt.wantBody)
+		})
+	}
+}
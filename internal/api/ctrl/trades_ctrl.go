@@ -3,25 +3,29 @@ package ctrl
 import (
 	"b3challenge/internal/adapter/http/request"
 	"b3challenge/internal/adapter/http/response"
+	"b3challenge/internal/domain/usecase"
 	"context"
 	"net/http"
 	"time"
 
 	"github.com/labstack/echo/v4"
+	"github.com/pkg/errors"
 	"github.com/shopspring/decimal"
 )
 
 //go:generate mockgen -source=trades_ctrl.go -destination=trades_ctrl_mock.go -package=ctrl TradesUC
 type TradesUC interface {
-	ComputeTickerMetrics(ctx context.Context, ticker string, date *time.Time) (decimal.Decimal, int, error)
+	ComputeTickerMetrics(ctx context.Context, ticker string, date *time.Time, adjusted bool) (decimal.Decimal, int, error)
 }
 type TradesCtrl struct {
-	uc TradesUC
+	uc          TradesUC
+	instruments InstrumentsUC
 }
 
-func NewTradesCtrl(uc TradesUC) *TradesCtrl {
+func NewTradesCtrl(uc TradesUC, instruments InstrumentsUC) *TradesCtrl {
 	return &TradesCtrl{
-		uc: uc,
+		uc:          uc,
+		instruments: instruments,
 	}
 }
 
@@ -35,12 +39,24 @@ func (h *TradesCtrl) ComputeTickerMetrics(c echo.Context) error {
 		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
 	}
 
-	maxRangeValue, maxDailyValue, err := h.uc.ComputeTickerMetrics(c.Request().Context(), req.Ticker, req.ParsedDate)
+	maxRangeValue, maxDailyValue, err := h.uc.ComputeTickerMetrics(
+		c.Request().Context(), req.Ticker, req.ParsedDate, req.Adjusted,
+	)
 	if err != nil {
 		return echo.NewHTTPError(http.StatusInternalServerError, "internal server error: "+err.Error())
 	}
 
 	res := response.NewComputeTickerMetricsResponse(req.Ticker, maxRangeValue, maxDailyValue)
+	if req.IncludeInstrument {
+		instrument, err := h.instruments.GetInstrument(c.Request().Context(), req.Ticker)
+		switch {
+		case errors.Is(err, usecase.ErrInstrumentNotFound):
+		case err != nil:
+			return echo.NewHTTPError(http.StatusInternalServerError, "internal server error: "+err.Error())
+		default:
+			res.Instrument = response.NewInstrumentResponse(instrument)
+		}
+	}
 
 	return c.JSON(http.StatusOK, res)
 }
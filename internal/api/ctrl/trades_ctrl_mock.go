@@ -43,9 +43,9 @@ func (m *MockTradesUC) EXPECT() *MockTradesUCMockRecorder {
 }
 
 // ComputeTickerMetrics mocks base method.
-func (m *MockTradesUC) ComputeTickerMetrics(ctx context.Context, ticker string, date *time.Time) (decimal.Decimal, int, error) {
+func (m *MockTradesUC) ComputeTickerMetrics(ctx context.Context, ticker string, date *time.Time, adjusted bool) (decimal.Decimal, int, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ComputeTickerMetrics", ctx, ticker, date)
+	ret := m.ctrl.Call(m, "ComputeTickerMetrics", ctx, ticker, date, adjusted)
 	ret0, _ := ret[0].(decimal.Decimal)
 	ret1, _ := ret[1].(int)
 	ret2, _ := ret[2].(error)
@@ -53,7 +53,7 @@ func (m *MockTradesUC) ComputeTickerMetrics(ctx context.Context, ticker string,
 }
 
 // ComputeTickerMetrics indicates an expected call of ComputeTickerMetrics.
-func (mr *MockTradesUCMockRecorder) ComputeTickerMetrics(ctx, ticker, date any) *gomock.Call {
+func (mr *MockTradesUCMockRecorder) ComputeTickerMetrics(ctx, ticker, date, adjusted any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ComputeTickerMetrics", reflect.TypeOf((*MockTradesUC)(nil).ComputeTickerMetrics), ctx, ticker, date)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ComputeTickerMetrics", reflect.TypeOf((*MockTradesUC)(nil).ComputeTickerMetrics), ctx, ticker, date, adjusted)
 }
@@ -3,6 +3,8 @@ package ctrl
 import (
 	"b3challenge/internal/adapter/http/request"
 	"b3challenge/internal/adapter/http/response"
+	"b3challenge/internal/domain/entity"
+	"b3challenge/internal/domain/usecase"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
@@ -19,8 +21,9 @@ import (
 
 func TestNewTradesCtrl(t *testing.T) {
 	ucMock := NewMockTradesUC(gomock.NewController(t))
-	expected := NewTradesCtrl(ucMock)
-	ctrl := NewTradesCtrl(ucMock)
+	instrumentsMock := NewMockInstrumentsUC(gomock.NewController(t))
+	expected := NewTradesCtrl(ucMock, instrumentsMock)
+	ctrl := NewTradesCtrl(ucMock, instrumentsMock)
 	assert.Equal(t, ctrl, expected)
 }
 
@@ -31,6 +34,7 @@ func TestTradesCtrl_ComputeTickerMetrics(t *testing.T) {
 		name        string
 		reqBody     request.ComputeTickerMetricsRequest
 		uc          TradesUC
+		instruments InstrumentsUC
 		wantErr     assert.ErrorAssertionFunc
 		expectedRes *response.ComputeTickerMetricsResponse
 	}{
@@ -44,7 +48,7 @@ func TestTradesCtrl_ComputeTickerMetrics(t *testing.T) {
 				uc := NewMockTradesUC(ctrl)
 				time, err := time.Parse(time.DateOnly, "2025-06-08")
 				assert.NoError(t, err)
-				uc.EXPECT().ComputeTickerMetrics(gomock.Any(), "AAPL", &time).Return(
+				uc.EXPECT().ComputeTickerMetrics(gomock.Any(), "AAPL", &time, false).Return(
 					decimal.NewFromFloat(150.00), 100, nil,
 				).Times(1)
 				return uc
@@ -56,6 +60,117 @@ func TestTradesCtrl_ComputeTickerMetrics(t *testing.T) {
 				MaxDailyVolume: 100,
 			},
 		},
+		{
+			name: "successful adjusted request",
+			reqBody: request.ComputeTickerMetricsRequest{
+				Ticker:    "PETR4",
+				TradeDate: pointer.To("2025-06-02"),
+				Adjusted:  true,
+			},
+			uc: func() TradesUC {
+				uc := NewMockTradesUC(ctrl)
+				uc.EXPECT().ComputeTickerMetrics(gomock.Any(), "PETR4", gomock.Any(), true).Return(decimal.NewFromFloat(16.05), 600, nil)
+				return uc
+			}(),
+			wantErr: assert.NoError,
+			expectedRes: &response.ComputeTickerMetricsResponse{
+				Ticker:         "PETR4",
+				MaxRangeValue:  16.05,
+				MaxDailyVolume: 600,
+			},
+		},
+		{
+			name: "successful request with instrument",
+			reqBody: request.ComputeTickerMetricsRequest{
+				Ticker:            "PETR4",
+				TradeDate:         pointer.To("2025-06-02"),
+				IncludeInstrument: true,
+			},
+			uc: func() TradesUC {
+				uc := NewMockTradesUC(ctrl)
+				uc.EXPECT().ComputeTickerMetrics(gomock.Any(), "PETR4", gomock.Any(), false).Return(decimal.NewFromFloat(32.1), 500, nil)
+				return uc
+			}(),
+			instruments: func() InstrumentsUC {
+				uc := NewMockInstrumentsUC(ctrl)
+				uc.EXPECT().GetInstrument(gomock.Any(), "PETR4").Return(&entity.Instrument{
+					ReferenceDate: time.Date(2025, 6, 2, 0, 0, 0, 0, time.UTC),
+					Ticker:        "PETR4",
+					ISIN:          "BRPETRACNPR6",
+					Asset:         "PETR",
+					IssuerName:    "PETROLEO BRASILEIRO S.A. PETROBRAS",
+					SecurityType:  "SHARES",
+					Segment:       "CASH",
+					Market:        "EQUITY-CASH",
+					Specification: "PN N2",
+					Currency:      "BRL",
+					LotSize:       100,
+				}, nil)
+				return uc
+			}(),
+			wantErr: assert.NoError,
+			expectedRes: &response.ComputeTickerMetricsResponse{
+				Ticker:         "PETR4",
+				MaxRangeValue:  32.1,
+				MaxDailyVolume: 500,
+				Instrument: &response.InstrumentResponse{
+					Ticker:        "PETR4",
+					ISIN:          "BRPETRACNPR6",
+					Asset:         "PETR",
+					IssuerName:    "PETROLEO BRASILEIRO S.A. PETROBRAS",
+					SecurityType:  "SHARES",
+					Segment:       "CASH",
+					Market:        "EQUITY-CASH",
+					Specification: "PN N2",
+					Currency:      "BRL",
+					LotSize:       100,
+					ReferenceDate: "2025-06-02",
+				},
+			},
+		},
+		{
+			name: "unknown instrument is omitted",
+			reqBody: request.ComputeTickerMetricsRequest{
+				Ticker:            "PETR4",
+				TradeDate:         pointer.To("2025-06-02"),
+				IncludeInstrument: true,
+			},
+			uc: func() TradesUC {
+				uc := NewMockTradesUC(ctrl)
+				uc.EXPECT().ComputeTickerMetrics(gomock.Any(), "PETR4", gomock.Any(), false).Return(decimal.NewFromFloat(32.1), 500, nil)
+				return uc
+			}(),
+			instruments: func() InstrumentsUC {
+				uc := NewMockInstrumentsUC(ctrl)
+				uc.EXPECT().GetInstrument(gomock.Any(), "PETR4").Return(nil, usecase.ErrInstrumentNotFound)
+				return uc
+			}(),
+			wantErr: assert.NoError,
+			expectedRes: &response.ComputeTickerMetricsResponse{
+				Ticker:         "PETR4",
+				MaxRangeValue:  32.1,
+				MaxDailyVolume: 500,
+			},
+		},
+		{
+			name: "instrument lookup failure",
+			reqBody: request.ComputeTickerMetricsRequest{
+				Ticker:            "PETR4",
+				TradeDate:         pointer.To("2025-06-02"),
+				IncludeInstrument: true,
+			},
+			uc: func() TradesUC {
+				uc := NewMockTradesUC(ctrl)
+				uc.EXPECT().ComputeTickerMetrics(gomock.Any(), "PETR4", gomock.Any(), false).Return(decimal.NewFromFloat(32.1), 500, nil)
+				return uc
+			}(),
+			instruments: func() InstrumentsUC {
+				uc := NewMockInstrumentsUC(ctrl)
+				uc.EXPECT().GetInstrument(gomock.Any(), "PETR4").Return(nil, assert.AnError)
+				return uc
+			}(),
+			wantErr: assert.Error,
+		},
 		{
 			name: "invalid request - missing ticker",
 			reqBody: request.ComputeTickerMetricsRequest{
@@ -81,7 +196,7 @@ func TestTradesCtrl_ComputeTickerMetrics(t *testing.T) {
 			},
 			uc: func() TradesUC {
 				uc := NewMockTradesUC(ctrl)
-				uc.EXPECT().ComputeTickerMetrics(gomock.Any(), gomock.Any(), gomock.Any()).Return(
+				uc.EXPECT().ComputeTickerMetrics(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(
 					decimal.Decimal{}, 0, assert.AnError,
 				)
 				return uc
@@ -98,7 +213,7 @@ func TestTradesCtrl_ComputeTickerMetrics(t *testing.T) {
 			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
 			rec := httptest.NewRecorder()
 			c := e.NewContext(req, rec)
-			h := NewTradesCtrl(tt.uc)
+			h := NewTradesCtrl(tt.uc, tt.instruments)
 			if !tt.wantErr(t, h.ComputeTickerMetrics(c)) {
 				return
 			}
@@ -2,12 +2,14 @@ package api
 
 import (
 	"b3challenge/internal/api/ctrl"
+	"context"
 	"fmt"
 	"net/http"
 	"time"
 
 	"github.com/labstack/echo/v4"
 	echomiddleware "github.com/labstack/echo/v4/middleware"
+	"github.com/pkg/errors"
 )
 
 type Server struct {
@@ -34,11 +36,30 @@ func (s *Server) Start(port uint16) {
 		ReadTimeout:  timeout,
 	}
 
-	if err := s.router.Start(srv.Addr); err != nil {
+	if err := s.router.StartServer(srv); err != nil && !errors.Is(err, http.ErrServerClosed) {
 		s.router.Logger.Fatal("Failed to start server: ", err)
 	}
 }
 
-func (s *Server) ConfigureRoutes(tradeCtrl *ctrl.TradesCtrl) {
+// Shutdown stops accepting connections and waits for the in-flight requests until ctx is done.
+func (s *Server) Shutdown(ctx context.Context) error {
+	if err := s.router.Shutdown(ctx); err != nil {
+		return errors.Wrap(err, "shutdown server")
+	}
+
+	return nil
+}
+
+func (s *Server) ConfigureRoutes(
+	tradeCtrl *ctrl.TradesCtrl,
+	quotesCtrl *ctrl.QuotesCtrl,
+	instrumentsCtrl *ctrl.InstrumentsCtrl,
+	ingestionsCtrl *ctrl.IngestionsCtrl,
+) {
 	s.router.GET("/ticker-metrics", tradeCtrl.ComputeTickerMetrics)
+	s.router.GET("/daily-quotes", quotesCtrl.ListDailyQuotes)
+	s.router.GET("/instruments/:ticker", instrumentsCtrl.GetInstrument)
+	s.router.POST("/ingestions", ingestionsCtrl.CreateIngestion)
+	s.router.GET("/ingestions/:id", ingestionsCtrl.GetIngestion)
+	s.router.DELETE("/ingestions/:id", ingestionsCtrl.CancelIngestion)
 }
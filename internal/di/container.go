@@ -9,30 +9,96 @@ import (
 )
 
 type Container struct {
-	database         *pgxpool.Pool
-	tradesRepository *db.TradeRepository
-	tradesUC         *usecase.TradesUC
+	database                  *pgxpool.Pool
+	tradesRepository          *db.TradeRepository
+	tradesUC                  *usecase.TradesUC
+	ingestionsRepository      *db.IngestionRepository
+	ingestionsUC              *usecase.IngestionsUC
+	partitionsRepository      *db.PartitionRepository
+	partitionsUC              *usecase.PartitionsUC
+	quotesRepository          *db.QuoteRepository
+	quotesUC                  *usecase.QuotesUC
+	instrumentsRepository     *db.InstrumentRepository
+	instrumentsUC             *usecase.InstrumentsUC
+	corporateEventsRepository *db.CorporateEventRepository
+	corporateEventsUC         *usecase.CorporateEventsUC
 }
 
 func NewContainer(database *pgxpool.Pool) *Container {
+	corporateEventsRepository := db.NewCorporateEventRepository(database)
+	corporateEventsUC := usecase.NewCorporateEventsUC(corporateEventsRepository)
 	tradesRepository := db.NewTradeRepository(database)
-	tradesUC := usecase.NewTradesUC(tradesRepository)
+	tradesUC := usecase.NewTradesUC(tradesRepository, corporateEventsRepository)
+	ingestionsRepository := db.NewIngestionRepository(database)
+	ingestionsUC := usecase.NewIngestionsUC(ingestionsRepository)
+	partitionsRepository := db.NewPartitionRepository(database)
+	partitionsUC := usecase.NewPartitionsUC(partitionsRepository)
+	quotesRepository := db.NewQuoteRepository(database)
+	quotesUC := usecase.NewQuotesUC(quotesRepository, corporateEventsRepository)
+	instrumentsRepository := db.NewInstrumentRepository(database)
+	instrumentsUC := usecase.NewInstrumentsUC(instrumentsRepository)
 
 	return &Container{
-		database:         database,
-		tradesRepository: tradesRepository,
-		tradesUC:         tradesUC,
+		database:                  database,
+		tradesRepository:          tradesRepository,
+		tradesUC:                  tradesUC,
+		ingestionsRepository:      ingestionsRepository,
+		ingestionsUC:              ingestionsUC,
+		partitionsRepository:      partitionsRepository,
+		partitionsUC:              partitionsUC,
+		quotesRepository:          quotesRepository,
+		quotesUC:                  quotesUC,
+		instrumentsRepository:     instrumentsRepository,
+		instrumentsUC:             instrumentsUC,
+		corporateEventsRepository: corporateEventsRepository,
+		corporateEventsUC:         corporateEventsUC,
 	}
 }
 
 func (c *Container) NewTradesHandler() *ctrl.TradesCtrl {
-	return ctrl.NewTradesCtrl(c.tradesUC)
+	return ctrl.NewTradesCtrl(c.tradesUC, c.instrumentsUC)
+}
+
+func (c *Container) NewQuotesHandler() *ctrl.QuotesCtrl {
+	return ctrl.NewQuotesCtrl(c.quotesUC)
+}
+
+func (c *Container) NewInstrumentsHandler() *ctrl.InstrumentsCtrl {
+	return ctrl.NewInstrumentsCtrl(c.instrumentsUC)
+}
+
+func (c *Container) NewIngestionsHandler(jobs *usecase.IngestionJobsUC) *ctrl.IngestionsCtrl {
+	return ctrl.NewIngestionsCtrl(jobs)
+}
+
+func (c *Container) NewIngestionJobsUC(loader usecase.TradeFileLoader) *usecase.IngestionJobsUC {
+	return usecase.NewIngestionJobsUC(c.ingestionsUC, c.tradesUC, loader)
 }
 
 func (c *Container) GetTradesUC() *usecase.TradesUC {
 	return c.tradesUC
 }
 
+func (c *Container) GetIngestionsUC() *usecase.IngestionsUC {
+	return c.ingestionsUC
+}
+
+func (c *Container) GetPartitionsUC() *usecase.PartitionsUC {
+	return c.partitionsUC
+}
+
+func (c *Container) GetQuotesUC() *usecase.QuotesUC {
+	return c.quotesUC
+}
+
+func (c *Container) GetInstrumentsUC() *usecase.InstrumentsUC {
+	return c.instrumentsUC
+}
+
+func (c *Container) GetCorporateEventsUC() *usecase.CorporateEventsUC {
+	return c.corporateEventsUC
+}
+
 func (c *Container) DB() *pgxpool.Pool {
 	return c.database
 }
@@ -0,0 +1,89 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+const AdjustedPriceDecimals = 3
+
+type CorporateEventType string
+
+const (
+	CorporateEventSplit            CorporateEventType = "split"
+	CorporateEventReverseSplit     CorporateEventType = "reverse_split"
+	CorporateEventCashDividend     CorporateEventType = "cash_dividend"
+	CorporateEventInterestOnEquity CorporateEventType = "interest_on_equity"
+)
+
+func (t CorporateEventType) IsKnown() bool {
+	switch t {
+	case CorporateEventSplit, CorporateEventReverseSplit, CorporateEventCashDividend, CorporateEventInterestOnEquity:
+		return true
+	default:
+		return false
+	}
+}
+
+func (t CorporateEventType) IsShareEvent() bool {
+	return t == CorporateEventSplit || t == CorporateEventReverseSplit
+}
+
+type CorporateEvent struct {
+	ID             int32     `exhaustruct:"optional"`
+	CreatedAt      time.Time `exhaustruct:"optional"`
+	UpdatedAt      time.Time `exhaustruct:"optional"`
+	Ticker         string
+	Type           CorporateEventType
+	ExDate         time.Time
+	Factor         decimal.Decimal
+	Amount         decimal.Decimal
+	ReferencePrice decimal.Decimal `exhaustruct:"optional"`
+}
+
+func (e *CorporateEvent) PriceFactor() decimal.Decimal {
+	if e.Type.IsShareEvent() {
+		if !e.Factor.IsPositive() {
+			return decimal.NewFromInt(1)
+		}
+
+		return decimal.NewFromInt(1).Div(e.Factor)
+	}
+	if !e.Amount.IsPositive() || e.ReferencePrice.LessThanOrEqual(e.Amount) {
+		return decimal.NewFromInt(1)
+	}
+
+	return decimal.NewFromInt(1).Sub(e.Amount.Div(e.ReferencePrice))
+}
+
+func (e *CorporateEvent) QuantityFactor() decimal.Decimal {
+	if !e.Type.IsShareEvent() || !e.Factor.IsPositive() {
+		return decimal.NewFromInt(1)
+	}
+
+	return e.Factor
+}
+
+type PriceAdjustment struct {
+	Price    decimal.Decimal
+	Quantity decimal.Decimal
+}
+
+type PriceAdjustments []CorporateEvent
+
+func (a PriceAdjustments) At(date time.Time) PriceAdjustment {
+	adjustment := PriceAdjustment{
+		Price:    decimal.NewFromInt(1),
+		Quantity: decimal.NewFromInt(1),
+	}
+	for i := range a {
+		if !a[i].ExDate.After(date) {
+			continue
+		}
+		adjustment.Price = adjustment.Price.Mul(a[i].PriceFactor())
+		adjustment.Quantity = adjustment.Quantity.Mul(a[i].QuantityFactor())
+	}
+
+	return adjustment
+}
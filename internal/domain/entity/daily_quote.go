@@ -0,0 +1,147 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+type MarketType int16
+
+const (
+	MarketTypeCash               MarketType = 10
+	MarketTypeCallExercise       MarketType = 12
+	MarketTypePutExercise        MarketType = 13
+	MarketTypeAuction            MarketType = 17
+	MarketTypeOddLot             MarketType = 20
+	MarketTypeForward            MarketType = 30
+	MarketTypeFutureRetainedGain MarketType = 50
+	MarketTypeFutureContinuous   MarketType = 60
+	MarketTypeCallOption         MarketType = 70
+	MarketTypePutOption          MarketType = 80
+)
+
+var marketTypeNames = map[MarketType]string{ //nolint:gochecknoglobals
+	MarketTypeCash:               "VISTA",
+	MarketTypeCallExercise:       "EXERCICIO DE OPCOES DE COMPRA",
+	MarketTypePutExercise:        "EXERCICIO DE OPCOES DE VENDA",
+	MarketTypeAuction:            "LEILAO",
+	MarketTypeOddLot:             "FRACIONARIO",
+	MarketTypeForward:            "TERMO",
+	MarketTypeFutureRetainedGain: "FUTURO COM RETENCAO DE GANHO",
+	MarketTypeFutureContinuous:   "FUTURO COM MOVIMENTACAO CONTINUA",
+	MarketTypeCallOption:         "OPCOES DE COMPRA",
+	MarketTypePutOption:          "OPCOES DE VENDA",
+}
+
+func (m MarketType) IsKnown() bool {
+	_, ok := marketTypeNames[m]
+
+	return ok
+}
+
+func (m MarketType) String() string {
+	return marketTypeNames[m]
+}
+
+type BDICode int16
+
+var bdiNames = map[BDICode]string{ //nolint:gochecknoglobals
+	2:  "LOTE PADRAO",
+	5:  "SANCIONADAS PELOS REGULAMENTOS BMFBOVESPA",
+	6:  "CONCORDATARIAS",
+	7:  "RECUPERACAO EXTRAJUDICIAL",
+	8:  "RECUPERACAO JUDICIAL",
+	9:  "RAET - REGIME DE ADMINISTRACAO ESPECIAL TEMPORARIA",
+	10: "DIREITOS E RECIBOS",
+	12: "FUNDOS IMOBILIARIOS",
+	14: "CERT.INVEST/TIT.DIV.PUBLICA",
+	18: "OBRIGACOES",
+	22: "BONUS (PRIVADOS)",
+	26: "APOLICES/BONUS/TITULOS PUBLICOS",
+	32: "EXERCICIO DE OPCOES DE COMPRA DE INDICES",
+	33: "EXERCICIO DE OPCOES DE VENDA DE INDICES",
+	38: "EXERCICIO DE OPCOES DE COMPRA",
+	42: "EXERCICIO DE OPCOES DE VENDA",
+	46: "LEILAO DE TITULOS NAO COTADOS",
+	48: "LEILAO DE PRIVATIZACAO",
+	49: "LEILAO DO FUNDO RECUPERACAO ECONOMICA ESPIRITO SANTO",
+	50: "LEILAO",
+	51: "LEILAO FINOR",
+	52: "LEILAO FINAM",
+	53: "LEILAO FISET",
+	54: "LEILAO DE ACOES EM MORA",
+	56: "VENDAS POR ALVARA JUDICIAL",
+	58: "OUTROS",
+	60: "PERMUTA POR ACOES",
+	61: "META",
+	62: "MERCADO A TERMO",
+	66: "DEBENTURES COM DATA DE VENCIMENTO ATE 3 ANOS",
+	68: "DEBENTURES COM DATA DE VENCIMENTO MAIOR QUE 3 ANOS",
+	70: "FUTURO COM RETENCAO DE GANHOS",
+	71: "MERCADO DE FUTURO",
+	74: "OPCOES DE COMPRA DE INDICES",
+	75: "OPCOES DE VENDA DE INDICES",
+	78: "OPCOES DE COMPRA",
+	82: "OPCOES DE VENDA",
+	83: "BOVESPAFIX",
+	84: "SOMA FIX",
+	90: "TERMO VISTA REGISTRADO",
+	96: "MERCADO FRACIONARIO",
+	99: "TOTAL GERAL",
+}
+
+func (b BDICode) IsKnown() bool {
+	_, ok := bdiNames[b]
+
+	return ok
+}
+
+func (b BDICode) String() string {
+	return bdiNames[b]
+}
+
+type DailyQuote struct {
+	ID              int32     `exhaustruct:"optional"`
+	CreatedAt       time.Time `exhaustruct:"optional"`
+	UpdatedAt       time.Time `exhaustruct:"optional"`
+	Date            time.Time
+	Ticker          string
+	BDICode         BDICode
+	MarketType      MarketType
+	CompanyName     string
+	Specification   string
+	ForwardTermDays int16
+	Currency        string
+	OpenPrice       decimal.Decimal
+	MaxPrice        decimal.Decimal
+	MinPrice        decimal.Decimal
+	AvgPrice        decimal.Decimal
+	ClosePrice      decimal.Decimal
+	BestBidPrice    decimal.Decimal
+	BestAskPrice    decimal.Decimal
+	TradesCount     int32
+	Quantity        int64
+	Volume          decimal.Decimal
+	StrikePrice     decimal.Decimal
+	ExpirationDate  *time.Time
+	QuoteFactor     int32
+	ISIN            string
+}
+
+func (q DailyQuote) Adjusted(adjustment PriceAdjustment) DailyQuote {
+	adjust := func(price decimal.Decimal) decimal.Decimal {
+		return price.Mul(adjustment.Price).Round(AdjustedPriceDecimals)
+	}
+
+	q.OpenPrice = adjust(q.OpenPrice)
+	q.MaxPrice = adjust(q.MaxPrice)
+	q.MinPrice = adjust(q.MinPrice)
+	q.AvgPrice = adjust(q.AvgPrice)
+	q.ClosePrice = adjust(q.ClosePrice)
+	q.BestBidPrice = adjust(q.BestBidPrice)
+	q.BestAskPrice = adjust(q.BestAskPrice)
+	q.Quantity = decimal.NewFromInt(q.Quantity).Mul(adjustment.Quantity).Round(0).IntPart()
+
+	return q
+}
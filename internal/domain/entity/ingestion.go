@@ -0,0 +1,71 @@
+package entity
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+type IngestionStatus string
+
+const (
+	IngestionStatusRunning   IngestionStatus = "running"
+	IngestionStatusSucceeded IngestionStatus = "succeeded"
+	IngestionStatusFailed    IngestionStatus = "failed"
+	IngestionStatusPartial   IngestionStatus = "partial"
+	IngestionStatusCancelled IngestionStatus = "cancelled"
+)
+
+type Ingestion struct {
+	ID             int32     `exhaustruct:"optional"`
+	CreatedAt      time.Time `exhaustruct:"optional"`
+	UpdatedAt      time.Time `exhaustruct:"optional"`
+	Path           string
+	SizeBytes      int64
+	Checksum       string
+	Status         IngestionStatus
+	RowsParsed     int64
+	RowsRejected   int64
+	RowsInserted   int64
+	StartedAt      time.Time
+	FinishedAt     *time.Time
+	CheckpointLine int64
+}
+
+func NewIngestion(path string, sizeBytes int64, checksum string, checkpointLine int64) *Ingestion {
+	return &Ingestion{
+		Path:           path,
+		SizeBytes:      sizeBytes,
+		Checksum:       checksum,
+		Status:         IngestionStatusRunning,
+		RowsParsed:     0,
+		RowsRejected:   0,
+		RowsInserted:   0,
+		StartedAt:      time.Now(),
+		FinishedAt:     nil,
+		CheckpointLine: checkpointLine,
+	}
+}
+
+type IngestionProgress struct {
+	rowsParsed   atomic.Int64
+	rowsRejected atomic.Int64
+	rowsInserted atomic.Int64
+}
+
+func (p *IngestionProgress) AddParsed(rows int64) {
+	p.rowsParsed.Add(rows)
+}
+
+func (p *IngestionProgress) AddRejected(rows int64) {
+	p.rowsRejected.Add(rows)
+}
+
+func (p *IngestionProgress) AddInserted(rows int64) {
+	p.rowsInserted.Add(rows)
+}
+
+func (p *IngestionProgress) ApplyTo(ingestion *Ingestion) {
+	ingestion.RowsParsed = p.rowsParsed.Load()
+	ingestion.RowsRejected = p.rowsRejected.Load()
+	ingestion.RowsInserted = p.rowsInserted.Load()
+}
@@ -0,0 +1,22 @@
+package entity
+
+import "time"
+
+type Instrument struct {
+	ID               int32     `exhaustruct:"optional"`
+	CreatedAt        time.Time `exhaustruct:"optional"`
+	UpdatedAt        time.Time `exhaustruct:"optional"`
+	ReferenceDate    time.Time
+	Ticker           string
+	ISIN             string
+	Asset            string
+	IssuerName       string
+	SecurityType     string
+	Segment          string
+	Market           string
+	Specification    string
+	Currency         string
+	LotSize          int64
+	TradingStartDate *time.Time
+	TradingEndDate   *time.Time
+}
@@ -0,0 +1,13 @@
+package entity
+
+import "time"
+
+type TradePartition struct {
+	Name string
+	From time.Time
+	To   time.Time
+}
+
+func (p *TradePartition) IsExpired(cutoff time.Time) bool {
+	return !p.To.After(cutoff)
+}
@@ -2,29 +2,51 @@ package entity
 
 import (
 	"time"
+	_ "time/tzdata"
 
 	"github.com/shopspring/decimal"
 )
 
+const B3TimeZone = "America/Sao_Paulo"
+
+//nolint:gochecknoglobals
+var B3Location = mustLoadLocation(B3TimeZone)
+
+func mustLoadLocation(name string) *time.Location {
+	location, err := time.LoadLocation(name)
+	if err != nil {
+		panic(err)
+	}
+
+	return location
+}
+
+type UpdateAction int16
+
+const (
+	UpdateActionNew    UpdateAction = 0
+	UpdateActionCancel UpdateAction = 2
+)
+
 type Trade struct {
-	ID        int32     `exhaustruct:"optional"`
-	CreatedAt time.Time `exhaustruct:"optional"`
-	UpdatedAt time.Time `exhaustruct:"optional"`
-	Ticker    string
-	Hour      string
-	Date      time.Time
-	Price     decimal.Decimal
-	Quantity  int32
+	ID            int32     `exhaustruct:"optional"`
+	CreatedAt     time.Time `exhaustruct:"optional"`
+	UpdatedAt     time.Time `exhaustruct:"optional"`
+	ReferenceDate time.Time
+	Ticker        string
+	UpdateAction  UpdateAction
+	Price         decimal.Decimal
+	Quantity      int32
+	TradedAt      time.Time
+	TradeID       int64
+	SessionType   int16
+	Date          time.Time
+	BuyerCode     *int32
+	SellerCode    *int32
 }
 
-func NewTrade(ticker, time string, date time.Time, price decimal.Decimal, quantity int32) *Trade {
-	return &Trade{
-		Ticker:   ticker,
-		Hour:     time,
-		Date:     date,
-		Price:    price,
-		Quantity: quantity,
-	}
+func (t *Trade) IsCancellation() bool {
+	return t.UpdateAction == UpdateActionCancel
 }
 
 type TradeInfo struct {
@@ -0,0 +1,57 @@
+package usecase
+
+import (
+	"b3challenge/internal/domain/entity"
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+//go:generate mockgen -source=corporate_events_uc.go -destination=corporate_events_uc_mock.go -package=usecase CorporateEventsRepository
+type CorporateEventsRepository interface {
+	CreateCorporateEvents(ctx context.Context, events []entity.CorporateEvent) (int64, error)
+	ListCorporateEvents(ctx context.Context, ticker string, after *time.Time) ([]entity.CorporateEvent, error)
+}
+
+type CorporateEventsUC struct {
+	repo CorporateEventsRepository
+}
+
+func NewCorporateEventsUC(repo CorporateEventsRepository) *CorporateEventsUC {
+	return &CorporateEventsUC{
+		repo: repo,
+	}
+}
+
+func (uc *CorporateEventsUC) CreateCorporateEvents(ctx context.Context, events []entity.CorporateEvent) (int, error) {
+	if len(events) == 0 {
+		return 0, nil
+	}
+
+	created, err := uc.repo.CreateCorporateEvents(ctx, events)
+	if err != nil {
+		return 0, errors.Wrap(err, "repo create corporate events")
+	}
+
+	return int(created), nil
+}
+
+func listAdjustments(
+	ctx context.Context,
+	repo CorporateEventsRepository,
+	ticker string,
+	after *time.Time,
+	adjusted bool,
+) (entity.PriceAdjustments, error) {
+	if !adjusted {
+		return nil, nil
+	}
+
+	events, err := repo.ListCorporateEvents(ctx, ticker, after)
+	if err != nil {
+		return nil, errors.Wrap(err, "repo list corporate events")
+	}
+
+	return entity.PriceAdjustments(events), nil
+}
@@ -0,0 +1,73 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: corporate_events_uc.go
+//
+// Generated by this command:
+//
+//	mockgen -source=corporate_events_uc.go -destination=corporate_events_uc_mock.go -package=usecase CorporateEventsRepository
+//
+
+// Package usecase is a generated GoMock package.
+package usecase
+
+import (
+	entity "b3challenge/internal/domain/entity"
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockCorporateEventsRepository is a mock of CorporateEventsRepository interface.
+type MockCorporateEventsRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockCorporateEventsRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockCorporateEventsRepositoryMockRecorder is the mock recorder for MockCorporateEventsRepository.
+type MockCorporateEventsRepositoryMockRecorder struct {
+	mock *MockCorporateEventsRepository
+}
+
+// NewMockCorporateEventsRepository creates a new mock instance.
+func NewMockCorporateEventsRepository(ctrl *gomock.Controller) *MockCorporateEventsRepository {
+	mock := &MockCorporateEventsRepository{ctrl: ctrl}
+	mock.recorder = &MockCorporateEventsRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCorporateEventsRepository) EXPECT() *MockCorporateEventsRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateCorporateEvents mocks base method.
+func (m *MockCorporateEventsRepository) CreateCorporateEvents(ctx context.Context, events []entity.CorporateEvent) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCorporateEvents", ctx, events)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateCorporateEvents indicates an expected call of CreateCorporateEvents.
+func (mr *MockCorporateEventsRepositoryMockRecorder) CreateCorporateEvents(ctx, events any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCorporateEvents", reflect.TypeOf((*MockCorporateEventsRepository)(nil).CreateCorporateEvents), ctx, events)
+}
+
+// ListCorporateEvents mocks base method.
+func (m *MockCorporateEventsRepository) ListCorporateEvents(ctx context.Context, ticker string, after *time.Time) ([]entity.CorporateEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCorporateEvents", ctx, ticker, after)
+	ret0, _ := ret[0].([]entity.CorporateEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCorporateEvents indicates an expected call of ListCorporateEvents.
+func (mr *MockCorporateEventsRepositoryMockRecorder) ListCorporateEvents(ctx, ticker, after any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCorporateEvents", reflect.TypeOf((*MockCorporateEventsRepository)(nil).ListCorporateEvents), ctx, ticker, after)
+}
@@ -0,0 +1,69 @@
+package usecase
+
+import (
+	"b3challenge/internal/domain/entity"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCorporateEventsUC_CreateCorporateEvents(t *testing.T) {
+	events := []entity.CorporateEvent{
+		{
+			Ticker: "PETR4",
+			Type:   entity.CorporateEventSplit,
+			ExDate: time.Date(2025, 6, 3, 0, 0, 0, 0, time.UTC),
+			Factor: decimal.NewFromInt(2),
+			Amount: decimal.Zero,
+		},
+	}
+
+	tests := []struct {
+		name    string
+		events  []entity.CorporateEvent
+		repo    func(repo *MockCorporateEventsRepository)
+		want    int
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name:   "created",
+			events: events,
+			repo: func(repo *MockCorporateEventsRepository) {
+				repo.EXPECT().CreateCorporateEvents(gomock.Any(), events).Return(int64(1), nil)
+			},
+			want:    1,
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "empty batch",
+			events:  nil,
+			repo:    func(_ *MockCorporateEventsRepository) {},
+			want:    0,
+			wantErr: assert.NoError,
+		},
+		{
+			name:   "repository error",
+			events: events,
+			repo: func(repo *MockCorporateEventsRepository) {
+				repo.EXPECT().CreateCorporateEvents(gomock.Any(), events).Return(int64(0), assert.AnError)
+			},
+			want:    0,
+			wantErr: assert.Error,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := NewMockCorporateEventsRepository(gomock.NewController(t))
+			tt.repo(repo)
+
+			got, err := NewCorporateEventsUC(repo).CreateCorporateEvents(context.Background(), tt.events)
+			tt.wantErr(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
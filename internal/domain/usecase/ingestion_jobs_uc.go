@@ -0,0 +1,213 @@
+package usecase
+
+import (
+	"b3challenge/internal/domain/entity"
+	"context"
+	"io"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+var (
+	ErrUnsupportedFile     = errors.New("unsupported file, expected a B3 .txt, .zip or .gz file an instruments or corporate events .csv, or a .jsonl or .parquet trades file")
+	ErrIngestionNotFound   = errors.New("ingestion not found")
+	ErrIngestionNotRunning = errors.New("ingestion is not running")
+)
+
+//go:generate mockgen -source=ingestion_jobs_uc.go -destination=ingestion_jobs_uc_mock.go -package=usecase TradeFileLoader
+type TradeFileLoader interface {
+	Supports(name string) bool
+	Store(name string, body io.Reader) (string, int64, string, error)
+	Load(ctx context.Context, ingestionID int32, path string, progress *entity.IngestionProgress) error
+	Remove(path string) error
+}
+
+type ingestionJob struct {
+	ingestion *entity.Ingestion
+	progress  *entity.IngestionProgress
+	cancel    context.CancelFunc
+	cancelled bool
+}
+
+type IngestionJobsUC struct {
+	ingestions *IngestionsUC
+	trades     *TradesUC
+	loader     TradeFileLoader
+	mu         sync.Mutex
+	jobs       map[int32]*ingestionJob
+	wg         sync.WaitGroup
+}
+
+func NewIngestionJobsUC(ingestions *IngestionsUC, trades *TradesUC, loader TradeFileLoader) *IngestionJobsUC {
+	return &IngestionJobsUC{
+		ingestions: ingestions,
+		trades:     trades,
+		loader:     loader,
+		mu:         sync.Mutex{},
+		jobs:       make(map[int32]*ingestionJob),
+		wg:         sync.WaitGroup{},
+	}
+}
+
+func (uc *IngestionJobsUC) Start(ctx context.Context, name string, body io.Reader) (*entity.Ingestion, error) {
+	if !uc.loader.Supports(name) {
+		return nil, ErrUnsupportedFile
+	}
+
+	path, size, checksum, err := uc.loader.Store(name, body)
+	if err != nil {
+		return nil, errors.Wrap(err, "loader store")
+	}
+
+	ingestion, err := uc.ingestions.StartIngestion(ctx, path, size, checksum, 0)
+	if err != nil {
+		_ = uc.loader.Remove(path)
+
+		return nil, errors.Wrap(err, "start ingestion")
+	}
+
+	jobCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+	job := &ingestionJob{
+		ingestion: ingestion,
+		progress:  &entity.IngestionProgress{},
+		cancel:    cancel,
+		cancelled: false,
+	}
+
+	uc.mu.Lock()
+	uc.jobs[ingestion.ID] = job
+	snapshot := job.snapshot()
+	uc.mu.Unlock()
+
+	uc.wg.Add(1)
+	go uc.run(jobCtx, job)
+
+	return snapshot, nil
+}
+
+func (uc *IngestionJobsUC) Get(ctx context.Context, id int32) (*entity.Ingestion, error) {
+	uc.mu.Lock()
+	job, ok := uc.jobs[id]
+	if ok {
+		snapshot := job.snapshot()
+		uc.mu.Unlock()
+
+		return snapshot, nil
+	}
+	uc.mu.Unlock()
+
+	ingestion, err := uc.ingestions.GetIngestion(ctx, id)
+	if err != nil {
+		return nil, errors.Wrap(err, "get ingestion")
+	}
+	if ingestion == nil {
+		return nil, ErrIngestionNotFound
+	}
+
+	return ingestion, nil
+}
+
+func (uc *IngestionJobsUC) Cancel(ctx context.Context, id int32) (*entity.Ingestion, error) {
+	uc.mu.Lock()
+	job, ok := uc.jobs[id]
+	if !ok {
+		uc.mu.Unlock()
+
+		if _, err := uc.Get(ctx, id); err != nil {
+			return nil, err
+		}
+
+		return nil, ErrIngestionNotRunning
+	}
+	defer uc.mu.Unlock()
+
+	if job.ingestion.Status != entity.IngestionStatusRunning {
+		return nil, ErrIngestionNotRunning
+	}
+	job.cancelled = true
+	job.cancel()
+
+	return job.snapshot(), nil
+}
+
+func (uc *IngestionJobsUC) Wait() {
+	uc.wg.Wait()
+}
+
+// Shutdown cancels the running jobs and waits for them to finish. Their ingestions are recorded as
+// failed, unless they had already been cancelled through Cancel.
+func (uc *IngestionJobsUC) Shutdown() {
+	uc.mu.Lock()
+	for _, job := range uc.jobs {
+		job.cancel()
+	}
+	uc.mu.Unlock()
+
+	uc.Wait()
+}
+
+func (uc *IngestionJobsUC) run(ctx context.Context, job *ingestionJob) {
+	defer uc.wg.Done()
+	defer job.cancel()
+
+	err := uc.loader.Load(ctx, job.ingestion.ID, job.ingestion.Path, job.progress)
+	if err == nil {
+		err = uc.promote(ctx, job)
+	}
+	if err != nil {
+		// Staged rows of an upload are never resumed, so they are dropped as soon as the job fails.
+		_, _ = uc.trades.DiscardStagedTrades(context.WithoutCancel(ctx), job.ingestion.ID)
+	}
+	// The uploaded file is only read by this job, whatever its outcome.
+	_ = uc.loader.Remove(job.ingestion.Path)
+
+	uc.mu.Lock()
+	finished := job.snapshot()
+	status := entity.IngestionStatusSucceeded
+	switch {
+	case job.cancelled:
+		status = entity.IngestionStatusCancelled
+	case err != nil:
+		status = entity.IngestionStatusFailed
+	}
+	uc.mu.Unlock()
+
+	// Finished jobs are served from the ingestions table. When the ledger update fails the job stays
+	// in memory so it is still reported as finished.
+	finishErr := uc.ingestions.FinishIngestion(context.WithoutCancel(ctx), finished, status)
+
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+	if finishErr != nil {
+		job.ingestion = finished
+
+		return
+	}
+	delete(uc.jobs, finished.ID)
+}
+
+// promote moves the trades the job staged into the trades table in one transaction and applies the
+// cancellations among them.
+func (uc *IngestionJobsUC) promote(ctx context.Context, job *ingestionJob) error {
+	created, cancelled, err := uc.trades.PromoteStagedTrades(ctx, job.ingestion.ID)
+	if err != nil {
+		return errors.Wrap(err, "promote staged trades")
+	}
+	job.progress.AddInserted(int64(created + cancelled))
+
+	if _, err := uc.trades.ApplyTradeCancellations(ctx); err != nil {
+		return errors.Wrap(err, "apply cancellations")
+	}
+
+	return nil
+}
+
+func (j *ingestionJob) snapshot() *entity.Ingestion {
+	snapshot := *j.ingestion
+	if snapshot.Status == entity.IngestionStatusRunning {
+		j.progress.ApplyTo(&snapshot)
+	}
+
+	return &snapshot
+}
@@ -0,0 +1,102 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ingestion_jobs_uc.go
+//
+// Generated by this command:
+//
+//	mockgen -source=ingestion_jobs_uc.go -destination=ingestion_jobs_uc_mock.go -package=usecase TradeFileLoader
+//
+
+// Package usecase is a generated GoMock package.
+package usecase
+
+import (
+	entity "b3challenge/internal/domain/entity"
+	context "context"
+	io "io"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockTradeFileLoader is a mock of TradeFileLoader interface.
+type MockTradeFileLoader struct {
+	ctrl     *gomock.Controller
+	recorder *MockTradeFileLoaderMockRecorder
+	isgomock struct{}
+}
+
+// MockTradeFileLoaderMockRecorder is the mock recorder for MockTradeFileLoader.
+type MockTradeFileLoaderMockRecorder struct {
+	mock *MockTradeFileLoader
+}
+
+// NewMockTradeFileLoader creates a new mock instance.
+func NewMockTradeFileLoader(ctrl *gomock.Controller) *MockTradeFileLoader {
+	mock := &MockTradeFileLoader{ctrl: ctrl}
+	mock.recorder = &MockTradeFileLoaderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTradeFileLoader) EXPECT() *MockTradeFileLoaderMockRecorder {
+	return m.recorder
+}
+
+// Load mocks base method.
+func (m *MockTradeFileLoader) Load(ctx context.Context, ingestionID int32, path string, progress *entity.IngestionProgress) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Load", ctx, ingestionID, path, progress)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Load indicates an expected call of Load.
+func (mr *MockTradeFileLoaderMockRecorder) Load(ctx, ingestionID, path, progress any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Load", reflect.TypeOf((*MockTradeFileLoader)(nil).Load), ctx, ingestionID, path, progress)
+}
+
+// Remove mocks base method.
+func (m *MockTradeFileLoader) Remove(path string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Remove", path)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Remove indicates an expected call of Remove.
+func (mr *MockTradeFileLoaderMockRecorder) Remove(path any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Remove", reflect.TypeOf((*MockTradeFileLoader)(nil).Remove), path)
+}
+
+// Store mocks base method.
+func (m *MockTradeFileLoader) Store(name string, body io.Reader) (string, int64, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Store", name, body)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(string)
+	ret3, _ := ret[3].(error)
+	return ret0, ret1, ret2, ret3
+}
+
+// Store indicates an expected call of Store.
+func (mr *MockTradeFileLoaderMockRecorder) Store(name, body any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Store", reflect.TypeOf((*MockTradeFileLoader)(nil).Store), name, body)
+}
+
+// Supports mocks base method.
+func (m *MockTradeFileLoader) Supports(name string) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Supports", name)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// Supports indicates an expected call of Supports.
+func (mr *MockTradeFileLoaderMockRecorder) Supports(name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Supports", reflect.TypeOf((*MockTradeFileLoader)(nil).Supports), name)
+}
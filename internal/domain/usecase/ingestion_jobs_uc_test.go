@@ -0,0 +1,300 @@
+package usecase
+
+import (
+	"b3challenge/internal/domain/entity"
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+const uploadPath = "uploads/1_02-06-2025_NEGOCIOSAVISTA.txt"
+
+func newTestIngestionJobsUC(
+	ingestions IngestionsRepository,
+	trades TradesRepository,
+	loader TradeFileLoader,
+) *IngestionJobsUC {
+	return NewIngestionJobsUC(NewIngestionsUC(ingestions), NewTradesUC(trades, nil), loader)
+}
+
+func TestIngestionJobsUC_Start(t *testing.T) {
+	tests := []struct {
+		name         string
+		load         func(ctx context.Context, id int32, path string, progress *entity.IngestionProgress) error
+		trades       func(repo *MockTradesRepository)
+		finishErr    error
+		wantStatus   entity.IngestionStatus
+		wantInserted int64
+	}{
+		{
+			name: "succeeded",
+			load: func(_ context.Context, _ int32, _ string, progress *entity.IngestionProgress) error {
+				progress.AddParsed(3)
+				progress.AddRejected(1)
+
+				return nil
+			},
+			trades: func(repo *MockTradesRepository) {
+				repo.EXPECT().PromoteStagedTrades(gomock.Any(), int32(1)).Return(int64(1), int64(1), nil)
+				repo.EXPECT().ApplyTradeCancellations(gomock.Any()).Return(int64(0), nil)
+			},
+			finishErr:    nil,
+			wantStatus:   entity.IngestionStatusSucceeded,
+			wantInserted: 2,
+		},
+		{
+			name: "finish not recorded",
+			load: func(_ context.Context, _ int32, _ string, progress *entity.IngestionProgress) error {
+				progress.AddParsed(3)
+				progress.AddRejected(1)
+
+				return nil
+			},
+			trades: func(repo *MockTradesRepository) {
+				repo.EXPECT().PromoteStagedTrades(gomock.Any(), int32(1)).Return(int64(2), int64(0), nil)
+				repo.EXPECT().ApplyTradeCancellations(gomock.Any()).Return(int64(0), nil)
+			},
+			finishErr:    assert.AnError,
+			wantStatus:   entity.IngestionStatusSucceeded,
+			wantInserted: 2,
+		},
+		{
+			name: "promote failed",
+			load: func(_ context.Context, _ int32, _ string, progress *entity.IngestionProgress) error {
+				progress.AddParsed(3)
+				progress.AddRejected(1)
+
+				return nil
+			},
+			trades: func(repo *MockTradesRepository) {
+				repo.EXPECT().PromoteStagedTrades(gomock.Any(), int32(1)).Return(int64(0), int64(0), assert.AnError)
+				repo.EXPECT().DiscardStagedTrades(gomock.Any(), int32(1)).Return(int64(2), nil)
+			},
+			finishErr:    nil,
+			wantStatus:   entity.IngestionStatusFailed,
+			wantInserted: 0,
+		},
+		{
+			name: "failed",
+			load: func(_ context.Context, _ int32, _ string, progress *entity.IngestionProgress) error {
+				progress.AddParsed(3)
+				progress.AddRejected(1)
+
+				return assert.AnError
+			},
+			trades: func(repo *MockTradesRepository) {
+				repo.EXPECT().DiscardStagedTrades(gomock.Any(), int32(1)).Return(int64(2), nil)
+			},
+			finishErr:    nil,
+			wantStatus:   entity.IngestionStatusFailed,
+			wantInserted: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			loader := NewMockTradeFileLoader(ctrl)
+			loader.EXPECT().Supports("02-06-2025_NEGOCIOSAVISTA.txt").Return(true)
+			loader.EXPECT().Store("02-06-2025_NEGOCIOSAVISTA.txt", gomock.Any()).Return(uploadPath, int64(10), "abc", nil)
+			gomock.InOrder(
+				loader.EXPECT().Load(gomock.Any(), int32(1), uploadPath, gomock.Any()).DoAndReturn(tt.load),
+				loader.EXPECT().Remove(uploadPath).Return(nil),
+			)
+
+			ingestions := NewMockIngestionsRepository(ctrl)
+			ingestions.EXPECT().CreateIngestion(gomock.Any(), gomock.Any()).Return(int32(1), nil)
+			var finished entity.Ingestion
+			ingestions.EXPECT().FinishIngestion(gomock.Any(), gomock.Any()).DoAndReturn(
+				func(_ context.Context, ingestion *entity.Ingestion) error {
+					assert.Equal(t, tt.wantStatus, ingestion.Status)
+					assert.NotNil(t, ingestion.FinishedAt)
+					finished = *ingestion
+
+					return tt.finishErr
+				},
+			)
+			if tt.finishErr == nil {
+				ingestions.EXPECT().GetIngestion(gomock.Any(), int32(1)).DoAndReturn(
+					func(_ context.Context, _ int32) (*entity.Ingestion, error) {
+						return &finished, nil
+					},
+				)
+			}
+
+			trades := NewMockTradesRepository(ctrl)
+			tt.trades(trades)
+
+			uc := newTestIngestionJobsUC(ingestions, trades, loader)
+			started, err := uc.Start(context.Background(), "02-06-2025_NEGOCIOSAVISTA.txt", strings.NewReader("x"))
+			require.NoError(t, err)
+			assert.Equal(t, int32(1), started.ID)
+			assert.Equal(t, entity.IngestionStatusRunning, started.Status)
+
+			uc.Wait()
+
+			got, err := uc.Get(context.Background(), 1)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantStatus, got.Status)
+			assert.Equal(t, int64(3), got.RowsParsed)
+			assert.Equal(t, int64(1), got.RowsRejected)
+			assert.Equal(t, tt.wantInserted, got.RowsInserted)
+		})
+	}
+}
+
+func TestIngestionJobsUC_Start_Errors(t *testing.T) {
+	tests := []struct {
+		name       string
+		loader     func(ctrl *gomock.Controller) TradeFileLoader
+		ingestions func(repo *MockIngestionsRepository)
+		wantErr    error
+	}{
+		{
+			name: "unsupported file",
+			loader: func(ctrl *gomock.Controller) TradeFileLoader {
+				loader := NewMockTradeFileLoader(ctrl)
+				loader.EXPECT().Supports("report.pdf").Return(false)
+				return loader
+			},
+			ingestions: func(_ *MockIngestionsRepository) {},
+			wantErr:    ErrUnsupportedFile,
+		},
+		{
+			name: "store error",
+			loader: func(ctrl *gomock.Controller) TradeFileLoader {
+				loader := NewMockTradeFileLoader(ctrl)
+				loader.EXPECT().Supports("report.pdf").Return(true)
+				loader.EXPECT().Store("report.pdf", gomock.Any()).Return("", int64(0), "", assert.AnError)
+				return loader
+			},
+			ingestions: func(_ *MockIngestionsRepository) {},
+			wantErr:    assert.AnError,
+		},
+		{
+			name: "start ingestion error removes the upload",
+			loader: func(ctrl *gomock.Controller) TradeFileLoader {
+				loader := NewMockTradeFileLoader(ctrl)
+				loader.EXPECT().Supports("report.pdf").Return(true)
+				loader.EXPECT().Store("report.pdf", gomock.Any()).Return(uploadPath, int64(10), "abc", nil)
+				loader.EXPECT().Remove(uploadPath).Return(nil)
+				return loader
+			},
+			ingestions: func(repo *MockIngestionsRepository) {
+				repo.EXPECT().CreateIngestion(gomock.Any(), gomock.Any()).Return(int32(0), assert.AnError)
+			},
+			wantErr: assert.AnError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			ingestions := NewMockIngestionsRepository(ctrl)
+			tt.ingestions(ingestions)
+			uc := newTestIngestionJobsUC(ingestions, NewMockTradesRepository(ctrl), tt.loader(ctrl))
+			_, err := uc.Start(context.Background(), "report.pdf", strings.NewReader("x"))
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestIngestionJobsUC_Cancel(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	loading := make(chan struct{})
+	loader := NewMockTradeFileLoader(ctrl)
+	loader.EXPECT().Supports(gomock.Any()).Return(true)
+	loader.EXPECT().Store(gomock.Any(), gomock.Any()).Return(uploadPath, int64(10), "abc", nil)
+	loader.EXPECT().Load(gomock.Any(), int32(1), uploadPath, gomock.Any()).DoAndReturn(
+		func(ctx context.Context, _ int32, _ string, _ *entity.IngestionProgress) error {
+			close(loading)
+			<-ctx.Done()
+
+			return ctx.Err()
+		},
+	)
+	loader.EXPECT().Remove(uploadPath).Return(nil)
+
+	ingestions := NewMockIngestionsRepository(ctrl)
+	ingestions.EXPECT().CreateIngestion(gomock.Any(), gomock.Any()).Return(int32(1), nil)
+	var finished entity.Ingestion
+	ingestions.EXPECT().FinishIngestion(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, ingestion *entity.Ingestion) error {
+			finished = *ingestion
+
+			return nil
+		},
+	)
+	ingestions.EXPECT().GetIngestion(gomock.Any(), int32(1)).DoAndReturn(
+		func(_ context.Context, _ int32) (*entity.Ingestion, error) {
+			return &finished, nil
+		},
+	).Times(2)
+	ingestions.EXPECT().GetIngestion(gomock.Any(), int32(2)).Return(nil, nil)
+
+	trades := NewMockTradesRepository(ctrl)
+	trades.EXPECT().DiscardStagedTrades(gomock.Any(), int32(1)).Return(int64(0), nil)
+
+	uc := newTestIngestionJobsUC(ingestions, trades, loader)
+	_, err := uc.Start(context.Background(), "02-06-2025_NEGOCIOSAVISTA.txt", io.LimitReader(nil, 0))
+	require.NoError(t, err)
+	<-loading
+
+	cancelled, err := uc.Cancel(context.Background(), 1)
+	require.NoError(t, err)
+	assert.Equal(t, entity.IngestionStatusRunning, cancelled.Status)
+
+	uc.Wait()
+
+	got, err := uc.Get(context.Background(), 1)
+	require.NoError(t, err)
+	assert.Equal(t, entity.IngestionStatusCancelled, got.Status)
+
+	_, err = uc.Cancel(context.Background(), 1)
+	assert.ErrorIs(t, err, ErrIngestionNotRunning)
+
+	_, err = uc.Cancel(context.Background(), 2)
+	assert.ErrorIs(t, err, ErrIngestionNotFound)
+}
+
+func TestIngestionJobsUC_Shutdown(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	loading := make(chan struct{})
+	loader := NewMockTradeFileLoader(ctrl)
+	loader.EXPECT().Supports(gomock.Any()).Return(true)
+	loader.EXPECT().Store(gomock.Any(), gomock.Any()).Return(uploadPath, int64(10), "abc", nil)
+	loader.EXPECT().Load(gomock.Any(), int32(1), uploadPath, gomock.Any()).DoAndReturn(
+		func(ctx context.Context, _ int32, _ string, _ *entity.IngestionProgress) error {
+			close(loading)
+			<-ctx.Done()
+
+			return ctx.Err()
+		},
+	)
+	loader.EXPECT().Remove(uploadPath).Return(nil)
+
+	ingestions := NewMockIngestionsRepository(ctrl)
+	ingestions.EXPECT().CreateIngestion(gomock.Any(), gomock.Any()).Return(int32(1), nil)
+	ingestions.EXPECT().FinishIngestion(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, ingestion *entity.Ingestion) error {
+			assert.Equal(t, entity.IngestionStatusFailed, ingestion.Status)
+
+			return nil
+		},
+	)
+
+	trades := NewMockTradesRepository(ctrl)
+	trades.EXPECT().DiscardStagedTrades(gomock.Any(), int32(1)).Return(int64(0), nil)
+
+	uc := newTestIngestionJobsUC(ingestions, trades, loader)
+	_, err := uc.Start(context.Background(), "02-06-2025_NEGOCIOSAVISTA.txt", io.LimitReader(nil, 0))
+	require.NoError(t, err)
+	<-loading
+
+	uc.Shutdown()
+}
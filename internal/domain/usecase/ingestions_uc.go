@@ -0,0 +1,101 @@
+package usecase
+
+import (
+	"b3challenge/internal/domain/entity"
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+//go:generate mockgen -source=ingestions_uc.go -destination=ingestions_uc_mock.go -package=usecase IngestionsRepository
+type IngestionsRepository interface {
+	CreateIngestion(ctx context.Context, ingestion *entity.Ingestion) (int32, error)
+	FinishIngestion(ctx context.Context, ingestion *entity.Ingestion) error
+	GetIngestion(ctx context.Context, id int32) (*entity.Ingestion, error)
+	GetResumableIngestion(ctx context.Context, path, checksum string) (*entity.Ingestion, error)
+	HasSucceededIngestion(ctx context.Context, path, checksum string) (bool, error)
+	UpdateIngestionCheckpoint(ctx context.Context, id int32, line int64) error
+}
+
+type IngestionsUC struct {
+	repo IngestionsRepository
+}
+
+func NewIngestionsUC(repo IngestionsRepository) *IngestionsUC {
+	return &IngestionsUC{
+		repo: repo,
+	}
+}
+
+func (uc *IngestionsUC) IsIngested(ctx context.Context, path, checksum string) (bool, error) {
+	exists, err := uc.repo.HasSucceededIngestion(ctx, path, checksum)
+	if err != nil {
+		return false, errors.Wrap(err, "repo has succeeded")
+	}
+
+	return exists, nil
+}
+
+func (uc *IngestionsUC) GetIngestion(ctx context.Context, id int32) (*entity.Ingestion, error) {
+	ingestion, err := uc.repo.GetIngestion(ctx, id)
+	if err != nil {
+		return nil, errors.Wrap(err, "repo get")
+	}
+
+	return ingestion, nil
+}
+
+func (uc *IngestionsUC) GetResumeCheckpoint(ctx context.Context, path, checksum string) (int64, error) {
+	previous, err := uc.repo.GetResumableIngestion(ctx, path, checksum)
+	if err != nil {
+		return 0, errors.Wrap(err, "repo get resumable")
+	}
+	if previous == nil {
+		return 0, nil
+	}
+
+	return previous.CheckpointLine, nil
+}
+
+func (uc *IngestionsUC) StartIngestion(
+	ctx context.Context,
+	path string,
+	sizeBytes int64,
+	checksum string,
+	checkpointLine int64,
+) (*entity.Ingestion, error) {
+	ingestion := entity.NewIngestion(path, sizeBytes, checksum, checkpointLine)
+
+	id, err := uc.repo.CreateIngestion(ctx, ingestion)
+	if err != nil {
+		return nil, errors.Wrap(err, "repo create")
+	}
+	ingestion.ID = id
+
+	return ingestion, nil
+}
+
+func (uc *IngestionsUC) UpdateCheckpoint(ctx context.Context, ingestion *entity.Ingestion, line int64) error {
+	if err := uc.repo.UpdateIngestionCheckpoint(ctx, ingestion.ID, line); err != nil {
+		return errors.Wrap(err, "repo update checkpoint")
+	}
+
+	return nil
+}
+
+func (uc *IngestionsUC) FinishIngestion(
+	ctx context.Context,
+	ingestion *entity.Ingestion,
+	status entity.IngestionStatus,
+) error {
+	finishedAt := time.Now()
+	ingestion.Status = status
+	ingestion.FinishedAt = &finishedAt
+
+	if err := uc.repo.FinishIngestion(ctx, ingestion); err != nil {
+		return errors.Wrap(err, "repo finish")
+	}
+
+	return nil
+}
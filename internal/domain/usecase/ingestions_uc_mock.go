@@ -0,0 +1,130 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ingestions_uc.go
+//
+// Generated by this command:
+//
+//	mockgen -source=ingestions_uc.go -destination=ingestions_uc_mock.go -package=usecase IngestionsRepository
+//
+
+// Package usecase is a generated GoMock package.
+package usecase
+
+import (
+	entity "b3challenge/internal/domain/entity"
+	context "context"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockIngestionsRepository is a mock of IngestionsRepository interface.
+type MockIngestionsRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockIngestionsRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockIngestionsRepositoryMockRecorder is the mock recorder for MockIngestionsRepository.
+type MockIngestionsRepositoryMockRecorder struct {
+	mock *MockIngestionsRepository
+}
+
+// NewMockIngestionsRepository creates a new mock instance.
+func NewMockIngestionsRepository(ctrl *gomock.Controller) *MockIngestionsRepository {
+	mock := &MockIngestionsRepository{ctrl: ctrl}
+	mock.recorder = &MockIngestionsRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIngestionsRepository) EXPECT() *MockIngestionsRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateIngestion mocks base method.
+func (m *MockIngestionsRepository) CreateIngestion(ctx context.Context, ingestion *entity.Ingestion) (int32, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateIngestion", ctx, ingestion)
+	ret0, _ := ret[0].(int32)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateIngestion indicates an expected call of CreateIngestion.
+func (mr *MockIngestionsRepositoryMockRecorder) CreateIngestion(ctx, ingestion any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateIngestion", reflect.TypeOf((*MockIngestionsRepository)(nil).CreateIngestion), ctx, ingestion)
+}
+
+// FinishIngestion mocks base method.
+func (m *MockIngestionsRepository) FinishIngestion(ctx context.Context, ingestion *entity.Ingestion) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FinishIngestion", ctx, ingestion)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// FinishIngestion indicates an expected call of FinishIngestion.
+func (mr *MockIngestionsRepositoryMockRecorder) FinishIngestion(ctx, ingestion any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FinishIngestion", reflect.TypeOf((*MockIngestionsRepository)(nil).FinishIngestion), ctx, ingestion)
+}
+
+// GetIngestion mocks base method.
+func (m *MockIngestionsRepository) GetIngestion(ctx context.Context, id int32) (*entity.Ingestion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetIngestion", ctx, id)
+	ret0, _ := ret[0].(*entity.Ingestion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetIngestion indicates an expected call of GetIngestion.
+func (mr *MockIngestionsRepositoryMockRecorder) GetIngestion(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetIngestion", reflect.TypeOf((*MockIngestionsRepository)(nil).GetIngestion), ctx, id)
+}
+
+// GetResumableIngestion mocks base method.
+func (m *MockIngestionsRepository) GetResumableIngestion(ctx context.Context, path, checksum string) (*entity.Ingestion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetResumableIngestion", ctx, path, checksum)
+	ret0, _ := ret[0].(*entity.Ingestion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetResumableIngestion indicates an expected call of GetResumableIngestion.
+func (mr *MockIngestionsRepositoryMockRecorder) GetResumableIngestion(ctx, path, checksum any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetResumableIngestion", reflect.TypeOf((*MockIngestionsRepository)(nil).GetResumableIngestion), ctx, path, checksum)
+}
+
+// HasSucceededIngestion mocks base method.
+func (m *MockIngestionsRepository) HasSucceededIngestion(ctx context.Context, path, checksum string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HasSucceededIngestion", ctx, path, checksum)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HasSucceededIngestion indicates an expected call of HasSucceededIngestion.
+func (mr *MockIngestionsRepositoryMockRecorder) HasSucceededIngestion(ctx, path, checksum any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasSucceededIngestion", reflect.TypeOf((*MockIngestionsRepository)(nil).HasSucceededIngestion), ctx, path, checksum)
+}
+
+// UpdateIngestionCheckpoint mocks base method.
+func (m *MockIngestionsRepository) UpdateIngestionCheckpoint(ctx context.Context, id int32, line int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateIngestionCheckpoint", ctx, id, line)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateIngestionCheckpoint indicates an expected call of UpdateIngestionCheckpoint.
+func (mr *MockIngestionsRepositoryMockRecorder) UpdateIngestionCheckpoint(ctx, id, line any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateIngestionCheckpoint", reflect.TypeOf((*MockIngestionsRepository)(nil).UpdateIngestionCheckpoint), ctx, id, line)
+}
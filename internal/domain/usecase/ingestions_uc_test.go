@@ -0,0 +1,250 @@
+package usecase
+
+import (
+	"b3challenge/internal/domain/entity"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestNewIngestionsUC(t *testing.T) {
+	repoMock := NewMockIngestionsRepository(gomock.NewController(t))
+	uc := &IngestionsUC{repo: repoMock}
+	got := NewIngestionsUC(repoMock)
+	assert.Equal(t, got, uc)
+}
+
+func TestIngestionsUC_IsIngested(t *testing.T) {
+	const (
+		path     = "b3Data/02-06-2025_NEGOCIOSAVISTA.txt"
+		checksum = "abc"
+	)
+
+	tests := []struct {
+		name    string
+		repo    IngestionsRepository
+		want    bool
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name: "already ingested",
+			repo: func() IngestionsRepository {
+				repo := NewMockIngestionsRepository(gomock.NewController(t))
+				repo.EXPECT().HasSucceededIngestion(gomock.Any(), path, checksum).Return(true, nil)
+				return repo
+			}(),
+			want:    true,
+			wantErr: assert.NoError,
+		},
+		{
+			name: "error case",
+			repo: func() IngestionsRepository {
+				repo := NewMockIngestionsRepository(gomock.NewController(t))
+				repo.EXPECT().HasSucceededIngestion(gomock.Any(), path, checksum).Return(false, assert.AnError)
+				return repo
+			}(),
+			want:    false,
+			wantErr: assert.Error,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc := &IngestionsUC{repo: tt.repo}
+			got, err := uc.IsIngested(context.Background(), path, checksum)
+			if !tt.wantErr(t, err) {
+				return
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestIngestionsUC_GetIngestion(t *testing.T) {
+	tests := []struct {
+		name    string
+		repo    IngestionsRepository
+		want    *entity.Ingestion
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name: "found",
+			repo: func() IngestionsRepository {
+				repo := NewMockIngestionsRepository(gomock.NewController(t))
+				repo.EXPECT().GetIngestion(gomock.Any(), int32(7)).Return(&entity.Ingestion{ID: 7}, nil)
+				return repo
+			}(),
+			want:    &entity.Ingestion{ID: 7},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "error case",
+			repo: func() IngestionsRepository {
+				repo := NewMockIngestionsRepository(gomock.NewController(t))
+				repo.EXPECT().GetIngestion(gomock.Any(), int32(7)).Return(nil, assert.AnError)
+				return repo
+			}(),
+			want:    nil,
+			wantErr: assert.Error,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc := &IngestionsUC{repo: tt.repo}
+			got, err := uc.GetIngestion(context.Background(), 7)
+			if !tt.wantErr(t, err) {
+				return
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestIngestionsUC_GetResumeCheckpoint(t *testing.T) {
+	tests := []struct {
+		name    string
+		repo    IngestionsRepository
+		want    int64
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name: "resumable ingestion found",
+			repo: func() IngestionsRepository {
+				repo := NewMockIngestionsRepository(gomock.NewController(t))
+				repo.EXPECT().GetResumableIngestion(gomock.Any(), "file.txt", "abc").Return(
+					&entity.Ingestion{CheckpointLine: 500}, nil,
+				)
+				return repo
+			}(),
+			want:    500,
+			wantErr: assert.NoError,
+		},
+		{
+			name: "nothing to resume",
+			repo: func() IngestionsRepository {
+				repo := NewMockIngestionsRepository(gomock.NewController(t))
+				repo.EXPECT().GetResumableIngestion(gomock.Any(), "file.txt", "abc").Return(nil, nil)
+				return repo
+			}(),
+			want:    0,
+			wantErr: assert.NoError,
+		},
+		{
+			name: "error case",
+			repo: func() IngestionsRepository {
+				repo := NewMockIngestionsRepository(gomock.NewController(t))
+				repo.EXPECT().GetResumableIngestion(gomock.Any(), "file.txt", "abc").Return(nil, assert.AnError)
+				return repo
+			}(),
+			want:    0,
+			wantErr: assert.Error,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc := &IngestionsUC{repo: tt.repo}
+			got, err := uc.GetResumeCheckpoint(context.Background(), "file.txt", "abc")
+			if !tt.wantErr(t, err) {
+				return
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestIngestionsUC_UpdateCheckpoint(t *testing.T) {
+	ingestion := &entity.Ingestion{ID: 9}
+
+	repo := NewMockIngestionsRepository(gomock.NewController(t))
+	repo.EXPECT().UpdateIngestionCheckpoint(gomock.Any(), int32(9), int64(120)).Return(nil)
+	repo.EXPECT().UpdateIngestionCheckpoint(gomock.Any(), int32(9), int64(240)).Return(assert.AnError)
+
+	uc := &IngestionsUC{repo: repo}
+	assert.NoError(t, uc.UpdateCheckpoint(context.Background(), ingestion, 120))
+	assert.Error(t, uc.UpdateCheckpoint(context.Background(), ingestion, 240))
+}
+
+func TestIngestionsUC_StartIngestion(t *testing.T) {
+	tests := []struct {
+		name    string
+		repo    IngestionsRepository
+		wantID  int32
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name: "successful start",
+			repo: func() IngestionsRepository {
+				repo := NewMockIngestionsRepository(gomock.NewController(t))
+				repo.EXPECT().CreateIngestion(gomock.Any(), gomock.Any()).Return(int32(3), nil)
+				return repo
+			}(),
+			wantID:  3,
+			wantErr: assert.NoError,
+		},
+		{
+			name: "error case",
+			repo: func() IngestionsRepository {
+				repo := NewMockIngestionsRepository(gomock.NewController(t))
+				repo.EXPECT().CreateIngestion(gomock.Any(), gomock.Any()).Return(int32(0), assert.AnError)
+				return repo
+			}(),
+			wantErr: assert.Error,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc := &IngestionsUC{repo: tt.repo}
+			got, err := uc.StartIngestion(context.Background(), "file.txt", 10, "abc", 42)
+			if !tt.wantErr(t, err) || err != nil {
+				return
+			}
+			assert.Equal(t, tt.wantID, got.ID)
+			assert.Equal(t, entity.IngestionStatusRunning, got.Status)
+			assert.Equal(t, "file.txt", got.Path)
+			assert.Equal(t, int64(42), got.CheckpointLine)
+		})
+	}
+}
+
+func TestIngestionsUC_FinishIngestion(t *testing.T) {
+	tests := []struct {
+		name    string
+		repo    IngestionsRepository
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name: "successful finish",
+			repo: func() IngestionsRepository {
+				repo := NewMockIngestionsRepository(gomock.NewController(t))
+				repo.EXPECT().FinishIngestion(gomock.Any(), gomock.Any()).Return(nil)
+				return repo
+			}(),
+			wantErr: assert.NoError,
+		},
+		{
+			name: "error case",
+			repo: func() IngestionsRepository {
+				repo := NewMockIngestionsRepository(gomock.NewController(t))
+				repo.EXPECT().FinishIngestion(gomock.Any(), gomock.Any()).Return(assert.AnError)
+				return repo
+			}(),
+			wantErr: assert.Error,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc := &IngestionsUC{repo: tt.repo}
+			ingestion := entity.NewIngestion("file.txt", 10, "abc", 0)
+			err := uc.FinishIngestion(context.Background(), ingestion, entity.IngestionStatusSucceeded)
+			tt.wantErr(t, err)
+			assert.Equal(t, entity.IngestionStatusSucceeded, ingestion.Status)
+			assert.NotNil(t, ingestion.FinishedAt)
+		})
+	}
+}
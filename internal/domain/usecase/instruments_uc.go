@@ -0,0 +1,51 @@
+package usecase
+
+import (
+	"b3challenge/internal/domain/entity"
+	"context"
+
+	"github.com/pkg/errors"
+)
+
+var ErrInstrumentNotFound = errors.New("instrument not found")
+
+//go:generate mockgen -source=instruments_uc.go -destination=instruments_uc_mock.go -package=usecase InstrumentsRepository
+type InstrumentsRepository interface {
+	UpsertInstruments(ctx context.Context, instruments []entity.Instrument) (int64, error)
+	GetInstrument(ctx context.Context, ticker string) (*entity.Instrument, error)
+}
+
+type InstrumentsUC struct {
+	repo InstrumentsRepository
+}
+
+func NewInstrumentsUC(repo InstrumentsRepository) *InstrumentsUC {
+	return &InstrumentsUC{
+		repo: repo,
+	}
+}
+
+func (uc *InstrumentsUC) UpsertInstruments(ctx context.Context, instruments []entity.Instrument) (int, error) {
+	if len(instruments) == 0 {
+		return 0, nil
+	}
+
+	upserted, err := uc.repo.UpsertInstruments(ctx, instruments)
+	if err != nil {
+		return 0, errors.Wrap(err, "repo upsert instruments")
+	}
+
+	return int(upserted), nil
+}
+
+func (uc *InstrumentsUC) GetInstrument(ctx context.Context, ticker string) (*entity.Instrument, error) {
+	instrument, err := uc.repo.GetInstrument(ctx, ticker)
+	if err != nil {
+		return nil, errors.Wrap(err, "repo get instrument")
+	}
+	if instrument == nil {
+		return nil, ErrInstrumentNotFound
+	}
+
+	return instrument, nil
+}
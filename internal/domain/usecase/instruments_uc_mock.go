@@ -0,0 +1,72 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: instruments_uc.go
+//
+// Generated by this command:
+//
+//	mockgen -source=instruments_uc.go -destination=instruments_uc_mock.go -package=usecase InstrumentsRepository
+//
+
+// Package usecase is a generated GoMock package.
+package usecase
+
+import (
+	entity "b3challenge/internal/domain/entity"
+	context "context"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockInstrumentsRepository is a mock of InstrumentsRepository interface.
+type MockInstrumentsRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockInstrumentsRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockInstrumentsRepositoryMockRecorder is the mock recorder for MockInstrumentsRepository.
+type MockInstrumentsRepositoryMockRecorder struct {
+	mock *MockInstrumentsRepository
+}
+
+// NewMockInstrumentsRepository creates a new mock instance.
+func NewMockInstrumentsRepository(ctrl *gomock.Controller) *MockInstrumentsRepository {
+	mock := &MockInstrumentsRepository{ctrl: ctrl}
+	mock.recorder = &MockInstrumentsRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockInstrumentsRepository) EXPECT() *MockInstrumentsRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetInstrument mocks base method.
+func (m *MockInstrumentsRepository) GetInstrument(ctx context.Context, ticker string) (*entity.Instrument, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInstrument", ctx, ticker)
+	ret0, _ := ret[0].(*entity.Instrument)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetInstrument indicates an expected call of GetInstrument.
+func (mr *MockInstrumentsRepositoryMockRecorder) GetInstrument(ctx, ticker any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInstrument", reflect.TypeOf((*MockInstrumentsRepository)(nil).GetInstrument), ctx, ticker)
+}
+
+// UpsertInstruments mocks base method.
+func (m *MockInstrumentsRepository) UpsertInstruments(ctx context.Context, instruments []entity.Instrument) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertInstruments", ctx, instruments)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpsertInstruments indicates an expected call of UpsertInstruments.
+func (mr *MockInstrumentsRepositoryMockRecorder) UpsertInstruments(ctx, instruments any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertInstruments", reflect.TypeOf((*MockInstrumentsRepository)(nil).UpsertInstruments), ctx, instruments)
+}
@@ -0,0 +1,79 @@
+package usecase
+
+import (
+	"b3challenge/internal/domain/entity"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestInstrumentsUC_UpsertInstruments(t *testing.T) {
+	instruments := []entity.Instrument{{Ticker: "PETR4"}, {Ticker: "VALE3"}}
+
+	tests := []struct {
+		name        string
+		instruments []entity.Instrument
+		repo        func(repo *MockInstrumentsRepository)
+		want        int
+		wantErr     assert.ErrorAssertionFunc
+	}{
+		{
+			name:        "upserted",
+			instruments: instruments,
+			repo: func(repo *MockInstrumentsRepository) {
+				repo.EXPECT().UpsertInstruments(gomock.Any(), instruments).Return(int64(2), nil)
+			},
+			want:    2,
+			wantErr: assert.NoError,
+		},
+		{
+			name:        "empty batch",
+			instruments: nil,
+			repo:        func(_ *MockInstrumentsRepository) {},
+			want:        0,
+			wantErr:     assert.NoError,
+		},
+		{
+			name:        "repository error",
+			instruments: instruments,
+			repo: func(repo *MockInstrumentsRepository) {
+				repo.EXPECT().UpsertInstruments(gomock.Any(), instruments).Return(int64(0), assert.AnError)
+			},
+			want:    0,
+			wantErr: assert.Error,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := NewMockInstrumentsRepository(gomock.NewController(t))
+			tt.repo(repo)
+
+			got, err := NewInstrumentsUC(repo).UpsertInstruments(context.Background(), tt.instruments)
+			tt.wantErr(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestInstrumentsUC_GetInstrument(t *testing.T) {
+	instrument := &entity.Instrument{Ticker: "PETR4", ISIN: "BRPETRACNPR6"}
+
+	repo := NewMockInstrumentsRepository(gomock.NewController(t))
+	repo.EXPECT().GetInstrument(gomock.Any(), "PETR4").Return(instrument, nil)
+	repo.EXPECT().GetInstrument(gomock.Any(), "XPTO3").Return(nil, nil)
+	repo.EXPECT().GetInstrument(gomock.Any(), "VALE3").Return(nil, assert.AnError)
+
+	uc := NewInstrumentsUC(repo)
+	got, err := uc.GetInstrument(context.Background(), "PETR4")
+	assert.NoError(t, err)
+	assert.Equal(t, instrument, got)
+
+	_, err = uc.GetInstrument(context.Background(), "XPTO3")
+	assert.ErrorIs(t, err, ErrInstrumentNotFound)
+
+	_, err = uc.GetInstrument(context.Background(), "VALE3")
+	assert.ErrorIs(t, err, assert.AnError)
+}
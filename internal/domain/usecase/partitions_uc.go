@@ -0,0 +1,132 @@
+package usecase
+
+import (
+	"b3challenge/internal/domain/entity"
+	"context"
+	"io"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+//go:generate mockgen -source=partitions_uc.go -destination=partitions_uc_mock.go -package=usecase PartitionsRepository
+type PartitionsRepository interface {
+	EnsureTradePartition(ctx context.Context, date time.Time) (string, error)
+	ListTradePartitions(ctx context.Context) ([]entity.TradePartition, error)
+	DetachTradePartition(ctx context.Context, name string) error
+	AttachTradePartition(ctx context.Context, partition entity.TradePartition) error
+	DropTradePartition(ctx context.Context, name string) error
+	ExportTradePartition(ctx context.Context, name string, out io.Writer) (int64, error)
+}
+
+type PartitionsUC struct {
+	repo PartitionsRepository
+}
+
+func NewPartitionsUC(repo PartitionsRepository) *PartitionsUC {
+	return &PartitionsUC{
+		repo: repo,
+	}
+}
+
+func (uc *PartitionsUC) EnsureAhead(ctx context.Context, from time.Time, months int) ([]string, error) {
+	start := monthStart(from)
+
+	names := make([]string, 0, months+1)
+	for offset := range months + 1 {
+		name, err := uc.repo.EnsureTradePartition(ctx, start.AddDate(0, offset, 0))
+		if err != nil {
+			return names, errors.Wrap(err, "repo ensure partition")
+		}
+		names = append(names, name)
+	}
+
+	return names, nil
+}
+
+func (uc *PartitionsUC) ListExpired(ctx context.Context, now time.Time, retentionMonths int) ([]entity.TradePartition, error) {
+	partitions, err := uc.repo.ListTradePartitions(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "repo list partitions")
+	}
+
+	cutoff := RetentionCutoff(now, retentionMonths)
+
+	var expired []entity.TradePartition
+	for _, partition := range partitions {
+		if partition.IsExpired(cutoff) {
+			expired = append(expired, partition)
+		}
+	}
+
+	return expired, nil
+}
+
+func (uc *PartitionsUC) Detach(ctx context.Context, partition entity.TradePartition) error {
+	if err := uc.repo.DetachTradePartition(ctx, partition.Name); err != nil {
+		return errors.Wrap(err, "repo detach")
+	}
+
+	return nil
+}
+
+func (uc *PartitionsUC) Drop(ctx context.Context, partition entity.TradePartition) error {
+	if err := uc.repo.DropTradePartition(ctx, partition.Name); err != nil {
+		return errors.Wrap(err, "repo drop")
+	}
+
+	return nil
+}
+
+// Archive detaches the partition so the export sees a stable snapshot, then drops it. When the
+// export fails the partition is attached back: a detached month is invisible to ListExpired and
+// makes ensure_trades_partition reject every later load into it.
+func (uc *PartitionsUC) Archive(ctx context.Context, partition entity.TradePartition, out io.WriteCloser) (int64, error) {
+	if err := uc.Detach(ctx, partition); err != nil {
+		out.Close() //nolint:errcheck
+
+		return 0, err
+	}
+
+	rows, err := uc.export(ctx, partition, out)
+	if err != nil {
+		if attachErr := uc.repo.AttachTradePartition(context.WithoutCancel(ctx), partition); attachErr != nil {
+			return 0, errors.Wrapf(err, "partition left detached (reattach: %v)", attachErr)
+		}
+
+		return 0, err
+	}
+
+	if err := uc.Drop(ctx, partition); err != nil {
+		return rows, err
+	}
+
+	return rows, nil
+}
+
+func (uc *PartitionsUC) export(
+	ctx context.Context,
+	partition entity.TradePartition,
+	out io.WriteCloser,
+) (int64, error) {
+	rows, err := uc.repo.ExportTradePartition(ctx, partition.Name, out)
+	if err != nil {
+		out.Close() //nolint:errcheck
+
+		return 0, errors.Wrap(err, "repo export")
+	}
+
+	if err := out.Close(); err != nil {
+		return 0, errors.Wrap(err, "close archive")
+	}
+
+	return rows, nil
+}
+
+func RetentionCutoff(now time.Time, retentionMonths int) time.Time {
+	return monthStart(now).AddDate(0, -retentionMonths, 0)
+}
+
+func monthStart(date time.Time) time.Time {
+	return time.Date(date.Year(), date.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
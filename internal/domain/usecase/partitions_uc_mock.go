@@ -0,0 +1,131 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: partitions_uc.go
+//
+// Generated by this command:
+//
+//	mockgen -source=partitions_uc.go -destination=partitions_uc_mock.go -package=usecase PartitionsRepository
+//
+
+// Package usecase is a generated GoMock package.
+package usecase
+
+import (
+	entity "b3challenge/internal/domain/entity"
+	context "context"
+	io "io"
+	reflect "reflect"
+	time "time"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockPartitionsRepository is a mock of PartitionsRepository interface.
+type MockPartitionsRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockPartitionsRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockPartitionsRepositoryMockRecorder is the mock recorder for MockPartitionsRepository.
+type MockPartitionsRepositoryMockRecorder struct {
+	mock *MockPartitionsRepository
+}
+
+// NewMockPartitionsRepository creates a new mock instance.
+func NewMockPartitionsRepository(ctrl *gomock.Controller) *MockPartitionsRepository {
+	mock := &MockPartitionsRepository{ctrl: ctrl}
+	mock.recorder = &MockPartitionsRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPartitionsRepository) EXPECT() *MockPartitionsRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AttachTradePartition mocks base method.
+func (m *MockPartitionsRepository) AttachTradePartition(ctx context.Context, partition entity.TradePartition) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AttachTradePartition", ctx, partition)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AttachTradePartition indicates an expected call of AttachTradePartition.
+func (mr *MockPartitionsRepositoryMockRecorder) AttachTradePartition(ctx, partition any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AttachTradePartition", reflect.TypeOf((*MockPartitionsRepository)(nil).AttachTradePartition), ctx, partition)
+}
+
+// DetachTradePartition mocks base method.
+func (m *MockPartitionsRepository) DetachTradePartition(ctx context.Context, name string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DetachTradePartition", ctx, name)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DetachTradePartition indicates an expected call of DetachTradePartition.
+func (mr *MockPartitionsRepositoryMockRecorder) DetachTradePartition(ctx, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DetachTradePartition", reflect.TypeOf((*MockPartitionsRepository)(nil).DetachTradePartition), ctx, name)
+}
+
+// DropTradePartition mocks base method.
+func (m *MockPartitionsRepository) DropTradePartition(ctx context.Context, name string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DropTradePartition", ctx, name)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DropTradePartition indicates an expected call of DropTradePartition.
+func (mr *MockPartitionsRepositoryMockRecorder) DropTradePartition(ctx, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DropTradePartition", reflect.TypeOf((*MockPartitionsRepository)(nil).DropTradePartition), ctx, name)
+}
+
+// EnsureTradePartition mocks base method.
+func (m *MockPartitionsRepository) EnsureTradePartition(ctx context.Context, date time.Time) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnsureTradePartition", ctx, date)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EnsureTradePartition indicates an expected call of EnsureTradePartition.
+func (mr *MockPartitionsRepositoryMockRecorder) EnsureTradePartition(ctx, date any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnsureTradePartition", reflect.TypeOf((*MockPartitionsRepository)(nil).EnsureTradePartition), ctx, date)
+}
+
+// ExportTradePartition mocks base method.
+func (m *MockPartitionsRepository) ExportTradePartition(ctx context.Context, name string, out io.Writer) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportTradePartition", ctx, name, out)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExportTradePartition indicates an expected call of ExportTradePartition.
+func (mr *MockPartitionsRepositoryMockRecorder) ExportTradePartition(ctx, name, out any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportTradePartition", reflect.TypeOf((*MockPartitionsRepository)(nil).ExportTradePartition), ctx, name, out)
+}
+
+// ListTradePartitions mocks base method.
+func (m *MockPartitionsRepository) ListTradePartitions(ctx context.Context) ([]entity.TradePartition, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTradePartitions", ctx)
+	ret0, _ := ret[0].([]entity.TradePartition)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTradePartitions indicates an expected call of ListTradePartitions.
+func (mr *MockPartitionsRepositoryMockRecorder) ListTradePartitions(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTradePartitions", reflect.TypeOf((*MockPartitionsRepository)(nil).ListTradePartitions), ctx)
+}
@@ -0,0 +1,253 @@
+package usecase
+
+import (
+	"b3challenge/internal/domain/entity"
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+type closeRecorder struct {
+	bytes.Buffer
+	closed bool
+	err    error
+}
+
+func (c *closeRecorder) Close() error {
+	c.closed = true
+
+	return c.err
+}
+
+func newTestPartition(year int, month time.Month) entity.TradePartition {
+	from := time.Date(year, month, 1, 0, 0, 0, 0, time.UTC)
+
+	return entity.TradePartition{
+		Name: "trades_p" + from.Format("200601"),
+		From: from,
+		To:   from.AddDate(0, 1, 0),
+	}
+}
+
+func TestNewPartitionsUC(t *testing.T) {
+	repoMock := NewMockPartitionsRepository(gomock.NewController(t))
+	uc := &PartitionsUC{repo: repoMock}
+	got := NewPartitionsUC(repoMock)
+	assert.Equal(t, got, uc)
+}
+
+func TestPartitionsUC_EnsureAhead(t *testing.T) {
+	now := time.Date(2025, 12, 15, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		months  int
+		repo    PartitionsRepository
+		want    []string
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name:   "current and next months across a year boundary",
+			months: 1,
+			repo: func() PartitionsRepository {
+				repo := NewMockPartitionsRepository(gomock.NewController(t))
+				gomock.InOrder(
+					repo.EXPECT().EnsureTradePartition(gomock.Any(), time.Date(2025, 12, 1, 0, 0, 0, 0, time.UTC)).
+						Return("trades_p202512", nil),
+					repo.EXPECT().EnsureTradePartition(gomock.Any(), time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)).
+						Return("trades_p202601", nil),
+				)
+				return repo
+			}(),
+			want:    []string{"trades_p202512", "trades_p202601"},
+			wantErr: assert.NoError,
+		},
+		{
+			name:   "error case",
+			months: 2,
+			repo: func() PartitionsRepository {
+				repo := NewMockPartitionsRepository(gomock.NewController(t))
+				repo.EXPECT().EnsureTradePartition(gomock.Any(), gomock.Any()).Return("", assert.AnError)
+				return repo
+			}(),
+			want:    []string{},
+			wantErr: assert.Error,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc := &PartitionsUC{repo: tt.repo}
+			got, err := uc.EnsureAhead(context.Background(), now, tt.months)
+			tt.wantErr(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestPartitionsUC_ListExpired(t *testing.T) {
+	now := time.Date(2025, 6, 20, 0, 0, 0, 0, time.UTC)
+	partitions := []entity.TradePartition{
+		newTestPartition(2025, time.March),
+		newTestPartition(2025, time.April),
+		newTestPartition(2025, time.May),
+		newTestPartition(2025, time.June),
+	}
+
+	tests := []struct {
+		name      string
+		retention int
+		repo      PartitionsRepository
+		want      []entity.TradePartition
+		wantErr   assert.ErrorAssertionFunc
+	}{
+		{
+			name:      "keeps the retention window",
+			retention: 2,
+			repo: func() PartitionsRepository {
+				repo := NewMockPartitionsRepository(gomock.NewController(t))
+				repo.EXPECT().ListTradePartitions(gomock.Any()).Return(partitions, nil)
+				return repo
+			}(),
+			want:    partitions[:1],
+			wantErr: assert.NoError,
+		},
+		{
+			name:      "zero retention keeps only the current month",
+			retention: 0,
+			repo: func() PartitionsRepository {
+				repo := NewMockPartitionsRepository(gomock.NewController(t))
+				repo.EXPECT().ListTradePartitions(gomock.Any()).Return(partitions, nil)
+				return repo
+			}(),
+			want:    partitions[:3],
+			wantErr: assert.NoError,
+		},
+		{
+			name:      "error case",
+			retention: 2,
+			repo: func() PartitionsRepository {
+				repo := NewMockPartitionsRepository(gomock.NewController(t))
+				repo.EXPECT().ListTradePartitions(gomock.Any()).Return(nil, assert.AnError)
+				return repo
+			}(),
+			want:    nil,
+			wantErr: assert.Error,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc := &PartitionsUC{repo: tt.repo}
+			got, err := uc.ListExpired(context.Background(), now, tt.retention)
+			if !tt.wantErr(t, err) {
+				return
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestPartitionsUC_Archive(t *testing.T) {
+	partition := newTestPartition(2025, time.March)
+
+	tests := []struct {
+		name     string
+		repo     PartitionsRepository
+		closeErr error
+		want     int64
+		wantErr  assert.ErrorAssertionFunc
+	}{
+		{
+			name: "detaches, exports and drops",
+			repo: func() PartitionsRepository {
+				repo := NewMockPartitionsRepository(gomock.NewController(t))
+				gomock.InOrder(
+					repo.EXPECT().DetachTradePartition(gomock.Any(), partition.Name).Return(nil),
+					repo.EXPECT().ExportTradePartition(gomock.Any(), partition.Name, gomock.Any()).Return(int64(42), nil),
+					repo.EXPECT().DropTradePartition(gomock.Any(), partition.Name).Return(nil),
+				)
+				return repo
+			}(),
+			closeErr: nil,
+			want:     42,
+			wantErr:  assert.NoError,
+		},
+		{
+			name: "export failure attaches the partition back",
+			repo: func() PartitionsRepository {
+				repo := NewMockPartitionsRepository(gomock.NewController(t))
+				gomock.InOrder(
+					repo.EXPECT().DetachTradePartition(gomock.Any(), partition.Name).Return(nil),
+					repo.EXPECT().ExportTradePartition(gomock.Any(), partition.Name, gomock.Any()).
+						Return(int64(0), assert.AnError),
+					repo.EXPECT().AttachTradePartition(gomock.Any(), partition).Return(nil),
+				)
+				return repo
+			}(),
+			closeErr: nil,
+			want:     0,
+			wantErr:  assert.Error,
+		},
+		{
+			name: "close failure attaches the partition back",
+			repo: func() PartitionsRepository {
+				repo := NewMockPartitionsRepository(gomock.NewController(t))
+				gomock.InOrder(
+					repo.EXPECT().DetachTradePartition(gomock.Any(), partition.Name).Return(nil),
+					repo.EXPECT().ExportTradePartition(gomock.Any(), partition.Name, gomock.Any()).Return(int64(42), nil),
+					repo.EXPECT().AttachTradePartition(gomock.Any(), partition).Return(nil),
+				)
+				return repo
+			}(),
+			closeErr: assert.AnError,
+			want:     0,
+			wantErr:  assert.Error,
+		},
+		{
+			name: "reattach failure",
+			repo: func() PartitionsRepository {
+				repo := NewMockPartitionsRepository(gomock.NewController(t))
+				gomock.InOrder(
+					repo.EXPECT().DetachTradePartition(gomock.Any(), partition.Name).Return(nil),
+					repo.EXPECT().ExportTradePartition(gomock.Any(), partition.Name, gomock.Any()).
+						Return(int64(0), assert.AnError),
+					repo.EXPECT().AttachTradePartition(gomock.Any(), partition).Return(errors.New("lock timeout")),
+				)
+				return repo
+			}(),
+			closeErr: nil,
+			want:     0,
+			wantErr: func(t assert.TestingT, err error, _ ...interface{}) bool {
+				return assert.ErrorIs(t, err, assert.AnError) && assert.ErrorContains(t, err, "lock timeout")
+			},
+		},
+		{
+			name: "detach failure",
+			repo: func() PartitionsRepository {
+				repo := NewMockPartitionsRepository(gomock.NewController(t))
+				repo.EXPECT().DetachTradePartition(gomock.Any(), partition.Name).Return(assert.AnError)
+				return repo
+			}(),
+			closeErr: nil,
+			want:     0,
+			wantErr:  assert.Error,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc := &PartitionsUC{repo: tt.repo}
+			out := &closeRecorder{closed: false, err: tt.closeErr}
+			got, err := uc.Archive(context.Background(), partition, out)
+			tt.wantErr(t, err)
+			assert.Equal(t, tt.want, got)
+			assert.True(t, out.closed)
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package usecase
+
+import (
+	"b3challenge/internal/domain/entity"
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+//go:generate mockgen -source=quotes_uc.go -destination=quotes_uc_mock.go -package=usecase QuotesRepository
+type QuotesRepository interface {
+	CreateDailyQuotes(ctx context.Context, quotes []entity.DailyQuote) (int64, error)
+	ListDailyQuotes(ctx context.Context, ticker string, from, to *time.Time) ([]entity.DailyQuote, error)
+}
+
+type QuotesUC struct {
+	repo   QuotesRepository
+	events CorporateEventsRepository
+}
+
+func NewQuotesUC(repo QuotesRepository, events CorporateEventsRepository) *QuotesUC {
+	return &QuotesUC{
+		repo:   repo,
+		events: events,
+	}
+}
+
+func (uc *QuotesUC) CreateDailyQuotes(ctx context.Context, quotes []entity.DailyQuote) (int, error) {
+	if len(quotes) == 0 {
+		return 0, nil
+	}
+
+	created, err := uc.repo.CreateDailyQuotes(ctx, quotes)
+	if err != nil {
+		return 0, errors.Wrap(err, "repo create daily quotes")
+	}
+
+	return int(created), nil
+}
+
+func (uc *QuotesUC) ListDailyQuotes(
+	ctx context.Context,
+	ticker string,
+	from, to *time.Time,
+	adjusted bool,
+) ([]entity.DailyQuote, error) {
+	quotes, err := uc.repo.ListDailyQuotes(ctx, ticker, from, to)
+	if err != nil {
+		return nil, errors.Wrap(err, "repo list daily quotes")
+	}
+
+	adjustments, err := listAdjustments(ctx, uc.events, ticker, from, adjusted && len(quotes) > 0)
+	if err != nil {
+		return nil, err
+	}
+	if len(adjustments) > 0 {
+		for i := range quotes {
+			quotes[i] = quotes[i].Adjusted(adjustments.At(quotes[i].Date))
+		}
+	}
+
+	return quotes, nil
+}
@@ -0,0 +1,73 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: quotes_uc.go
+//
+// Generated by this command:
+//
+//	mockgen -source=quotes_uc.go -destination=quotes_uc_mock.go -package=usecase QuotesRepository
+//
+
+// Package usecase is a generated GoMock package.
+package usecase
+
+import (
+	entity "b3challenge/internal/domain/entity"
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockQuotesRepository is a mock of QuotesRepository interface.
+type MockQuotesRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockQuotesRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockQuotesRepositoryMockRecorder is the mock recorder for MockQuotesRepository.
+type MockQuotesRepositoryMockRecorder struct {
+	mock *MockQuotesRepository
+}
+
+// NewMockQuotesRepository creates a new mock instance.
+func NewMockQuotesRepository(ctrl *gomock.Controller) *MockQuotesRepository {
+	mock := &MockQuotesRepository{ctrl: ctrl}
+	mock.recorder = &MockQuotesRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockQuotesRepository) EXPECT() *MockQuotesRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateDailyQuotes mocks base method.
+func (m *MockQuotesRepository) CreateDailyQuotes(ctx context.Context, quotes []entity.DailyQuote) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateDailyQuotes", ctx, quotes)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateDailyQuotes indicates an expected call of CreateDailyQuotes.
+func (mr *MockQuotesRepositoryMockRecorder) CreateDailyQuotes(ctx, quotes any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateDailyQuotes", reflect.TypeOf((*MockQuotesRepository)(nil).CreateDailyQuotes), ctx, quotes)
+}
+
+// ListDailyQuotes mocks base method.
+func (m *MockQuotesRepository) ListDailyQuotes(ctx context.Context, ticker string, from, to *time.Time) ([]entity.DailyQuote, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDailyQuotes", ctx, ticker, from, to)
+	ret0, _ := ret[0].([]entity.DailyQuote)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDailyQuotes indicates an expected call of ListDailyQuotes.
+func (mr *MockQuotesRepositoryMockRecorder) ListDailyQuotes(ctx, ticker, from, to any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDailyQuotes", reflect.TypeOf((*MockQuotesRepository)(nil).ListDailyQuotes), ctx, ticker, from, to)
+}
@@ -0,0 +1,115 @@
+package usecase
+
+import (
+	"b3challenge/internal/domain/entity"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestQuotesUC_CreateDailyQuotes(t *testing.T) {
+	quotes := []entity.DailyQuote{
+		{Ticker: "PETR4", ClosePrice: decimal.New(3190, -2)},
+		{Ticker: "VALE3", ClosePrice: decimal.New(5520, -2)},
+	}
+
+	tests := []struct {
+		name    string
+		quotes  []entity.DailyQuote
+		repo    func(repo *MockQuotesRepository)
+		want    int
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name:   "created",
+			quotes: quotes,
+			repo: func(repo *MockQuotesRepository) {
+				repo.EXPECT().CreateDailyQuotes(gomock.Any(), quotes).Return(int64(2), nil)
+			},
+			want:    2,
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "empty batch",
+			quotes:  nil,
+			repo:    func(_ *MockQuotesRepository) {},
+			want:    0,
+			wantErr: assert.NoError,
+		},
+		{
+			name:   "repository error",
+			quotes: quotes,
+			repo: func(repo *MockQuotesRepository) {
+				repo.EXPECT().CreateDailyQuotes(gomock.Any(), quotes).Return(int64(0), assert.AnError)
+			},
+			want:    0,
+			wantErr: assert.Error,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := NewMockQuotesRepository(gomock.NewController(t))
+			tt.repo(repo)
+
+			got, err := NewQuotesUC(repo, nil).CreateDailyQuotes(context.Background(), tt.quotes)
+			tt.wantErr(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestQuotesUC_ListDailyQuotes(t *testing.T) {
+	from := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	quotes := []entity.DailyQuote{{Ticker: "PETR4", Date: from}}
+
+	repo := NewMockQuotesRepository(gomock.NewController(t))
+	repo.EXPECT().ListDailyQuotes(gomock.Any(), "PETR4", &from, nil).Return(quotes, nil)
+	repo.EXPECT().ListDailyQuotes(gomock.Any(), "VALE3", nil, nil).Return(nil, assert.AnError)
+
+	uc := NewQuotesUC(repo, nil)
+	got, err := uc.ListDailyQuotes(context.Background(), "PETR4", &from, nil, false)
+	assert.NoError(t, err)
+	assert.Equal(t, quotes, got)
+
+	_, err = uc.ListDailyQuotes(context.Background(), "VALE3", nil, nil, false)
+	assert.ErrorIs(t, err, assert.AnError)
+}
+
+func TestQuotesUC_ListDailyQuotes_Adjusted(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	before := time.Date(2025, 6, 2, 0, 0, 0, 0, time.UTC)
+	after := time.Date(2025, 6, 4, 0, 0, 0, 0, time.UTC)
+	quotes := []entity.DailyQuote{
+		{Ticker: "PETR4", Date: before, ClosePrice: decimal.New(4000, -2), Quantity: 1000},
+		{Ticker: "PETR4", Date: after, ClosePrice: decimal.New(2010, -2), Quantity: 2500},
+	}
+	split := entity.CorporateEvent{
+		Ticker: "PETR4",
+		Type:   entity.CorporateEventSplit,
+		ExDate: time.Date(2025, 6, 3, 0, 0, 0, 0, time.UTC),
+		Factor: decimal.NewFromInt(2),
+		Amount: decimal.Zero,
+	}
+
+	repo := NewMockQuotesRepository(ctrl)
+	repo.EXPECT().ListDailyQuotes(gomock.Any(), "PETR4", &before, nil).Return(quotes, nil).Times(2)
+	events := NewMockCorporateEventsRepository(ctrl)
+	events.EXPECT().ListCorporateEvents(gomock.Any(), "PETR4", &before).Return([]entity.CorporateEvent{split}, nil)
+	events.EXPECT().ListCorporateEvents(gomock.Any(), "PETR4", &before).Return(nil, assert.AnError)
+
+	uc := NewQuotesUC(repo, events)
+	got, err := uc.ListDailyQuotes(context.Background(), "PETR4", &before, nil, true)
+	assert.NoError(t, err)
+	assert.True(t, decimal.NewFromInt(20).Equal(got[0].ClosePrice))
+	assert.Equal(t, int64(2000), got[0].Quantity)
+	assert.True(t, decimal.New(2010, -2).Equal(got[1].ClosePrice))
+	assert.Equal(t, int64(2500), got[1].Quantity)
+
+	_, err = uc.ListDailyQuotes(context.Background(), "PETR4", &before, nil, true)
+	assert.ErrorIs(t, err, assert.AnError)
+}
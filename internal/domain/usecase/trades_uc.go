@@ -12,64 +12,196 @@ import (
 //go:generate mockgen -source=trades_uc.go -destination=trades_uc_mock.go -package=usecase TradesRepository
 type TradesRepository interface {
 	CreateTrades(ctx context.Context, trades []entity.Trade) (int64, error)
+	CreateTradeCancellations(ctx context.Context, trades []entity.Trade) (int64, error)
+	StageTrades(ctx context.Context, ingestionID int32, trades []entity.Trade, lines []int64) (int64, error)
+	ResetStagedTrades(ctx context.Context, ingestion *entity.Ingestion) (int64, error)
+	PromoteStagedTrades(ctx context.Context, ingestionID int32) (int64, int64, error)
+	DiscardStagedTrades(ctx context.Context, ingestionID int32) (int64, error)
+	ApplyTradeCancellations(ctx context.Context) (int64, error)
+	DeleteTradesByDate(ctx context.Context, date time.Time) (int64, error)
 	ListTradeInfoByTickerAndDate(ctx context.Context, ticker string, date *time.Time) ([]entity.TradeInfo, error)
 }
 
 type TradesUC struct {
-	repo TradesRepository
+	repo   TradesRepository
+	events CorporateEventsRepository
 }
 
-func NewTradesUC(repo TradesRepository) *TradesUC {
+func NewTradesUC(repo TradesRepository, events CorporateEventsRepository) *TradesUC {
 	return &TradesUC{
-		repo: repo,
+		repo:   repo,
+		events: events,
 	}
 }
 
-func (tr *TradesUC) CreateTrades(ctx context.Context, trades []entity.Trade) (int, error) {
-	affected, err := tr.repo.CreateTrades(ctx, trades)
+func (tr *TradesUC) CreateTrades(ctx context.Context, trades []entity.Trade) (int, int, error) {
+	effective, cancellations := splitCancellations(trades)
+
+	var created, cancelled int64
+	if len(effective) > 0 {
+		affected, err := tr.repo.CreateTrades(ctx, effective)
+		if err != nil {
+			return 0, 0, errors.Wrap(err, "repo create")
+		}
+		created = affected
+	}
+
+	if len(cancellations) > 0 {
+		affected, err := tr.repo.CreateTradeCancellations(ctx, cancellations)
+		if err != nil {
+			return int(created), 0, errors.Wrap(err, "repo create cancellations")
+		}
+		cancelled = affected
+	}
+
+	return int(created), int(cancelled), nil
+}
+
+// StageTrades holds trades of the file tracked by ingestionID until PromoteStagedTrades. It returns
+// how many trades and cancellations were staged.
+func (tr *TradesUC) StageTrades(
+	ctx context.Context,
+	ingestionID int32,
+	trades []entity.Trade,
+	lines []int64,
+) (int, int, error) {
+	staged, err := tr.repo.StageTrades(ctx, ingestionID, trades, lines)
+	if err != nil {
+		return 0, 0, errors.Wrap(err, "repo stage")
+	}
+
+	_, cancellations := splitCancellations(trades)
+
+	return int(staged) - len(cancellations), len(cancellations), nil
+}
+
+// ResumeStagedTrades prepares the staging area for ingestion, keeping the rows an earlier run of the
+// same file staged up to the ingestion checkpoint. It returns how many staged rows were kept.
+func (tr *TradesUC) ResumeStagedTrades(ctx context.Context, ingestion *entity.Ingestion) (int, error) {
+	kept, err := tr.repo.ResetStagedTrades(ctx, ingestion)
+	if err != nil {
+		return 0, errors.Wrap(err, "repo reset staged")
+	}
+
+	return int(kept), nil
+}
+
+func (tr *TradesUC) PromoteStagedTrades(ctx context.Context, ingestionID int32) (int, int, error) {
+	created, cancelled, err := tr.repo.PromoteStagedTrades(ctx, ingestionID)
 	if err != nil {
-		return 0, errors.Wrap(err, "repo create")
+		return 0, 0, errors.Wrap(err, "repo promote staged")
 	}
 
-	return int(affected), nil
+	return int(created), int(cancelled), nil
+}
+
+func (tr *TradesUC) DiscardStagedTrades(ctx context.Context, ingestionID int32) (int, error) {
+	removed, err := tr.repo.DiscardStagedTrades(ctx, ingestionID)
+	if err != nil {
+		return 0, errors.Wrap(err, "repo discard staged")
+	}
+
+	return int(removed), nil
+}
+
+func (tr *TradesUC) ApplyTradeCancellations(ctx context.Context) (int, error) {
+	removed, err := tr.repo.ApplyTradeCancellations(ctx)
+	if err != nil {
+		return 0, errors.Wrap(err, "repo apply cancellations")
+	}
+
+	return int(removed), nil
+}
+
+func (tr *TradesUC) TruncateDate(ctx context.Context, date time.Time) (int, error) {
+	removed, err := tr.repo.DeleteTradesByDate(ctx, date)
+	if err != nil {
+		return 0, errors.Wrap(err, "repo delete by date")
+	}
+
+	return int(removed), nil
 }
 
 func (tr *TradesUC) ComputeTickerMetrics(
 	ctx context.Context,
 	ticker string,
 	date *time.Time,
+	adjusted bool,
 ) (decimal.Decimal, int, error) {
 	trades, err := tr.repo.ListTradeInfoByTickerAndDate(ctx, ticker, date)
 	if err != nil {
 		return decimal.Decimal{}, 0, errors.Wrap(err, "repo list")
 	}
 
-	maxRangeValue := calcMaxRangeValue(trades)
-	maxDailyValue := calcMaxDailyValue(trades)
+	adjustments, err := listAdjustments(ctx, tr.events, ticker, date, adjusted)
+	if err != nil {
+		return decimal.Decimal{}, 0, err
+	}
+
+	maxRangeValue := calcMaxRangeValue(trades, adjustments)
+	maxDailyValue := calcMaxDailyValue(trades, adjustments)
 
 	return maxRangeValue, maxDailyValue, nil
 }
 
-func calcMaxRangeValue(trades []entity.TradeInfo) decimal.Decimal {
+func splitCancellations(trades []entity.Trade) ([]entity.Trade, []entity.Trade) {
+	if !hasCancellations(trades) {
+		return trades, nil
+	}
+
+	var effective, cancellations []entity.Trade
+	for _, trade := range trades {
+		if trade.IsCancellation() {
+			cancellations = append(cancellations, trade)
+
+			continue
+		}
+		effective = append(effective, trade)
+	}
+
+	return effective, cancellations
+}
+
+func hasCancellations(trades []entity.Trade) bool {
+	for i := range trades {
+		if trades[i].IsCancellation() {
+			return true
+		}
+	}
+
+	return false
+}
+
+func calcMaxRangeValue(trades []entity.TradeInfo, adjustments entity.PriceAdjustments) decimal.Decimal {
 	var maxRangeVal decimal.Decimal
 	for _, trade := range trades {
-		if trade.Price.GreaterThan(maxRangeVal) {
-			maxRangeVal = trade.Price
+		price := trade.Price
+		if len(adjustments) > 0 {
+			price = price.Mul(adjustments.At(trade.Date).Price).Round(entity.AdjustedPriceDecimals)
+		}
+		if price.GreaterThan(maxRangeVal) {
+			maxRangeVal = price
 		}
 	}
 
 	return maxRangeVal
 }
 
-func calcMaxDailyValue(trades []entity.TradeInfo) int {
+func calcMaxDailyValue(trades []entity.TradeInfo, adjustments entity.PriceAdjustments) int {
 	dailyTotal := make(map[string]int)
+	dailyDate := make(map[string]time.Time)
 	for _, trade := range trades {
 		date := trade.Date.Format("2006-01-02")
 		dailyTotal[date] += trade.Quantity
+		dailyDate[date] = trade.Date
 	}
 
 	var maxDailyVal int
-	for _, total := range dailyTotal {
+	for date, total := range dailyTotal {
+		if len(adjustments) > 0 {
+			adjustment := adjustments.At(dailyDate[date])
+			total = int(decimal.NewFromInt(int64(total)).Mul(adjustment.Quantity).Round(0).IntPart())
+		}
 		if total > maxDailyVal {
 			maxDailyVal = total
 		}
@@ -42,6 +42,36 @@ func (m *MockTradesRepository) EXPECT() *MockTradesRepositoryMockRecorder {
 	return m.recorder
 }
 
+// ApplyTradeCancellations mocks base method.
+func (m *MockTradesRepository) ApplyTradeCancellations(ctx context.Context) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApplyTradeCancellations", ctx)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ApplyTradeCancellations indicates an expected call of ApplyTradeCancellations.
+func (mr *MockTradesRepositoryMockRecorder) ApplyTradeCancellations(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplyTradeCancellations", reflect.TypeOf((*MockTradesRepository)(nil).ApplyTradeCancellations), ctx)
+}
+
+// CreateTradeCancellations mocks base method.
+func (m *MockTradesRepository) CreateTradeCancellations(ctx context.Context, trades []entity.Trade) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTradeCancellations", ctx, trades)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateTradeCancellations indicates an expected call of CreateTradeCancellations.
+func (mr *MockTradesRepositoryMockRecorder) CreateTradeCancellations(ctx, trades any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTradeCancellations", reflect.TypeOf((*MockTradesRepository)(nil).CreateTradeCancellations), ctx, trades)
+}
+
 // CreateTrades mocks base method.
 func (m *MockTradesRepository) CreateTrades(ctx context.Context, trades []entity.Trade) (int64, error) {
 	m.ctrl.T.Helper()
@@ -57,6 +87,36 @@ func (mr *MockTradesRepositoryMockRecorder) CreateTrades(ctx, trades any) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTrades", reflect.TypeOf((*MockTradesRepository)(nil).CreateTrades), ctx, trades)
 }
 
+// DeleteTradesByDate mocks base method.
+func (m *MockTradesRepository) DeleteTradesByDate(ctx context.Context, date time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTradesByDate", ctx, date)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteTradesByDate indicates an expected call of DeleteTradesByDate.
+func (mr *MockTradesRepositoryMockRecorder) DeleteTradesByDate(ctx, date any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTradesByDate", reflect.TypeOf((*MockTradesRepository)(nil).DeleteTradesByDate), ctx, date)
+}
+
+// DiscardStagedTrades mocks base method.
+func (m *MockTradesRepository) DiscardStagedTrades(ctx context.Context, ingestionID int32) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DiscardStagedTrades", ctx, ingestionID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DiscardStagedTrades indicates an expected call of DiscardStagedTrades.
+func (mr *MockTradesRepositoryMockRecorder) DiscardStagedTrades(ctx, ingestionID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DiscardStagedTrades", reflect.TypeOf((*MockTradesRepository)(nil).DiscardStagedTrades), ctx, ingestionID)
+}
+
 // ListTradeInfoByTickerAndDate mocks base method.
 func (m *MockTradesRepository) ListTradeInfoByTickerAndDate(ctx context.Context, ticker string, date *time.Time) ([]entity.TradeInfo, error) {
 	m.ctrl.T.Helper()
@@ -71,3 +131,49 @@ func (mr *MockTradesRepositoryMockRecorder) ListTradeInfoByTickerAndDate(ctx, ti
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTradeInfoByTickerAndDate", reflect.TypeOf((*MockTradesRepository)(nil).ListTradeInfoByTickerAndDate), ctx, ticker, date)
 }
+
+// PromoteStagedTrades mocks base method.
+func (m *MockTradesRepository) PromoteStagedTrades(ctx context.Context, ingestionID int32) (int64, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PromoteStagedTrades", ctx, ingestionID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// PromoteStagedTrades indicates an expected call of PromoteStagedTrades.
+func (mr *MockTradesRepositoryMockRecorder) PromoteStagedTrades(ctx, ingestionID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PromoteStagedTrades", reflect.TypeOf((*MockTradesRepository)(nil).PromoteStagedTrades), ctx, ingestionID)
+}
+
+// ResetStagedTrades mocks base method.
+func (m *MockTradesRepository) ResetStagedTrades(ctx context.Context, ingestion *entity.Ingestion) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetStagedTrades", ctx, ingestion)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResetStagedTrades indicates an expected call of ResetStagedTrades.
+func (mr *MockTradesRepositoryMockRecorder) ResetStagedTrades(ctx, ingestion any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetStagedTrades", reflect.TypeOf((*MockTradesRepository)(nil).ResetStagedTrades), ctx, ingestion)
+}
+
+// StageTrades mocks base method.
+func (m *MockTradesRepository) StageTrades(ctx context.Context, ingestionID int32, trades []entity.Trade, lines []int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StageTrades", ctx, ingestionID, trades, lines)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StageTrades indicates an expected call of StageTrades.
+func (mr *MockTradesRepositoryMockRecorder) StageTrades(ctx, ingestionID, trades, lines any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StageTrades", reflect.TypeOf((*MockTradesRepository)(nil).StageTrades), ctx, ingestionID, trades, lines)
+}
@@ -13,8 +13,9 @@ import (
 
 func TestNewTradeUC(t *testing.T) {
 	repoMock := NewMockTradesRepository(gomock.NewController(t))
-	uc := &TradesUC{repo: repoMock}
-	got := NewTradesUC(repoMock)
+	eventsMock := NewMockCorporateEventsRepository(gomock.NewController(t))
+	uc := &TradesUC{repo: repoMock, events: eventsMock}
+	got := NewTradesUC(repoMock, eventsMock)
 	assert.Equal(t, got, uc)
 }
 
@@ -23,7 +24,189 @@ func TestTradeUC_CreateTrades(t *testing.T) {
 		{Ticker: "AAPL", Price: decimal.NewFromFloat(200.00), Quantity: 100},
 		{Ticker: "GOOGL", Price: decimal.NewFromFloat(2800.00), Quantity: 50},
 	}
+	cancellation := entity.Trade{Ticker: "AAPL", TradeID: 10, UpdateAction: entity.UpdateActionCancel}
 
+	tests := []struct {
+		name          string
+		trades        []entity.Trade
+		repo          TradesRepository
+		wantCode      int
+		wantCancelled int
+		wantErr       assert.ErrorAssertionFunc
+	}{
+		{
+			name:   "successful creation",
+			trades: expectedTrades,
+			repo: func() TradesRepository {
+				ctrl := gomock.NewController(t)
+				repo := NewMockTradesRepository(ctrl)
+				repo.EXPECT().CreateTrades(gomock.Any(), expectedTrades).Return(int64(2), nil)
+				return repo
+			}(),
+			wantCode:      2,
+			wantCancelled: 0,
+			wantErr:       assert.NoError,
+		},
+		{
+			name:   "cancellations are recorded apart from trades",
+			trades: append([]entity.Trade{cancellation}, expectedTrades...),
+			repo: func() TradesRepository {
+				ctrl := gomock.NewController(t)
+				repo := NewMockTradesRepository(ctrl)
+				repo.EXPECT().CreateTrades(gomock.Any(), expectedTrades).Return(int64(2), nil)
+				repo.EXPECT().CreateTradeCancellations(gomock.Any(), []entity.Trade{cancellation}).Return(int64(1), nil)
+				return repo
+			}(),
+			wantCode:      2,
+			wantCancelled: 1,
+			wantErr:       assert.NoError,
+		},
+		{
+			name:   "error case",
+			trades: expectedTrades,
+			repo: func() TradesRepository {
+				ctrl := gomock.NewController(t)
+				repo := NewMockTradesRepository(ctrl)
+				repo.EXPECT().CreateTrades(gomock.Any(), expectedTrades).Return(int64(0), assert.AnError)
+				return repo
+			}(),
+			wantCode:      0,
+			wantCancelled: 0,
+			wantErr:       assert.Error,
+		},
+		{
+			name:   "cancellations error case",
+			trades: []entity.Trade{cancellation},
+			repo: func() TradesRepository {
+				ctrl := gomock.NewController(t)
+				repo := NewMockTradesRepository(ctrl)
+				repo.EXPECT().CreateTradeCancellations(gomock.Any(), gomock.Any()).Return(int64(0), assert.AnError)
+				return repo
+			}(),
+			wantCode:      0,
+			wantCancelled: 0,
+			wantErr:       assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc := &TradesUC{
+				repo: tt.repo,
+			}
+			got, gotCancelled, err := uc.CreateTrades(context.Background(), tt.trades)
+			if !tt.wantErr(t, err) {
+				return
+			}
+			assert.Equal(t, tt.wantCode, got)
+			assert.Equal(t, tt.wantCancelled, gotCancelled)
+		})
+	}
+}
+
+func TestTradeUC_StageTrades(t *testing.T) {
+	trades := []entity.Trade{
+		{Ticker: "PETR4", TradeID: 10, UpdateAction: entity.UpdateActionNew},
+		{Ticker: "PETR4", TradeID: 10, UpdateAction: entity.UpdateActionCancel},
+		{Ticker: "VALE3", TradeID: 11, UpdateAction: entity.UpdateActionNew},
+	}
+	lines := []int64{2, 3, 4}
+
+	tests := []struct {
+		name          string
+		repo          TradesRepository
+		wantCode      int
+		wantCancelled int
+		wantErr       assert.ErrorAssertionFunc
+	}{
+		{
+			name: "successful staging",
+			repo: func() TradesRepository {
+				ctrl := gomock.NewController(t)
+				repo := NewMockTradesRepository(ctrl)
+				repo.EXPECT().StageTrades(gomock.Any(), int32(7), trades, lines).Return(int64(3), nil)
+				return repo
+			}(),
+			wantCode:      2,
+			wantCancelled: 1,
+			wantErr:       assert.NoError,
+		},
+		{
+			name: "error case",
+			repo: func() TradesRepository {
+				ctrl := gomock.NewController(t)
+				repo := NewMockTradesRepository(ctrl)
+				repo.EXPECT().StageTrades(gomock.Any(), int32(7), trades, lines).Return(int64(0), assert.AnError)
+				return repo
+			}(),
+			wantCode:      0,
+			wantCancelled: 0,
+			wantErr:       assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc := &TradesUC{
+				repo: tt.repo,
+			}
+			got, gotCancelled, err := uc.StageTrades(context.Background(), 7, trades, lines)
+			if !tt.wantErr(t, err) {
+				return
+			}
+			assert.Equal(t, tt.wantCode, got)
+			assert.Equal(t, tt.wantCancelled, gotCancelled)
+		})
+	}
+}
+
+func TestTradeUC_PromoteStagedTrades(t *testing.T) {
+	tests := []struct {
+		name          string
+		repo          TradesRepository
+		wantCode      int
+		wantCancelled int
+		wantErr       assert.ErrorAssertionFunc
+	}{
+		{
+			name: "successful promotion",
+			repo: func() TradesRepository {
+				ctrl := gomock.NewController(t)
+				repo := NewMockTradesRepository(ctrl)
+				repo.EXPECT().PromoteStagedTrades(gomock.Any(), int32(7)).Return(int64(5), int64(1), nil)
+				return repo
+			}(),
+			wantCode:      5,
+			wantCancelled: 1,
+			wantErr:       assert.NoError,
+		},
+		{
+			name: "error case",
+			repo: func() TradesRepository {
+				ctrl := gomock.NewController(t)
+				repo := NewMockTradesRepository(ctrl)
+				repo.EXPECT().PromoteStagedTrades(gomock.Any(), int32(7)).Return(int64(0), int64(0), assert.AnError)
+				return repo
+			}(),
+			wantCode:      0,
+			wantCancelled: 0,
+			wantErr:       assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc := &TradesUC{
+				repo: tt.repo,
+			}
+			got, gotCancelled, err := uc.PromoteStagedTrades(context.Background(), 7)
+			if !tt.wantErr(t, err) {
+				return
+			}
+			assert.Equal(t, tt.wantCode, got)
+			assert.Equal(t, tt.wantCancelled, gotCancelled)
+		})
+	}
+}
+
+func TestTradeUC_ApplyTradeCancellations(t *testing.T) {
 	tests := []struct {
 		name     string
 		repo     TradesRepository
@@ -31,15 +214,60 @@ func TestTradeUC_CreateTrades(t *testing.T) {
 		wantErr  assert.ErrorAssertionFunc
 	}{
 		{
-			name: "successful creation",
+			name: "successful apply",
+			repo: func() TradesRepository {
+				ctrl := gomock.NewController(t)
+				repo := NewMockTradesRepository(ctrl)
+				repo.EXPECT().ApplyTradeCancellations(gomock.Any()).Return(int64(3), nil)
+				return repo
+			}(),
+			wantCode: 3,
+			wantErr:  assert.NoError,
+		},
+		{
+			name: "error case",
+			repo: func() TradesRepository {
+				ctrl := gomock.NewController(t)
+				repo := NewMockTradesRepository(ctrl)
+				repo.EXPECT().ApplyTradeCancellations(gomock.Any()).Return(int64(0), assert.AnError)
+				return repo
+			}(),
+			wantCode: 0,
+			wantErr:  assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc := &TradesUC{
+				repo: tt.repo,
+			}
+			got, err := uc.ApplyTradeCancellations(context.Background())
+			if !tt.wantErr(t, err) {
+				return
+			}
+			assert.Equal(t, tt.wantCode, got)
+		})
+	}
+}
+
+func TestTradeUC_TruncateDate(t *testing.T) {
+	date := time.Date(2025, 6, 2, 0, 0, 0, 0, time.UTC)
 
+	tests := []struct {
+		name     string
+		repo     TradesRepository
+		wantCode int
+		wantErr  assert.ErrorAssertionFunc
+	}{
+		{
+			name: "successful truncate",
 			repo: func() TradesRepository {
 				ctrl := gomock.NewController(t)
 				repo := NewMockTradesRepository(ctrl)
-				repo.EXPECT().CreateTrades(gomock.Any(), expectedTrades).Return(int64(2), nil)
+				repo.EXPECT().DeleteTradesByDate(gomock.Any(), date).Return(int64(42), nil)
 				return repo
 			}(),
-			wantCode: 2,
+			wantCode: 42,
 			wantErr:  assert.NoError,
 		},
 		{
@@ -47,7 +275,7 @@ func TestTradeUC_CreateTrades(t *testing.T) {
 			repo: func() TradesRepository {
 				ctrl := gomock.NewController(t)
 				repo := NewMockTradesRepository(ctrl)
-				repo.EXPECT().CreateTrades(gomock.Any(), expectedTrades).Return(int64(0), assert.AnError)
+				repo.EXPECT().DeleteTradesByDate(gomock.Any(), date).Return(int64(0), assert.AnError)
 				return repo
 			}(),
 			wantCode: 0,
@@ -59,7 +287,7 @@ func TestTradeUC_CreateTrades(t *testing.T) {
 			uc := &TradesUC{
 				repo: tt.repo,
 			}
-			got, err := uc.CreateTrades(context.Background(), expectedTrades)
+			got, err := uc.TruncateDate(context.Background(), date)
 			if !tt.wantErr(t, err) {
 				return
 			}
@@ -117,7 +345,7 @@ func TestTradeUC_ComputeTickerMetrics(t *testing.T) {
 			uc := &TradesUC{
 				repo: tt.repo,
 			}
-			gotMax, gotCode, err := uc.ComputeTickerMetrics(context.Background(), expectedTicker, &today)
+			gotMax, gotCode, err := uc.ComputeTickerMetrics(context.Background(), expectedTicker, &today, false)
 			if !tt.wantErr(t, err) {
 				return
 			}
@@ -126,3 +354,87 @@ func TestTradeUC_ComputeTickerMetrics(t *testing.T) {
 		})
 	}
 }
+
+func TestTradeUC_ComputeTickerMetrics_Adjusted(t *testing.T) {
+	splitDate := time.Date(2025, 6, 3, 0, 0, 0, 0, time.UTC)
+	before := splitDate.AddDate(0, 0, -1)
+	trades := []entity.TradeInfo{
+		{Ticker: "PETR4", Price: decimal.New(4000, -2), Quantity: 300, Date: before},
+		{Ticker: "PETR4", Price: decimal.New(2150, -2), Quantity: 500, Date: splitDate},
+	}
+	split := entity.CorporateEvent{
+		Ticker: "PETR4",
+		Type:   entity.CorporateEventSplit,
+		ExDate: splitDate,
+		Factor: decimal.NewFromInt(2),
+		Amount: decimal.Zero,
+	}
+	dividend := entity.CorporateEvent{
+		Ticker:         "PETR4",
+		Type:           entity.CorporateEventCashDividend,
+		ExDate:         splitDate,
+		Factor:         decimal.Zero,
+		Amount:         decimal.NewFromInt(4),
+		ReferencePrice: decimal.NewFromInt(40),
+	}
+
+	tests := []struct {
+		name      string
+		events    []entity.CorporateEvent
+		eventsErr error
+		wantMax   decimal.Decimal
+		wantDaily int
+		wantErr   assert.ErrorAssertionFunc
+	}{
+		{
+			name:      "no events",
+			events:    nil,
+			wantMax:   decimal.New(4000, -2),
+			wantDaily: 500,
+			wantErr:   assert.NoError,
+		},
+		{
+			name:      "split",
+			events:    []entity.CorporateEvent{split},
+			wantMax:   decimal.New(2150, -2),
+			wantDaily: 600,
+			wantErr:   assert.NoError,
+		},
+		{
+			name:      "split and dividend",
+			events:    []entity.CorporateEvent{split, dividend},
+			wantMax:   decimal.New(2150, -2),
+			wantDaily: 600,
+			wantErr:   assert.NoError,
+		},
+		{
+			name:      "dividend",
+			events:    []entity.CorporateEvent{dividend},
+			wantMax:   decimal.New(3600, -2),
+			wantDaily: 500,
+			wantErr:   assert.NoError,
+		},
+		{
+			name:      "events error",
+			eventsErr: assert.AnError,
+			wantErr:   assert.Error,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repo := NewMockTradesRepository(ctrl)
+			repo.EXPECT().ListTradeInfoByTickerAndDate(gomock.Any(), "PETR4", nil).Return(trades, nil)
+			events := NewMockCorporateEventsRepository(ctrl)
+			events.EXPECT().ListCorporateEvents(gomock.Any(), "PETR4", nil).Return(tt.events, tt.eventsErr)
+
+			gotMax, gotDaily, err := NewTradesUC(repo, events).ComputeTickerMetrics(context.Background(), "PETR4", nil, true)
+			if !tt.wantErr(t, err) || err != nil {
+				return
+			}
+			assert.True(t, tt.wantMax.Equal(gotMax), "max %s", gotMax)
+			assert.Equal(t, tt.wantDaily, gotDaily)
+		})
+	}
+}
@@ -0,0 +1,94 @@
+package filehandler
+
+import (
+	"archive/zip"
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	txtExtension = ".txt"
+	csvExtension = ".csv"
+	zipExtension = ".zip"
+	gzExtension  = ".gz"
+)
+
+func IsSupportedFile(name string) bool {
+	switch strings.ToLower(filepath.Ext(name)) {
+	case txtExtension, zipExtension, gzExtension, jsonlExtension, ndjsonExtension, parquetExtension:
+		return true
+	case csvExtension:
+		return IsInstrumentsFile(name) || IsCorporateEventsFile(name)
+	default:
+		return false
+	}
+}
+
+func forEachTradeStream(filePath string, fn func(stream io.Reader) error) error {
+	switch strings.ToLower(filepath.Ext(filePath)) {
+	case zipExtension:
+		return forEachZipEntry(filePath, fn)
+	case gzExtension:
+		return withGzipStream(filePath, fn)
+	default:
+		file, err := os.Open(filePath)
+		if err != nil {
+			return errors.Wrap(err, "cannot open file")
+		}
+		defer file.Close()
+
+		return fn(file)
+	}
+}
+
+func forEachZipEntry(filePath string, fn func(stream io.Reader) error) error {
+	archive, err := zip.OpenReader(filePath)
+	if err != nil {
+		return errors.Wrap(err, "cannot open zip archive")
+	}
+	defer archive.Close()
+
+	for _, entry := range archive.File {
+		ext := filepath.Ext(entry.Name)
+		if entry.FileInfo().IsDir() || !strings.EqualFold(ext, txtExtension) && !strings.EqualFold(ext, csvExtension) {
+			continue
+		}
+
+		if err := withZipEntry(entry, fn); err != nil {
+			return errors.Wrapf(err, "zip entry %s", entry.Name)
+		}
+	}
+
+	return nil
+}
+
+func withZipEntry(entry *zip.File, fn func(stream io.Reader) error) error {
+	stream, err := entry.Open()
+	if err != nil {
+		return errors.Wrap(err, "cannot open zip entry")
+	}
+	defer stream.Close()
+
+	return fn(stream)
+}
+
+func withGzipStream(filePath string, fn func(stream io.Reader) error) error {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return errors.Wrap(err, "cannot open file")
+	}
+	defer file.Close()
+
+	stream, err := gzip.NewReader(file)
+	if err != nil {
+		return errors.Wrap(err, "cannot open gzip stream")
+	}
+	defer stream.Close()
+
+	return fn(stream)
+}
@@ -0,0 +1,302 @@
+package filehandler
+
+import (
+	"b3challenge/internal/domain/entity"
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/AlekSi/pointer"
+	"github.com/pkg/errors"
+)
+
+const (
+	participantCodesPerTrade = 2
+	maxInternedStrings       = 1 << 16
+)
+
+type Batch struct {
+	Source      string
+	Segment     int
+	Seq         int
+	LastLine    int64
+	Trades      []entity.Trade
+	Quotes      []entity.DailyQuote
+	Instruments []entity.Instrument
+	Events      []entity.CorporateEvent
+	Lines       []int64
+	codes       []int32
+	reserved    int64
+}
+
+func (b *Batch) Len() int {
+	return len(b.Trades) + len(b.Quotes) + len(b.Instruments) + len(b.Events)
+}
+
+func (b *Batch) next(line int64) *entity.Trade {
+	var trade entity.Trade
+	b.Trades = append(b.Trades, trade)
+	b.Lines = append(b.Lines, line)
+	b.LastLine = line
+
+	return &b.Trades[len(b.Trades)-1]
+}
+
+func (b *Batch) nextQuote(line int64) *entity.DailyQuote {
+	var quote entity.DailyQuote
+	b.Quotes = append(b.Quotes, quote)
+	b.Lines = append(b.Lines, line)
+	b.LastLine = line
+
+	return &b.Quotes[len(b.Quotes)-1]
+}
+
+func (b *Batch) nextInstrument(line int64) *entity.Instrument {
+	var instrument entity.Instrument
+	b.Instruments = append(b.Instruments, instrument)
+	b.Lines = append(b.Lines, line)
+	b.LastLine = line
+
+	return &b.Instruments[len(b.Instruments)-1]
+}
+
+func (b *Batch) nextEvent(line int64) *entity.CorporateEvent {
+	var event entity.CorporateEvent
+	b.Events = append(b.Events, event)
+	b.Lines = append(b.Lines, line)
+	b.LastLine = line
+
+	return &b.Events[len(b.Events)-1]
+}
+
+func (b *Batch) discardLast() {
+	switch {
+	case len(b.Quotes) > 0:
+		b.Quotes = b.Quotes[:len(b.Quotes)-1]
+	case len(b.Instruments) > 0:
+		b.Instruments = b.Instruments[:len(b.Instruments)-1]
+	case len(b.Events) > 0:
+		b.Events = b.Events[:len(b.Events)-1]
+	default:
+		b.Trades = b.Trades[:len(b.Trades)-1]
+	}
+	b.Lines = b.Lines[:len(b.Lines)-1]
+}
+
+func (b *Batch) slice(from, to int) *Batch {
+	part := &Batch{
+		Source:      b.Source,
+		Segment:     b.Segment,
+		Seq:         b.Seq,
+		LastLine:    b.Lines[to-1],
+		Trades:      nil,
+		Quotes:      nil,
+		Instruments: nil,
+		Events:      nil,
+		Lines:       b.Lines[from:to],
+		codes:       nil,
+		reserved:    0,
+	}
+	switch {
+	case len(b.Quotes) > 0:
+		part.Quotes = b.Quotes[from:to]
+	case len(b.Instruments) > 0:
+		part.Instruments = b.Instruments[from:to]
+	case len(b.Events) > 0:
+		part.Events = b.Events[from:to]
+	default:
+		part.Trades = b.Trades[from:to]
+	}
+
+	return part
+}
+
+func (b *Batch) record(i int) any {
+	switch {
+	case len(b.Quotes) > 0:
+		return b.Quotes[i]
+	case len(b.Instruments) > 0:
+		return b.Instruments[i]
+	case len(b.Events) > 0:
+		return b.Events[i]
+	default:
+		return b.Trades[i]
+	}
+}
+
+func (b *Batch) code(value int32) *int32 {
+	if len(b.codes) == cap(b.codes) {
+		return pointer.ToInt32(value)
+	}
+	b.codes = append(b.codes, value)
+
+	return &b.codes[len(b.codes)-1]
+}
+
+func (b *Batch) reset() {
+	clear(b.Trades)
+	clear(b.Quotes)
+	clear(b.Instruments)
+	clear(b.Events)
+	b.Source = ""
+	b.Segment = 0
+	b.Seq = 0
+	b.LastLine = 0
+	b.Trades = b.Trades[:0]
+	b.Quotes = b.Quotes[:0]
+	b.Instruments = b.Instruments[:0]
+	b.Events = b.Events[:0]
+	b.Lines = b.Lines[:0]
+	b.codes = b.codes[:0]
+	b.reserved = 0
+}
+
+type TradeWriter func(ctx context.Context, trades []entity.Trade) (int, int, error)
+
+// StagedTradeWriter holds the trades of a batch in staging, keyed by their source file and lines,
+// instead of writing them straight to the trades table.
+type StagedTradeWriter func(ctx context.Context, source string, trades []entity.Trade, lines []int64) (int, int, error)
+
+// TradeStager holds trades in the staging area of the ingestion identified by ingestionID.
+type TradeStager func(ctx context.Context, ingestionID int32, trades []entity.Trade, lines []int64) (int, int, error)
+
+type QuoteWriter func(ctx context.Context, quotes []entity.DailyQuote) (int, error)
+
+type InstrumentWriter func(ctx context.Context, instruments []entity.Instrument) (int, error)
+
+type CorporateEventWriter func(ctx context.Context, events []entity.CorporateEvent) (int, error)
+
+type BatchWriter struct {
+	Trades       TradeWriter
+	StagedTrades StagedTradeWriter
+	Quotes       QuoteWriter
+	Instruments  InstrumentWriter
+	Events       CorporateEventWriter
+}
+
+func (w BatchWriter) Write(ctx context.Context, batch *Batch) (int, int, error) {
+	switch {
+	case len(batch.Quotes) > 0:
+		created, err := w.Quotes(ctx, batch.Quotes)
+
+		return created, 0, err
+	case len(batch.Instruments) > 0:
+		upserted, err := w.Instruments(ctx, batch.Instruments)
+
+		return upserted, 0, err
+	case len(batch.Events) > 0:
+		created, err := w.Events(ctx, batch.Events)
+
+		return created, 0, err
+	case w.StagedTrades != nil:
+		return w.StagedTrades(ctx, batch.Source, batch.Trades, batch.Lines)
+	default:
+		return w.Trades(ctx, batch.Trades)
+	}
+}
+
+type BatchPool struct {
+	size   int
+	budget *RowBudget
+	pool   sync.Pool
+}
+
+func NewBatchPool(size int) *BatchPool {
+	return NewBudgetedBatchPool(size, nil)
+}
+
+// NewBudgetedBatchPool returns a pool whose Acquire waits until budget has room for a full batch.
+func NewBudgetedBatchPool(size int, budget *RowBudget) *BatchPool {
+	p := &BatchPool{size: size, budget: budget, pool: sync.Pool{}}
+	p.pool.New = func() any {
+		return &Batch{
+			Source:      "",
+			Segment:     0,
+			Seq:         0,
+			LastLine:    0,
+			Trades:      make([]entity.Trade, 0, size),
+			Quotes:      nil,
+			Instruments: nil,
+			Events:      nil,
+			Lines:       make([]int64, 0, size),
+			codes:       make([]int32, 0, size*participantCodesPerTrade),
+			reserved:    0,
+		}
+	}
+
+	return p
+}
+
+func (p *BatchPool) Size() int {
+	return p.size
+}
+
+func (p *BatchPool) Get() *Batch {
+	return p.pool.Get().(*Batch) //nolint:forcetypeassert
+}
+
+func (p *BatchPool) Acquire(ctx context.Context) (*Batch, error) {
+	if p.budget == nil {
+		return p.Get(), nil
+	}
+
+	if err := p.budget.Acquire(ctx, int64(p.size)); err != nil {
+		return nil, err
+	}
+	batch := p.Get()
+	batch.reserved = int64(p.size)
+
+	return batch, nil
+}
+
+func (p *BatchPool) Put(b *Batch) {
+	if b.reserved > 0 {
+		p.budget.Release(b.reserved)
+	}
+	b.reset()
+	p.pool.Put(b)
+}
+
+type fieldCache struct {
+	dates   map[string]time.Time
+	strings map[string]string
+}
+
+func newFieldCache() *fieldCache {
+	return &fieldCache{
+		dates:   make(map[string]time.Time),
+		strings: make(map[string]string),
+	}
+}
+
+func (c *fieldCache) date(raw string) (time.Time, error) {
+	return c.dateLayout(raw, time.DateOnly)
+}
+
+func (c *fieldCache) dateLayout(raw, layout string) (time.Time, error) {
+	if date, ok := c.dates[raw]; ok {
+		return date, nil
+	}
+
+	date, err := time.Parse(layout, raw)
+	if err != nil {
+		return time.Time{}, errors.Wrap(err, "parse date")
+	}
+	c.dates[strings.Clone(raw)] = date
+
+	return date, nil
+}
+
+func (c *fieldCache) intern(raw string) string {
+	if interned, ok := c.strings[raw]; ok {
+		return interned
+	}
+
+	interned := strings.Clone(raw)
+	if len(c.strings) < maxInternedStrings {
+		c.strings[interned] = interned
+	}
+
+	return interned
+}
@@ -0,0 +1,78 @@
+package filehandler
+
+import (
+	"context"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// RowBudget caps the rows held by batches between the parsers and the database workers. A parser
+// reserves a full batch before filling it and the reservation is released when the batch returns
+// to the pool, so parsing stalls while the database falls behind. A reservation larger than the
+// whole budget is granted once nothing else is in flight. A limit of zero only tracks the peak.
+type RowBudget struct {
+	limit    int64
+	mu       sync.Mutex
+	inFlight int64
+	peak     int64
+	released chan struct{}
+}
+
+func NewRowBudget(limit int64) *RowBudget {
+	return &RowBudget{
+		limit:    limit,
+		mu:       sync.Mutex{},
+		inFlight: 0,
+		peak:     0,
+		released: make(chan struct{}),
+	}
+}
+
+func (b *RowBudget) Acquire(ctx context.Context, rows int64) error {
+	for {
+		b.mu.Lock()
+		if b.limit <= 0 || b.inFlight == 0 || b.inFlight+rows <= b.limit {
+			b.inFlight += rows
+			b.peak = max(b.peak, b.inFlight)
+			b.mu.Unlock()
+
+			return nil
+		}
+		released := b.released
+		b.mu.Unlock()
+
+		select {
+		case <-released:
+		case <-ctx.Done():
+			return errors.Wrap(ctx.Err(), "waiting for in-flight rows")
+		}
+	}
+}
+
+func (b *RowBudget) Release(rows int64) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.inFlight -= rows
+	close(b.released)
+	b.released = make(chan struct{})
+}
+
+func (b *RowBudget) Limit() int64 {
+	return b.limit
+}
+
+func (b *RowBudget) InFlight() int64 {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.inFlight
+}
+
+func (b *RowBudget) Peak() int64 {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.peak
+}
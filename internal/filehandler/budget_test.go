@@ -0,0 +1,104 @@
+package filehandler
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestRowBudget_Acquire(t *testing.T) {
+	budget := NewRowBudget(10)
+	ctx := context.Background()
+
+	require.NoError(t, budget.Acquire(ctx, 6))
+	require.NoError(t, budget.Acquire(ctx, 4))
+
+	acquired := make(chan error)
+	go func() {
+		acquired <- budget.Acquire(ctx, 5)
+	}()
+	select {
+	case <-acquired:
+		t.Fatal("acquire should wait while the budget is exhausted")
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	budget.Release(6)
+	require.NoError(t, <-acquired)
+	assert.Equal(t, int64(9), budget.InFlight())
+
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	assert.ErrorIs(t, budget.Acquire(cancelled, 5), context.Canceled)
+
+	budget.Release(9)
+	require.NoError(t, budget.Acquire(ctx, 50))
+	assert.Equal(t, int64(50), budget.Peak())
+
+	unlimited := NewRowBudget(0)
+	require.NoError(t, unlimited.Acquire(ctx, 100))
+	require.NoError(t, unlimited.Acquire(ctx, 100))
+	assert.Equal(t, int64(200), unlimited.Peak())
+}
+
+func TestParseSegment_RowBudget(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "trades.txt")
+	var content strings.Builder
+	content.WriteString(strings.SplitAfter(validationCSV, "\n")[0])
+	for i := range 9 {
+		fmt.Fprintf(&content, "2025-06-02;PETR4;0;32,150;100;100001123;%d;1;2025-06-02;3;8\n", i+1)
+	}
+	require.NoError(t, os.WriteFile(path, []byte(content.String()), 0o600))
+
+	budget := NewRowBudget(4)
+	observer := &recordingObserver{parsed: 0, waits: make(map[WaitCause]int)}
+	opts := newTestParseOptions(2, newTestRejectWriter(t))
+	opts.Pool = NewBudgetedBatchPool(2, budget)
+	opts.Observer = observer
+
+	out := make(chan *Batch)
+	done := make(chan struct{})
+	var rows int
+	go func() {
+		defer close(done)
+		for batch := range out {
+			assert.LessOrEqual(t, budget.InFlight(), int64(4))
+			rows += batch.Len()
+			opts.Pool.Put(batch)
+		}
+	}()
+
+	stats, err := ParseSegment(context.Background(), path, WholeFile(), 0, out, opts, zap.NewNop())
+	close(out)
+	<-done
+	require.NoError(t, err)
+
+	assert.Equal(t, int64(9), stats.Parsed)
+	assert.Equal(t, 9, rows)
+	assert.Equal(t, int64(0), budget.InFlight())
+	assert.LessOrEqual(t, budget.Peak(), int64(4))
+	assert.Equal(t, 9, observer.parsed)
+	assert.Equal(t, 5, observer.waits[WaitBudget])
+	assert.Equal(t, 5, observer.waits[WaitConsumer])
+}
+
+type recordingObserver struct {
+	parsed int
+	waits  map[WaitCause]int
+}
+
+func (o *recordingObserver) ObserveParsed(rows int) {
+	o.parsed += rows
+}
+
+func (o *recordingObserver) ObserveWait(cause WaitCause, _ time.Duration) {
+	o.waits[cause]++
+}
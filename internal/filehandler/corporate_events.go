@@ -0,0 +1,132 @@
+package filehandler
+
+import (
+	"b3challenge/internal/domain/entity"
+	"context"
+	"io"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+)
+
+const (
+	corporateEventsName    = "CORPORATEEVENTS"
+	corporateTickerColumn  = "ticker"
+	corporateTypeColumn    = "event_type"
+	corporateExDateColumn  = "ex_date"
+	corporateFactorColumn  = "factor"
+	corporateAmountColumn  = "amount"
+	corporateNameSeparator = "_"
+)
+
+//nolint:gochecknoglobals
+var corporateEventAliases = map[string]entity.CorporateEventType{
+	"DESDOBRAMENTO":   entity.CorporateEventSplit,
+	"GRUPAMENTO":      entity.CorporateEventReverseSplit,
+	"DIVIDENDO":       entity.CorporateEventCashDividend,
+	"JRS CAP PROPRIO": entity.CorporateEventInterestOnEquity,
+	"JCP":             entity.CorporateEventInterestOnEquity,
+}
+
+func IsCorporateEventsFile(name string) bool {
+	base := strings.ReplaceAll(strings.ToUpper(filepath.Base(name)), corporateNameSeparator, "")
+
+	return strings.Contains(base, corporateEventsName)
+}
+
+func ParseCorporateEventsFile(
+	ctx context.Context,
+	filePath string,
+	fromLine int64,
+	out chan<- *Batch,
+	opts ParseOptions,
+	logger *zap.Logger,
+) (ParseStats, error) {
+	parser := &corporateEventParser{tradeParser: newTradeParser(filePath, 0, fromLine, out, opts, logger)}
+	var lineOffset int64
+
+	err := forEachTradeStream(filePath, func(stream io.Reader) error {
+		lastLine, err := parser.parse(ctx, stream, lineOffset)
+		lineOffset = lastLine
+
+		return err
+	})
+
+	return parser.finish(ctx, err)
+}
+
+type corporateEventParser struct {
+	*tradeParser
+}
+
+func (p *corporateEventParser) parse(ctx context.Context, stream io.Reader, lineOffset int64) (int64, error) {
+	required := []string{corporateTickerColumn, corporateTypeColumn, corporateExDateColumn}
+
+	return p.parseHeaderCSV(ctx, stream, lineOffset, required, p.parseRecord)
+}
+
+func (p *corporateEventParser) parseRecord(batch *Batch, columns csvColumns, rec []string, line int64) (time.Time, error) {
+	event := batch.nextEvent(line)
+	if err := p.parseEvent(columns, rec, event); err != nil {
+		return time.Time{}, err
+	}
+
+	return event.ExDate, nil
+}
+
+func (p *corporateEventParser) parseEvent(columns csvColumns, rec []string, event *entity.CorporateEvent) error {
+	ticker := columns.get(rec, corporateTickerColumn)
+	if ticker == "" {
+		return newRejectError(ReasonInvalidTicker, errors.New("missing ticker"))
+	}
+	eventType, err := parseCorporateEventType(columns.get(rec, corporateTypeColumn))
+	if err != nil {
+		return newRejectError(ReasonInvalidEventType, err)
+	}
+	exDate, err := p.cache.date(columns.get(rec, corporateExDateColumn))
+	if err != nil {
+		return newRejectError(ReasonInvalidDate, errors.Wrap(err, "parsing ex date"))
+	}
+	factor, err := parseOptionalDecimal(columns.get(rec, corporateFactorColumn))
+	if err != nil || eventType.IsShareEvent() && !factor.IsPositive() {
+		return newRejectError(ReasonInvalidFactor, errors.Errorf("invalid factor %q", columns.get(rec, corporateFactorColumn)))
+	}
+	amount, err := parseOptionalDecimal(columns.get(rec, corporateAmountColumn))
+	if err != nil || !eventType.IsShareEvent() && !amount.IsPositive() {
+		return newRejectError(ReasonInvalidPrice, errors.Errorf("invalid amount %q", columns.get(rec, corporateAmountColumn)))
+	}
+
+	*event = entity.CorporateEvent{
+		Ticker: p.cache.intern(ticker),
+		Type:   eventType,
+		ExDate: exDate,
+		Factor: factor,
+		Amount: amount,
+	}
+
+	return nil
+}
+
+func parseCorporateEventType(raw string) (entity.CorporateEventType, error) {
+	eventType := entity.CorporateEventType(strings.ToLower(raw))
+	if eventType.IsKnown() {
+		return eventType, nil
+	}
+	if alias, ok := corporateEventAliases[strings.ToUpper(raw)]; ok {
+		return alias, nil
+	}
+
+	return "", errors.Errorf("unknown event type %q", raw)
+}
+
+func parseOptionalDecimal(raw string) (decimal.Decimal, error) {
+	if raw == "" {
+		return decimal.Zero, nil
+	}
+
+	return parsePrice(raw)
+}
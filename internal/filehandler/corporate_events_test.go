@@ -0,0 +1,57 @@
+package filehandler
+
+import (
+	"b3challenge/internal/domain/entity"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+const corporateEventsFile = "testdata/corporate_events/CorporateEvents_20250602.csv"
+
+func TestIsCorporateEventsFile(t *testing.T) {
+	assert.True(t, IsCorporateEventsFile("b3Data/CorporateEvents_20250602.csv"))
+	assert.True(t, IsCorporateEventsFile("uploads/12_corporate_events.csv"))
+	assert.True(t, IsSupportedFile("b3Data/CorporateEvents_20250602.csv"))
+	assert.False(t, IsCorporateEventsFile("b3Data/InstrumentsConsolidatedFile_20250602.csv"))
+}
+
+func TestParseCorporateEventsFile(t *testing.T) {
+	date := func(day int) time.Time {
+		return time.Date(2025, 6, day, 0, 0, 0, 0, time.UTC)
+	}
+
+	out := make(chan *Batch, 10)
+	rejects := newTestRejectWriter(t)
+	stats, err := ParseSegment(
+		context.Background(), corporateEventsFile, WholeFile(), 0, out, newTestParseOptions(10, rejects), zap.NewNop(),
+	)
+	require.NoError(t, err)
+	close(out)
+
+	var lines []int64
+	var events []entity.CorporateEvent
+	for batch := range out {
+		lines = append(lines, batch.Lines...)
+		events = append(events, batch.Events...)
+	}
+
+	assert.Equal(t, ParseStats{Parsed: 4, Rejected: 3, Filtered: 0}, stats)
+	assert.Equal(t, []int64{2, 3, 4, 5}, lines)
+	assert.Equal(t, map[RejectReason]int64{
+		ReasonInvalidEventType: 1,
+		ReasonInvalidFactor:    1,
+		ReasonInvalidTicker:    1,
+	}, rejects.Counts())
+	assert.Equal(t, []entity.CorporateEvent{
+		{Ticker: "PETR4", Type: entity.CorporateEventCashDividend, ExDate: date(3), Factor: decimal.Zero, Amount: decimal.New(145, -2)},
+		{Ticker: "PETR4", Type: entity.CorporateEventSplit, ExDate: date(10), Factor: decimal.New(2, 0), Amount: decimal.Zero},
+		{Ticker: "MGLU3", Type: entity.CorporateEventReverseSplit, ExDate: date(12), Factor: decimal.New(1, -1), Amount: decimal.Zero},
+		{Ticker: "VALE3", Type: entity.CorporateEventInterestOnEquity, ExDate: date(4), Factor: decimal.Zero, Amount: decimal.New(85, -2)},
+	}, events)
+}
@@ -0,0 +1,319 @@
+package filehandler
+
+import (
+	"b3challenge/internal/domain/entity"
+	"bufio"
+	"context"
+	"io"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+)
+
+const (
+	cotahistName         = "COTAHIST"
+	cotahistRecordLength = 245
+	cotahistDateLayout   = "20060102"
+	cotahistNoExpiration = "99991231"
+	cotahistHeader       = "00"
+	cotahistQuote        = "01"
+	cotahistTrailer      = "99"
+	priceDecimals        = 2
+)
+
+type cotahistField struct {
+	start int
+	end   int
+}
+
+func (f cotahistField) of(line string) string {
+	return strings.TrimSpace(line[f.start-1 : f.end])
+}
+
+//nolint:gochecknoglobals
+var (
+	recordTypeField     = cotahistField{start: 1, end: 2}
+	headerFileField     = cotahistField{start: 3, end: 15}
+	trailerRecordsField = cotahistField{start: 32, end: 42}
+	quoteDateField      = cotahistField{start: 3, end: 10}
+	bdiCodeField        = cotahistField{start: 11, end: 12}
+	tickerField         = cotahistField{start: 13, end: 24}
+	marketTypeField     = cotahistField{start: 25, end: 27}
+	companyNameField    = cotahistField{start: 28, end: 39}
+	specificationField  = cotahistField{start: 40, end: 49}
+	forwardTermField    = cotahistField{start: 50, end: 52}
+	currencyField       = cotahistField{start: 53, end: 56}
+	openPriceField      = cotahistField{start: 57, end: 69}
+	maxPriceField       = cotahistField{start: 70, end: 82}
+	minPriceField       = cotahistField{start: 83, end: 95}
+	avgPriceField       = cotahistField{start: 96, end: 108}
+	closePriceField     = cotahistField{start: 109, end: 121}
+	bestBidPriceField   = cotahistField{start: 122, end: 134}
+	bestAskPriceField   = cotahistField{start: 135, end: 147}
+	tradesCountField    = cotahistField{start: 148, end: 152}
+	quantityField       = cotahistField{start: 153, end: 170}
+	volumeField         = cotahistField{start: 171, end: 188}
+	strikePriceField    = cotahistField{start: 189, end: 201}
+	expirationDateField = cotahistField{start: 203, end: 210}
+	quoteFactorField    = cotahistField{start: 211, end: 217}
+	isinField           = cotahistField{start: 231, end: 242}
+	quotePriceFields    = [...]cotahistField{
+		openPriceField, maxPriceField, minPriceField, avgPriceField,
+		closePriceField, bestBidPriceField, bestAskPriceField, strikePriceField,
+	}
+	errInvalidHeader     = errors.New("invalid COTAHIST header")
+	errUnexpectedTrailer = errors.New("unexpected COTAHIST trailer")
+)
+
+func IsCotahistFile(name string) bool {
+	return strings.Contains(strings.ToUpper(filepath.Base(name)), cotahistName)
+}
+
+func ParseCotahistFile(
+	ctx context.Context,
+	filePath string,
+	fromLine int64,
+	out chan<- *Batch,
+	opts ParseOptions,
+	logger *zap.Logger,
+) (ParseStats, error) {
+	parser := &cotahistParser{tradeParser: newTradeParser(filePath, 0, fromLine, out, opts, logger)}
+	var lineOffset int64
+
+	err := forEachTradeStream(filePath, func(stream io.Reader) error {
+		lastLine, err := parser.parse(ctx, stream, lineOffset)
+		lineOffset = lastLine
+
+		return err
+	})
+
+	return parser.finish(ctx, err)
+}
+
+type cotahistParser struct {
+	*tradeParser
+}
+
+func (p *cotahistParser) parse(ctx context.Context, stream io.Reader, lineOffset int64) (int64, error) {
+	scanner := bufio.NewScanner(stream)
+	lastLine := lineOffset
+	trailer := false
+
+	for scanner.Scan() {
+		if err := ctx.Err(); err != nil {
+			p.logger.Info("Context cancelled, stopping file parsing")
+
+			return lastLine, errors.Wrap(err, "context cancelled")
+		}
+
+		lastLine++
+		raw := strings.TrimRight(scanner.Text(), "\r")
+		if raw == "" {
+			continue
+		}
+		if trailer {
+			return lastLine, errors.Wrapf(errUnexpectedTrailer, "line %d", lastLine-1)
+		}
+
+		switch recordType := raw[:min(len(raw), len(cotahistHeader))]; {
+		case recordType == cotahistHeader:
+			if !strings.HasPrefix(headerFileField.of(padRecord(raw)), cotahistName) {
+				return lastLine, errors.Wrapf(errInvalidHeader, "line %d", lastLine)
+			}
+		case recordType == cotahistTrailer:
+			trailer = true
+			p.checkTrailer(padRecord(raw), lastLine-lineOffset)
+		case lastLine <= p.fromLine:
+		default:
+			if err := p.parseRecord(ctx, raw, lastLine); err != nil {
+				return lastLine, err
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return lastLine, errors.Wrap(err, "reading COTAHIST file")
+	}
+
+	return lastLine, nil
+}
+
+func (p *cotahistParser) parseRecord(ctx context.Context, raw string, line int64) error {
+	batch, err := p.currentBatch(ctx)
+	if err != nil {
+		return err
+	}
+	quote := batch.nextQuote(line)
+	if err := p.parseQuote(raw, quote); err != nil {
+		batch.discardLast()
+		p.logger.Error("parsing quote: ", zap.String("file", p.filePath), zap.Int64("line", line), zap.Error(err))
+		p.stats.Rejected++
+		writeReject(p.opts.Rejects, p.filePath, line, []string{raw}, ReasonOf(err), err, p.logger)
+
+		return nil
+	}
+	if !p.opts.Dates.Contains(quote.Date) {
+		batch.discardLast()
+		p.stats.Filtered++
+
+		return nil
+	}
+	p.stats.Parsed++
+
+	if batch.Len() >= p.opts.Pool.Size() {
+		return p.flush(ctx)
+	}
+
+	return nil
+}
+
+func (p *cotahistParser) checkTrailer(raw string, lines int64) {
+	total, err := strconv.ParseInt(trailerRecordsField.of(raw), 10, 64)
+	if err != nil || total != lines {
+		p.logger.Warn(
+			"COTAHIST trailer record count mismatch",
+			zap.String("file", p.filePath),
+			zap.String("declared", trailerRecordsField.of(raw)),
+			zap.Int64("read", lines),
+		)
+	}
+}
+
+func (p *cotahistParser) parseQuote(raw string, quote *entity.DailyQuote) error {
+	if len(raw) < cotahistRecordLength {
+		return newRejectError(ReasonInvalidRecordLength, errors.Errorf("invalid record length %d", len(raw)))
+	}
+	if recordType := recordTypeField.of(raw); recordType != cotahistQuote {
+		return newRejectError(ReasonInvalidRecordType, errors.Errorf("unknown record type %q", recordType))
+	}
+
+	date, err := p.cache.dateLayout(quoteDateField.of(raw), cotahistDateLayout)
+	if err != nil {
+		return newRejectError(ReasonInvalidDate, errors.Wrap(err, "parsing trade date"))
+	}
+	bdiCode, err := strconv.ParseInt(bdiCodeField.of(raw), 10, 16)
+	if err != nil || !entity.BDICode(bdiCode).IsKnown() {
+		return newRejectError(ReasonInvalidBDICode, errors.Errorf("unknown BDI code %q", bdiCodeField.of(raw)))
+	}
+	marketType, err := strconv.ParseInt(marketTypeField.of(raw), 10, 16)
+	if err != nil || !entity.MarketType(marketType).IsKnown() {
+		return newRejectError(ReasonInvalidMarketType, errors.Errorf("unknown market type %q", marketTypeField.of(raw)))
+	}
+	forwardTerm, err := parseOptionalInt(forwardTermField.of(raw), 16)
+	if err != nil {
+		return newRejectError(ReasonInvalidForwardTerm, errors.Wrap(err, "parsing forward term"))
+	}
+
+	var prices [len(quotePriceFields)]decimal.Decimal
+	for i, field := range quotePriceFields {
+		if prices[i], err = parseImpliedDecimal(field.of(raw), priceDecimals); err != nil {
+			return newRejectError(ReasonInvalidPrice, errors.Wrap(err, "parsing price"))
+		}
+	}
+
+	tradesCount, err := strconv.ParseInt(tradesCountField.of(raw), 10, 32)
+	if err != nil {
+		return newRejectError(ReasonInvalidTradesCount, errors.Wrap(err, "parsing trades count"))
+	}
+	quantity, err := strconv.ParseInt(quantityField.of(raw), 10, 64)
+	if err != nil {
+		return newRejectError(ReasonInvalidQuantity, errors.Wrap(err, "parsing quantity"))
+	}
+	volume, err := parseImpliedDecimal(volumeField.of(raw), priceDecimals)
+	if err != nil {
+		return newRejectError(ReasonInvalidPrice, errors.Wrap(err, "parsing volume"))
+	}
+	quoteFactor, err := strconv.ParseInt(quoteFactorField.of(raw), 10, 32)
+	if err != nil {
+		return newRejectError(ReasonInvalidQuoteFactor, errors.Wrap(err, "parsing quote factor"))
+	}
+	expiration, err := p.parseExpiration(expirationDateField.of(raw))
+	if err != nil {
+		return newRejectError(ReasonInvalidDate, errors.Wrap(err, "parsing expiration date"))
+	}
+
+	*quote = entity.DailyQuote{
+		Date:            date,
+		Ticker:          p.cache.intern(tickerField.of(raw)),
+		BDICode:         entity.BDICode(bdiCode),
+		MarketType:      entity.MarketType(marketType),
+		CompanyName:     p.cache.intern(decodeLatin1(companyNameField.of(raw))),
+		Specification:   p.cache.intern(decodeLatin1(specificationField.of(raw))),
+		ForwardTermDays: int16(forwardTerm),
+		Currency:        p.cache.intern(currencyField.of(raw)),
+		OpenPrice:       prices[0],
+		MaxPrice:        prices[1],
+		MinPrice:        prices[2],
+		AvgPrice:        prices[3],
+		ClosePrice:      prices[4],
+		BestBidPrice:    prices[5],
+		BestAskPrice:    prices[6],
+		TradesCount:     int32(tradesCount),
+		Quantity:        quantity,
+		Volume:          volume,
+		StrikePrice:     prices[7],
+		ExpirationDate:  expiration,
+		QuoteFactor:     int32(quoteFactor),
+		ISIN:            p.cache.intern(isinField.of(raw)),
+	}
+
+	return nil
+}
+
+func (p *cotahistParser) parseExpiration(raw string) (*time.Time, error) {
+	if raw == "" || raw == cotahistNoExpiration || strings.Trim(raw, "0") == "" {
+		return nil, nil //nolint:nilnil
+	}
+
+	date, err := p.cache.dateLayout(raw, cotahistDateLayout)
+	if err != nil {
+		return nil, err
+	}
+
+	return &date, nil
+}
+
+func parseImpliedDecimal(raw string, decimals int32) (decimal.Decimal, error) {
+	value, err := strconv.ParseInt(raw, 10, 64)
+	if err != nil {
+		return decimal.Zero, errors.Wrap(err, "parse int")
+	}
+
+	return decimal.New(value, -decimals), nil
+}
+
+func parseOptionalInt(raw string, bitSize int) (int64, error) {
+	if raw == "" {
+		return 0, nil
+	}
+
+	value, err := strconv.ParseInt(raw, 10, bitSize)
+
+	return value, errors.Wrap(err, "parse int")
+}
+
+func padRecord(raw string) string {
+	if len(raw) >= cotahistRecordLength {
+		return raw
+	}
+
+	return raw + strings.Repeat(" ", cotahistRecordLength-len(raw))
+}
+
+func decodeLatin1(raw string) string {
+	if utf8.ValidString(raw) {
+		return raw
+	}
+
+	runes := make([]rune, len(raw))
+	for i := range len(raw) {
+		runes[i] = rune(raw[i])
+	}
+
+	return string(runes)
+}
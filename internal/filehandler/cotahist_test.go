@@ -0,0 +1,203 @@
+package filehandler
+
+import (
+	"b3challenge/internal/domain/entity"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+const cotahistFile = "testdata/cotahist/COTAHIST_D02062025.TXT"
+
+func drainQuotes(out chan *Batch) ([]int64, []entity.DailyQuote) {
+	close(out)
+
+	var lines []int64
+	var quotes []entity.DailyQuote
+	for batch := range out {
+		lines = append(lines, batch.Lines...)
+		quotes = append(quotes, batch.Quotes...)
+	}
+
+	return lines, quotes
+}
+
+func TestIsCotahistFile(t *testing.T) {
+	assert.True(t, IsCotahistFile("b3Data/COTAHIST_A2024.TXT"))
+	assert.True(t, IsCotahistFile("uploads/123_cotahist_d02062025.zip"))
+	assert.False(t, IsCotahistFile("b3Data/02-06-2025_NEGOCIOSAVISTA.txt"))
+}
+
+func TestParseCotahistFile(t *testing.T) {
+	date := time.Date(2025, 6, 2, 0, 0, 0, 0, time.UTC)
+	expiration := time.Date(2025, 6, 20, 0, 0, 0, 0, time.UTC)
+
+	out := make(chan *Batch, 10)
+	rejects := newTestRejectWriter(t)
+	stats, err := ParseCotahistFile(context.Background(), cotahistFile, 0, out, newTestParseOptions(2, rejects), zap.NewNop())
+	require.NoError(t, err)
+
+	lines, quotes := drainQuotes(out)
+	assert.Equal(t, ParseStats{Parsed: 3, Rejected: 1, Filtered: 0}, stats)
+	assert.Equal(t, []int64{2, 3, 4}, lines)
+	assert.Equal(t, map[RejectReason]int64{ReasonInvalidPrice: 1}, rejects.Counts())
+	require.Len(t, quotes, 3)
+
+	assert.Equal(t, entity.DailyQuote{
+		Date:            date,
+		Ticker:          "PETR4",
+		BDICode:         2,
+		MarketType:      entity.MarketTypeCash,
+		CompanyName:     "PETROBRAS",
+		Specification:   "PN      N2",
+		ForwardTermDays: 0,
+		Currency:        "R$",
+		OpenPrice:       decimal.New(3150, -2),
+		MaxPrice:        decimal.New(3210, -2),
+		MinPrice:        decimal.New(3120, -2),
+		AvgPrice:        decimal.New(3175, -2),
+		ClosePrice:      decimal.New(3190, -2),
+		BestBidPrice:    decimal.New(3189, -2),
+		BestAskPrice:    decimal.New(3190, -2),
+		TradesCount:     25310,
+		Quantity:        31250400,
+		Volume:          decimal.New(99218957600, -2),
+		StrikePrice:     decimal.New(0, -2),
+		ExpirationDate:  nil,
+		QuoteFactor:     1,
+		ISIN:            "BRPETRACNPR6",
+	}, quotes[0])
+
+	assert.Equal(t, "CEMIG ENERGÉ", quotes[1].CompanyName)
+
+	option := quotes[2]
+	assert.Equal(t, entity.BDICode(78), option.BDICode)
+	assert.Equal(t, "OPCOES DE COMPRA", option.BDICode.String())
+	assert.Equal(t, entity.MarketTypeCallOption, option.MarketType)
+	assert.True(t, decimal.RequireFromString("32").Equal(option.StrikePrice))
+	assert.Equal(t, &expiration, option.ExpirationDate)
+}
+
+func TestParseCotahistFile_FromLineAndDates(t *testing.T) {
+	out := make(chan *Batch, 10)
+	stats, err := ParseCotahistFile(
+		context.Background(), cotahistFile, 3, out, newTestParseOptions(10, newTestRejectWriter(t)), zap.NewNop(),
+	)
+	require.NoError(t, err)
+	lines, _ := drainQuotes(out)
+	assert.Equal(t, []int64{4}, lines)
+	assert.Equal(t, int64(1), stats.Parsed)
+
+	out = make(chan *Batch, 10)
+	opts := newTestParseOptions(10, newTestRejectWriter(t))
+	opts.Dates = DateRange{From: time.Date(2025, 6, 3, 0, 0, 0, 0, time.UTC), To: time.Time{}}
+	stats, err = ParseCotahistFile(context.Background(), cotahistFile, 0, out, opts, zap.NewNop())
+	require.NoError(t, err)
+	_, quotes := drainQuotes(out)
+	assert.Empty(t, quotes)
+	assert.Equal(t, int64(3), stats.Filtered)
+}
+
+func TestCotahistParser_ParseQuote(t *testing.T) {
+	valid := cotahistRecord(t, map[cotahistField]string{})
+
+	tests := []struct {
+		name       string
+		raw        string
+		wantReason RejectReason
+	}{
+		{
+			name:       "short record",
+			raw:        valid[:100],
+			wantReason: ReasonInvalidRecordLength,
+		},
+		{
+			name:       "unknown record type",
+			raw:        "02" + valid[2:],
+			wantReason: ReasonInvalidRecordType,
+		},
+		{
+			name:       "unknown bdi code",
+			raw:        cotahistRecord(t, map[cotahistField]string{bdiCodeField: "03"}),
+			wantReason: ReasonInvalidBDICode,
+		},
+		{
+			name:       "unknown market type",
+			raw:        cotahistRecord(t, map[cotahistField]string{marketTypeField: "999"}),
+			wantReason: ReasonInvalidMarketType,
+		},
+		{
+			name:       "invalid date",
+			raw:        cotahistRecord(t, map[cotahistField]string{quoteDateField: "20251341"}),
+			wantReason: ReasonInvalidDate,
+		},
+		{
+			name:       "invalid quantity",
+			raw:        cotahistRecord(t, map[cotahistField]string{quantityField: "00000000000000000X"}),
+			wantReason: ReasonInvalidQuantity,
+		},
+		{
+			name:       "invalid forward term",
+			raw:        cotahistRecord(t, map[cotahistField]string{forwardTermField: "0X0"}),
+			wantReason: ReasonInvalidForwardTerm,
+		},
+		{
+			name:       "invalid trades count",
+			raw:        cotahistRecord(t, map[cotahistField]string{tradesCountField: "0000X"}),
+			wantReason: ReasonInvalidTradesCount,
+		},
+		{
+			name:       "invalid quote factor",
+			raw:        cotahistRecord(t, map[cotahistField]string{quoteFactorField: "000000X"}),
+			wantReason: ReasonInvalidQuoteFactor,
+		},
+	}
+
+	parser := &cotahistParser{tradeParser: newTradeParser("COTAHIST.TXT", 0, 0, nil, ParseOptions{}, zap.NewNop())}
+	var quote entity.DailyQuote
+	require.NoError(t, parser.parseQuote(valid, &quote))
+	assert.Equal(t, "PETR4", quote.Ticker)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := parser.parseQuote(tt.raw, &quote)
+			require.Error(t, err)
+			assert.Equal(t, tt.wantReason, ReasonOf(err))
+		})
+	}
+}
+
+func TestParseSegment_Cotahist(t *testing.T) {
+	segments, err := SplitFile(cotahistFile, 10)
+	require.NoError(t, err)
+	assert.Equal(t, []Segment{WholeFile()}, segments)
+
+	out := make(chan *Batch, 10)
+	opts := newTestParseOptions(10, newTestRejectWriter(t))
+	stats, err := ParseSegment(context.Background(), cotahistFile, segments[0], 0, out, opts, zap.NewNop())
+	require.NoError(t, err)
+	_, quotes := drainQuotes(out)
+	assert.Len(t, quotes, 3)
+	assert.Equal(t, int64(3), stats.Parsed)
+}
+
+func cotahistRecord(t *testing.T, overrides map[cotahistField]string) string {
+	t.Helper()
+
+	record := []byte("012025060202PETR4       010PETROBRAS   PN      N2   R$  " + strings.Repeat("0", 189))
+	copy(record[tradesCountField.start-1:], "00001")
+	copy(record[expirationDateField.start-1:], cotahistNoExpiration)
+	copy(record[quoteFactorField.start-1:], "0000001")
+	for field, value := range overrides {
+		require.Len(t, value, field.end-field.start+1)
+		copy(record[field.start-1:], value)
+	}
+
+	return string(record)
+}
@@ -0,0 +1,119 @@
+package filehandler
+
+import (
+	"context"
+	"encoding/csv"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+var errMissingColumn = errors.New("header is missing a required column")
+
+type csvColumns map[string]int
+
+func newCSVColumns(header []string, required ...string) (csvColumns, error) {
+	columns := make(csvColumns, len(header))
+	for i, name := range header {
+		columns[strings.TrimPrefix(strings.TrimSpace(name), "\ufeff")] = i
+	}
+
+	for _, name := range required {
+		if _, ok := columns[name]; !ok {
+			return nil, errors.Wrap(errMissingColumn, name)
+		}
+	}
+
+	return columns, nil
+}
+
+func (c csvColumns) get(rec []string, name string) string {
+	index, ok := c[name]
+	if !ok || index >= len(rec) {
+		return ""
+	}
+
+	return strings.TrimSpace(rec[index])
+}
+
+type headerRecordFunc func(batch *Batch, columns csvColumns, rec []string, line int64) (time.Time, error)
+
+func (p *tradeParser) parseHeaderCSV(
+	ctx context.Context,
+	stream io.Reader,
+	lineOffset int64,
+	required []string,
+	parseRecord headerRecordFunc,
+) (int64, error) {
+	reader := csv.NewReader(stream)
+	reader.Comma = ';'
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return lineOffset, errors.Wrap(err, "reading header")
+	}
+	columns, err := newCSVColumns(header, required...)
+	if err != nil {
+		return lineOffset, err
+	}
+	lastLine := lineOffset + 1
+
+	for {
+		if err := ctx.Err(); err != nil {
+			p.logger.Info("Context cancelled, stopping file parsing")
+
+			return lastLine, errors.Wrap(err, "context cancelled")
+		}
+
+		rec, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return lastLine, nil
+		}
+
+		line := lineOffset + recordLine(reader, err)
+		lastLine = line
+		if line <= p.fromLine {
+			continue
+		}
+
+		if err != nil {
+			p.logger.Error("CSV read error: ", zap.String("file", p.filePath), zap.Int64("line", line), zap.Error(err))
+			p.stats.Rejected++
+			writeReject(p.opts.Rejects, p.filePath, line, rec, ReasonCSVRead, err, p.logger)
+
+			continue
+		}
+
+		batch, err := p.currentBatch(ctx)
+		if err != nil {
+			return lastLine, err
+		}
+		date, err := parseRecord(batch, columns, rec, line)
+		if err != nil {
+			batch.discardLast()
+			p.logger.Error("parsing record: ", zap.String("file", p.filePath), zap.Int64("line", line), zap.Error(err))
+			p.stats.Rejected++
+			writeReject(p.opts.Rejects, p.filePath, line, rec, ReasonOf(err), err, p.logger)
+
+			continue
+		}
+		if !p.opts.Dates.Contains(date) {
+			batch.discardLast()
+			p.stats.Filtered++
+
+			continue
+		}
+		p.stats.Parsed++
+
+		if batch.Len() >= p.opts.Pool.Size() {
+			if err := p.flush(ctx); err != nil {
+				return lastLine, err
+			}
+		}
+	}
+}
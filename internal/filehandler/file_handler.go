@@ -0,0 +1,549 @@
+package filehandler
+
+import (
+	"b3challenge/internal/domain/entity"
+	"context"
+	"crypto/sha256"
+	"encoding/csv"
+	"encoding/hex"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+)
+
+const (
+	minRecordLength          = 11
+	shortTradeTimeLength     = 6
+	tradeTimeLength          = 9
+	referenceDateColumnIndex = 0
+	tickerColumnIndex        = 1
+	updateActionColumnIndex  = 2
+	priceColumnIndex         = 3
+	quantityColumnIndex      = 4
+	hourColumnIndex          = 5
+	tradeIDColumnIndex       = 6
+	sessionTypeColumnIndex   = 7
+	dateColumnIndex          = 8
+	buyerCodeColumnIndex     = 9
+	sellerCodeColumnIndex    = 10
+)
+
+type ParseStats struct {
+	Parsed   int64
+	Rejected int64
+	Filtered int64
+}
+
+type DateRange struct {
+	From time.Time
+	To   time.Time
+}
+
+func (r DateRange) IsZero() bool {
+	return r.From.IsZero() && r.To.IsZero()
+}
+
+func (r DateRange) Contains(date time.Time) bool {
+	if !r.From.IsZero() && date.Before(r.From) {
+		return false
+	}
+
+	return r.To.IsZero() || !date.After(r.To)
+}
+
+type ParseOptions struct {
+	Pool      *BatchPool
+	Rejects   *RejectWriter
+	Dates     DateRange
+	Format    TradeFormat
+	Validator *Validator
+	Observer  ParseObserver
+}
+
+type WaitCause string
+
+const (
+	WaitBudget   WaitCause = "budget"
+	WaitConsumer WaitCause = "consumer"
+)
+
+// ParseObserver is told about every batch a parser hands over and about the time it spends blocked,
+// either on the row budget before filling a batch or on the consumer while handing one over, so
+// parsing time can be told apart from backpressure.
+type ParseObserver interface {
+	ObserveParsed(rows int)
+	ObserveWait(cause WaitCause, waited time.Duration)
+}
+
+func FindTXTFiles(pathDir string) ([]string, error) {
+	entries, err := os.ReadDir(pathDir)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Error reading directory %s", pathDir)
+	}
+	var list []string
+	for _, e := range entries {
+		if !e.IsDir() && IsSupportedFile(e.Name()) {
+			path := filepath.Join(pathDir, e.Name())
+			list = append(list, path)
+		}
+	}
+
+	return list, nil
+}
+
+func FindInputFiles(inputs, include, exclude []string) ([]string, error) {
+	seen := make(map[string]struct{})
+	var list []string
+	for _, input := range inputs {
+		info, err := os.Stat(input)
+		if err != nil {
+			return nil, errors.Wrapf(err, "Error reading input %s", input)
+		}
+
+		candidates := []string{input}
+		if info.IsDir() {
+			if candidates, err = FindTXTFiles(input); err != nil {
+				return nil, err
+			}
+		} else if !IsSupportedFile(input) {
+			return nil, errors.Errorf("unsupported input file %s", input)
+		}
+
+		for _, candidate := range candidates {
+			if _, ok := seen[candidate]; ok {
+				continue
+			}
+			selected, err := matchesGlobs(filepath.Base(candidate), include, exclude)
+			if err != nil {
+				return nil, err
+			}
+			if selected {
+				seen[candidate] = struct{}{}
+				list = append(list, candidate)
+			}
+		}
+	}
+
+	return list, nil
+}
+
+func IsSelectedFile(path string, include, exclude []string) (bool, error) {
+	if !IsSupportedFile(path) {
+		return false, nil
+	}
+
+	return matchesGlobs(filepath.Base(path), include, exclude)
+}
+
+func matchesGlobs(name string, include, exclude []string) (bool, error) {
+	for _, pattern := range exclude {
+		matched, err := filepath.Match(pattern, name)
+		if err != nil {
+			return false, errors.Wrapf(err, "invalid exclude pattern %s", pattern)
+		}
+		if matched {
+			return false, nil
+		}
+	}
+
+	if len(include) == 0 {
+		return true, nil
+	}
+	for _, pattern := range include {
+		matched, err := filepath.Match(pattern, name)
+		if err != nil {
+			return false, errors.Wrapf(err, "invalid include pattern %s", pattern)
+		}
+		if matched {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+func ComputeChecksum(filePath string) (string, int64, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return "", 0, errors.Wrap(err, "cannot open file")
+	}
+	defer file.Close()
+
+	hash := sha256.New()
+	size, err := io.Copy(hash, file)
+	if err != nil {
+		return "", 0, errors.Wrap(err, "hashing file")
+	}
+
+	return hex.EncodeToString(hash.Sum(nil)), size, nil
+}
+
+func ParseFileToTrades(
+	ctx context.Context,
+	filePath string,
+	fromLine int64,
+	out chan<- *Batch,
+	opts ParseOptions,
+	logger *zap.Logger,
+) (ParseStats, error) {
+	parser := newTradeParser(filePath, 0, fromLine, out, opts, logger)
+	var lineOffset int64
+
+	err := forEachTradeStream(filePath, func(stream io.Reader) error {
+		lastLine, err := parser.parse(ctx, stream, lineOffset, true)
+		lineOffset = lastLine
+
+		return err
+	})
+
+	return parser.finish(ctx, err)
+}
+
+type tradeParser struct {
+	filePath   string
+	segment    int
+	fromLine   int64
+	out        chan<- *Batch
+	opts       ParseOptions
+	logger     *zap.Logger
+	cache      *fieldCache
+	batch      *Batch
+	seq        int
+	stats      ParseStats
+	violations []Violation
+}
+
+func newTradeParser(
+	filePath string,
+	segment int,
+	fromLine int64,
+	out chan<- *Batch,
+	opts ParseOptions,
+	logger *zap.Logger,
+) *tradeParser {
+	return &tradeParser{
+		filePath:   filePath,
+		segment:    segment,
+		fromLine:   fromLine,
+		out:        out,
+		opts:       opts,
+		logger:     logger,
+		cache:      newFieldCache(),
+		batch:      nil,
+		seq:        0,
+		stats:      ParseStats{Parsed: 0, Rejected: 0, Filtered: 0},
+		violations: nil,
+	}
+}
+
+func (p *tradeParser) parse(ctx context.Context, stream io.Reader, lineOffset int64, withHeader bool) (int64, error) {
+	reader := csv.NewReader(stream)
+	reader.Comma = ';'
+	reader.ReuseRecord = true
+
+	lastLine := lineOffset
+	if withHeader {
+		if _, err := reader.Read(); err != nil {
+			return lineOffset, errors.Wrap(err, "reading header")
+		}
+		lastLine++
+	}
+
+	source := &csvTradeSource{reader: reader, lineOffset: lineOffset, cache: p.cache, rec: nil}
+
+	return p.parseSource(ctx, source, lastLine)
+}
+
+type csvTradeSource struct {
+	reader     *csv.Reader
+	lineOffset int64
+	cache      *fieldCache
+	rec        []string
+}
+
+func (s *csvTradeSource) Next() (int64, error) {
+	rec, err := s.reader.Read()
+	if errors.Is(err, io.EOF) {
+		return 0, io.EOF
+	}
+	s.rec = rec
+
+	line := s.lineOffset + recordLine(s.reader, err)
+	if err != nil {
+		return line, newRejectError(ReasonCSVRead, err)
+	}
+
+	return line, nil
+}
+
+func (s *csvTradeSource) Decode(batch *Batch, trade *entity.Trade) error {
+	return s.parseTrade(s.rec, batch, trade)
+}
+
+func (s *csvTradeSource) Raw() []string {
+	return s.rec
+}
+
+func (p *tradeParser) currentBatch(ctx context.Context) (*Batch, error) {
+	if p.batch == nil {
+		start := time.Now()
+		batch, err := p.opts.Pool.Acquire(ctx)
+		p.observeWait(WaitBudget, start)
+		if err != nil {
+			return nil, err
+		}
+		p.batch = batch
+		p.batch.Source = p.filePath
+		p.batch.Segment = p.segment
+		p.batch.Seq = p.seq
+		p.seq++
+	}
+
+	return p.batch, nil
+}
+
+func (p *tradeParser) flush(ctx context.Context) error {
+	batch := p.batch
+	p.batch = nil
+	if batch == nil {
+		return nil
+	}
+
+	if p.opts.Observer != nil {
+		p.opts.Observer.ObserveParsed(batch.Len())
+	}
+
+	start := time.Now()
+	defer p.observeWait(WaitConsumer, start)
+
+	select {
+	case p.out <- batch:
+		return nil
+	case <-ctx.Done():
+		p.opts.Pool.Put(batch)
+
+		return errors.Wrap(ctx.Err(), "context cancelled")
+	}
+}
+
+func (p *tradeParser) observeWait(cause WaitCause, start time.Time) {
+	if p.opts.Observer != nil {
+		p.opts.Observer.ObserveWait(cause, time.Since(start))
+	}
+}
+
+func (p *tradeParser) finish(ctx context.Context, err error) (ParseStats, error) {
+	if err != nil {
+		if p.batch != nil {
+			p.opts.Pool.Put(p.batch)
+			p.batch = nil
+		}
+
+		return p.stats, err
+	}
+
+	if p.batch != nil && p.batch.Len() == 0 {
+		p.opts.Pool.Put(p.batch)
+		p.batch = nil
+	}
+
+	return p.stats, p.flush(ctx)
+}
+
+func writeReject(
+	rejects *RejectWriter,
+	filePath string,
+	line int64,
+	rec []string,
+	reason RejectReason,
+	cause error,
+	logger *zap.Logger,
+) {
+	reject := Reject{
+		File:   filePath,
+		Line:   line,
+		Raw:    strings.Join(rec, ";"),
+		Reason: reason,
+		Error:  cause.Error(),
+	}
+	if err := rejects.Write(reject); err != nil {
+		logger.Error("Error writing reject", zap.String("file", filePath), zap.Int64("line", line), zap.Error(err))
+	}
+}
+
+func recordLine(reader *csv.Reader, err error) int64 {
+	if err != nil {
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			return int64(parseErr.StartLine)
+		}
+	}
+
+	line, _ := reader.FieldPos(0)
+
+	return int64(line)
+}
+
+func (s *csvTradeSource) parseTrade(rows []string, batch *Batch, trade *entity.Trade) error {
+	if len(rows) < minRecordLength {
+		return newRejectError(ReasonInvalidRecordLength, errors.New("invalid record length"))
+	}
+
+	referenceDate, err := s.cache.date(rows[referenceDateColumnIndex])
+	if err != nil {
+		return newRejectError(ReasonInvalidReferenceDate, errors.Wrap(err, "parsing reference date"))
+	}
+	updateAction, err := strconv.ParseInt(rows[updateActionColumnIndex], 10, 16)
+	if err != nil {
+		return newRejectError(ReasonInvalidUpdateAction, errors.Wrap(err, "parsing update action"))
+	}
+	price, err := parsePrice(rows[priceColumnIndex])
+	if err != nil {
+		return newRejectError(ReasonInvalidPrice, errors.Wrap(err, "parsing price"))
+	}
+	qty, err := strconv.ParseInt(rows[quantityColumnIndex], 10, 32)
+	if err != nil {
+		return newRejectError(ReasonInvalidQuantity, errors.Wrap(err, "parsing quantity"))
+	}
+
+	tradeID, err := strconv.ParseInt(rows[tradeIDColumnIndex], 10, 64)
+	if err != nil {
+		return newRejectError(ReasonInvalidTradeID, errors.Wrap(err, "parsing trade id"))
+	}
+	sessionType, err := strconv.ParseInt(rows[sessionTypeColumnIndex], 10, 16)
+	if err != nil {
+		return newRejectError(ReasonInvalidSessionType, errors.Wrap(err, "parsing session type"))
+	}
+	date, err := s.cache.date(rows[dateColumnIndex])
+	if err != nil {
+		return newRejectError(ReasonInvalidDate, errors.Wrap(err, "parsing date"))
+	}
+	tradedAt, err := parseTradeTime(date, rows[hourColumnIndex])
+	if err != nil {
+		return newRejectError(ReasonInvalidHour, errors.Wrap(err, "parsing trade time"))
+	}
+	buyerCode, err := parseParticipantCode(rows[buyerCodeColumnIndex], batch)
+	if err != nil {
+		return newRejectError(ReasonInvalidBuyerCode, errors.Wrap(err, "parsing buyer code"))
+	}
+	sellerCode, err := parseParticipantCode(rows[sellerCodeColumnIndex], batch)
+	if err != nil {
+		return newRejectError(ReasonInvalidSellerCode, errors.Wrap(err, "parsing seller code"))
+	}
+
+	*trade = entity.Trade{
+		ReferenceDate: referenceDate,
+		Ticker:        s.cache.intern(rows[tickerColumnIndex]),
+		UpdateAction:  entity.UpdateAction(updateAction),
+		Price:         price,
+		Quantity:      int32(qty),
+		TradedAt:      tradedAt,
+		TradeID:       tradeID,
+		SessionType:   int16(sessionType),
+		Date:          date,
+		BuyerCode:     buyerCode,
+		SellerCode:    sellerCode,
+	}
+
+	return nil
+}
+
+func parsePrice(raw string) (decimal.Decimal, error) {
+	const (
+		maxDigits   = 18
+		decimalBase = 10
+	)
+
+	var coefficient int64
+	var exp int32
+	var digits int
+	negative := false
+	separator := false
+
+	for i := range len(raw) {
+		switch ch := raw[i]; {
+		case ch >= '0' && ch <= '9':
+			digits++
+			coefficient = coefficient*decimalBase + int64(ch-'0')
+			if separator {
+				exp--
+			}
+		case (ch == ',' || ch == '.') && !separator:
+			separator = true
+		case ch == '-' && i == 0:
+			negative = true
+		default:
+			digits = maxDigits + 1
+		}
+		if digits > maxDigits {
+			price, err := decimal.NewFromString(strings.ReplaceAll(raw, ",", "."))
+
+			return price, errors.Wrap(err, "decimal")
+		}
+	}
+	if digits == 0 {
+		return decimal.Zero, errors.Errorf("can't convert %q to decimal", raw)
+	}
+	if negative {
+		coefficient = -coefficient
+	}
+
+	return decimal.New(coefficient, exp), nil
+}
+
+func parseParticipantCode(raw string, batch *Batch) (*int32, error) {
+	if raw == "" {
+		return nil, nil //nolint:nilnil
+	}
+
+	code, err := strconv.ParseInt(raw, 10, 32)
+	if err != nil {
+		return nil, errors.Wrap(err, "parse int")
+	}
+
+	return batch.code(int32(code)), nil
+}
+
+func parseTradeTime(date time.Time, raw string) (time.Time, error) {
+	const (
+		maxHour   = 23
+		maxMinute = 59
+		maxSecond = 59
+	)
+
+	length := tradeTimeLength
+	if len(raw) <= shortTradeTimeLength {
+		length = shortTradeTimeLength
+	}
+	if raw == "" || len(raw) > length {
+		return time.Time{}, errors.Errorf("invalid trade time %q", raw)
+	}
+
+	var digits [tradeTimeLength]int
+	offset := length - len(raw)
+	for i := range len(raw) {
+		if raw[i] < '0' || raw[i] > '9' {
+			return time.Time{}, errors.Errorf("invalid trade time %q", raw)
+		}
+		digits[offset+i] = int(raw[i] - '0')
+	}
+
+	hour := digits[0]*10 + digits[1]
+	minute := digits[2]*10 + digits[3]
+	second := digits[4]*10 + digits[5]
+	millis := digits[6]*100 + digits[7]*10 + digits[8]
+	if hour > maxHour || minute > maxMinute || second > maxSecond {
+		return time.Time{}, errors.Errorf("invalid trade time %q", raw)
+	}
+
+	return time.Date(
+		date.Year(), date.Month(), date.Day(), hour, minute, second, millis*int(time.Millisecond), entity.B3Location,
+	), nil
+}
@@ -0,0 +1,388 @@
+package filehandler
+
+import (
+	"b3challenge/internal/domain/entity"
+	"bytes"
+	"context"
+	"encoding/json"
+	"github.com/AlekSi/pointer"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newTestRejectWriter(t testing.TB) *RejectWriter {
+	t.Helper()
+
+	rejects, err := NewRejectWriter(nil, RejectFormatJSONL)
+	assert.NoError(t, err)
+
+	return rejects
+}
+
+func newTestParseOptions(batchSize int, rejects *RejectWriter) ParseOptions {
+	return ParseOptions{
+		Pool:      NewBatchPool(batchSize),
+		Rejects:   rejects,
+		Dates:     DateRange{},
+		Format:    TradeFormatAuto,
+		Validator: nil,
+		Observer:  nil,
+	}
+}
+
+func drainBatches(out chan *Batch) ([]int64, []entity.Trade, []*Batch) {
+	close(out)
+
+	var lines []int64
+	var trades []entity.Trade
+	var batches []*Batch
+	for batch := range out {
+		lines = append(lines, batch.Lines...)
+		trades = append(trades, batch.Trades...)
+		batches = append(batches, batch)
+	}
+
+	return lines, trades, batches
+}
+
+func TestFindTXTFiles(t *testing.T) {
+	input := "testdata"
+	files, err := FindTXTFiles(input)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		"testdata/mock-csv.txt",
+		"testdata/mock-csv.txt.gz",
+		"testdata/mock-csv.zip",
+	}, files)
+}
+
+func TestFindInputFiles(t *testing.T) {
+	tests := []struct {
+		name    string
+		inputs  []string
+		include []string
+		exclude []string
+		want    []string
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name:    "directory and duplicated file",
+			inputs:  []string{"testdata", "testdata/mock-csv.txt"},
+			want:    []string{"testdata/mock-csv.txt", "testdata/mock-csv.txt.gz", "testdata/mock-csv.zip"},
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "include glob",
+			inputs:  []string{"testdata"},
+			include: []string{"*.zip", "*.gz"},
+			want:    []string{"testdata/mock-csv.txt.gz", "testdata/mock-csv.zip"},
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "exclude wins over include",
+			inputs:  []string{"testdata"},
+			include: []string{"mock-*"},
+			exclude: []string{"*.gz"},
+			want:    []string{"testdata/mock-csv.txt", "testdata/mock-csv.zip"},
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "explicit file in subdirectory",
+			inputs:  []string{"testdata/invalid/mock-invalid-csv.txt"},
+			want:    []string{"testdata/invalid/mock-invalid-csv.txt"},
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "missing input",
+			inputs:  []string{"testdata/missing"},
+			wantErr: assert.Error,
+		},
+		{
+			name:    "invalid pattern",
+			inputs:  []string{"testdata"},
+			include: []string{"["},
+			wantErr: assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := FindInputFiles(tt.inputs, tt.include, tt.exclude)
+			if !tt.wantErr(t, err) {
+				return
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestComputeChecksum(t *testing.T) {
+	checksum, size, err := ComputeChecksum("testdata/mock-csv.txt")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(338), size)
+	assert.Len(t, checksum, 64)
+
+	_, _, err = ComputeChecksum("testdata/missing.txt")
+	assert.Error(t, err)
+}
+
+func TestParseFileToTrades(t *testing.T) {
+	expectedTrades := []entity.Trade{
+		{
+			ReferenceDate: time.Date(2025, 6, 2, 0, 0, 0, 0, time.UTC),
+			Ticker:        "TF583R",
+			UpdateAction:  0,
+			Price:         decimal.New(10000, -3),
+			Quantity:      10000,
+			TradedAt:      time.Date(2025, 6, 2, 3, 5, 7, 886*int(time.Millisecond), entity.B3Location),
+			TradeID:       10,
+			SessionType:   1,
+			Date:          time.Date(2025, 6, 2, 0, 0, 0, 0, time.UTC),
+			BuyerCode:     pointer.ToInt32(100),
+			SellerCode:    pointer.ToInt32(100),
+		},
+		{
+			ReferenceDate: time.Date(2025, 6, 2, 0, 0, 0, 0, time.UTC),
+			Ticker:        "FRCQ25",
+			UpdateAction:  0,
+			Price:         decimal.New(5740, -3),
+			Quantity:      870,
+			TradedAt:      time.Date(2025, 6, 2, 9, 0, 0, 644*int(time.Millisecond), entity.B3Location),
+			TradeID:       10,
+			SessionType:   1,
+			Date:          time.Date(2025, 6, 2, 0, 0, 0, 0, time.UTC),
+			BuyerCode:     pointer.ToInt32(127),
+			SellerCode:    pointer.ToInt32(127),
+		},
+	}
+
+	out := make(chan *Batch, 2)
+	stats, gotErr := ParseFileToTrades(
+		context.Background(), "testdata/mock-csv.txt", 0, out, newTestParseOptions(10, newTestRejectWriter(t)), zap.L(),
+	)
+	assert.NoError(t, gotErr)
+	assert.Equal(t, ParseStats{Parsed: 2, Rejected: 0}, stats)
+
+	lines, trades, batches := drainBatches(out)
+	assert.Equal(t, []int64{2, 3}, lines)
+	assert.Equal(t, expectedTrades, trades)
+	assert.Len(t, batches, 1)
+	assert.Equal(t, "testdata/mock-csv.txt", batches[0].Source)
+	assert.Equal(t, int64(3), batches[0].LastLine)
+}
+
+func TestParseFileToTrades_MissingParticipant(t *testing.T) {
+	path := "testdata/participants/mock-missing-participant.txt"
+	out := make(chan *Batch, 2)
+	opts := newTestParseOptions(10, newTestRejectWriter(t))
+	stats, err := ParseFileToTrades(context.Background(), path, 0, out, opts, zap.L())
+	assert.NoError(t, err)
+	assert.Equal(t, ParseStats{Parsed: 2, Rejected: 0}, stats)
+
+	_, trades, _ := drainBatches(out)
+	assert.Len(t, trades, 2)
+	assert.Equal(t, pointer.ToInt32(127), trades[0].BuyerCode)
+	assert.Nil(t, trades[0].SellerCode)
+	assert.Nil(t, trades[1].BuyerCode)
+	assert.Equal(t, pointer.ToInt32(72), trades[1].SellerCode)
+}
+
+func TestParseFileToTrades_BatchSize(t *testing.T) {
+	out := make(chan *Batch, 2)
+	stats, err := ParseFileToTrades(
+		context.Background(), "testdata/mock-csv.txt", 0, out, newTestParseOptions(1, newTestRejectWriter(t)), zap.L(),
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, ParseStats{Parsed: 2, Rejected: 0}, stats)
+
+	_, _, batches := drainBatches(out)
+	assert.Len(t, batches, 2)
+	for i, batch := range batches {
+		assert.Equal(t, i, batch.Seq)
+		assert.Equal(t, int64(i+2), batch.LastLine)
+		assert.Equal(t, 1, batch.Len())
+	}
+}
+
+func TestParseFileToTrades_FromLine(t *testing.T) {
+	out := make(chan *Batch, 2)
+	stats, err := ParseFileToTrades(
+		context.Background(), "testdata/mock-csv.txt", 2, out, newTestParseOptions(10, newTestRejectWriter(t)), zap.L(),
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, ParseStats{Parsed: 1, Rejected: 0}, stats)
+
+	lines, trades, _ := drainBatches(out)
+	assert.Equal(t, []int64{3}, lines)
+	assert.Equal(t, "FRCQ25", trades[0].Ticker)
+}
+
+func TestParseFileToTrades_DateRange(t *testing.T) {
+	day := time.Date(2025, 6, 2, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name  string
+		dates DateRange
+		want  ParseStats
+	}{
+		{name: "unbounded", dates: DateRange{}, want: ParseStats{Parsed: 2, Rejected: 0, Filtered: 0}},
+		{name: "same day", dates: DateRange{From: day, To: day}, want: ParseStats{Parsed: 2, Rejected: 0, Filtered: 0}},
+		{name: "later days", dates: DateRange{From: day.AddDate(0, 0, 1)}, want: ParseStats{Parsed: 0, Rejected: 0, Filtered: 2}},
+		{name: "earlier days", dates: DateRange{To: day.AddDate(0, 0, -1)}, want: ParseStats{Parsed: 0, Rejected: 0, Filtered: 2}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := newTestParseOptions(10, newTestRejectWriter(t))
+			opts.Dates = tt.dates
+
+			out := make(chan *Batch, 2)
+			stats, err := ParseFileToTrades(context.Background(), "testdata/mock-csv.txt", 0, out, opts, zap.L())
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, stats)
+
+			_, trades, _ := drainBatches(out)
+			assert.Len(t, trades, int(tt.want.Parsed))
+		})
+	}
+}
+
+func TestParseFileToTrades_Archives(t *testing.T) {
+	tests := []struct {
+		name string
+		file string
+	}{
+		{name: "zip archive", file: "testdata/mock-csv.zip"},
+		{name: "gzip stream", file: "testdata/mock-csv.txt.gz"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := make(chan *Batch, 2)
+			stats, err := ParseFileToTrades(
+				context.Background(), tt.file, 0, out, newTestParseOptions(10, newTestRejectWriter(t)), zap.L(),
+			)
+			assert.NoError(t, err)
+			assert.Equal(t, ParseStats{Parsed: 2, Rejected: 0}, stats)
+
+			_, trades, batches := drainBatches(out)
+			var tickers []string
+			for _, trade := range trades {
+				tickers = append(tickers, trade.Ticker)
+			}
+			assert.Equal(t, []string{"TF583R", "FRCQ25"}, tickers)
+			assert.Equal(t, tt.file, batches[0].Source)
+		})
+	}
+}
+
+func TestParseFileToTrades_Rejects(t *testing.T) {
+	const file = "testdata/invalid/mock-invalid-csv.txt"
+
+	var buf bytes.Buffer
+	rejects, err := NewRejectWriter(&buf, RejectFormatJSONL)
+	assert.NoError(t, err)
+
+	out := make(chan *Batch, 4)
+	stats, err := ParseFileToTrades(
+		context.Background(), file, 0, out, newTestParseOptions(10, rejects), zap.L(),
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, ParseStats{Parsed: 1, Rejected: 3}, stats)
+	assert.Equal(t, map[RejectReason]int64{
+		ReasonInvalidPrice: 1,
+		ReasonCSVRead:      1,
+		ReasonInvalidDate:  1,
+	}, rejects.Counts())
+
+	var got []Reject
+	decoder := json.NewDecoder(&buf)
+	for decoder.More() {
+		var reject Reject
+		assert.NoError(t, decoder.Decode(&reject))
+		got = append(got, reject)
+	}
+
+	assert.Len(t, got, 3)
+	assert.Equal(t, int64(3), got[0].Line)
+	assert.Equal(t, file, got[0].File)
+	assert.Equal(t, ReasonInvalidPrice, got[0].Reason)
+	assert.Equal(t, "2025-06-02;TF583R;0;abc;10000;030507886;11;1;2025-06-02;100;100", got[0].Raw)
+	assert.Equal(t, int64(4), got[1].Line)
+	assert.Equal(t, ReasonCSVRead, got[1].Reason)
+	assert.Equal(t, "2025-06-02;TF583R;0;10,000", got[1].Raw)
+	assert.Equal(t, int64(5), got[2].Line)
+	assert.Equal(t, ReasonInvalidDate, got[2].Reason)
+}
+
+func TestParsePrice(t *testing.T) {
+	tests := []struct {
+		raw     string
+		want    decimal.Decimal
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{raw: "10,000", want: decimal.New(10000, -3), wantErr: assert.NoError},
+		{raw: "5.74", want: decimal.New(574, -2), wantErr: assert.NoError},
+		{raw: "12", want: decimal.New(12, 0), wantErr: assert.NoError},
+		{raw: "-0,5", want: decimal.New(-5, -1), wantErr: assert.NoError},
+		{raw: "1234567890123456789,5", want: decimal.RequireFromString("1234567890123456789.5"), wantErr: assert.NoError},
+		{raw: "abc", want: decimal.Decimal{}, wantErr: assert.Error},
+		{raw: "1,2,3", want: decimal.Decimal{}, wantErr: assert.Error},
+		{raw: "", want: decimal.Decimal{}, wantErr: assert.Error},
+	}
+	for _, tt := range tests {
+		t.Run(tt.raw, func(t *testing.T) {
+			got, err := parsePrice(tt.raw)
+			if !tt.wantErr(t, err) || err != nil {
+				return
+			}
+			assert.True(t, tt.want.Equal(got), "want %s, got %s", tt.want, got)
+		})
+	}
+}
+
+func TestParseTradeTime(t *testing.T) {
+	date := time.Date(2025, 6, 2, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		raw     string
+		want    time.Time
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{raw: "100507886", want: time.Date(2025, 6, 2, 10, 5, 7, 886000000, entity.B3Location), wantErr: assert.NoError},
+		{raw: "90000644", want: time.Date(2025, 6, 2, 9, 0, 0, 644000000, entity.B3Location), wantErr: assert.NoError},
+		{raw: "173000", want: time.Date(2025, 6, 2, 17, 30, 0, 0, entity.B3Location), wantErr: assert.NoError},
+		{raw: "", wantErr: assert.Error},
+		{raw: "1005078861", wantErr: assert.Error},
+		{raw: "10a507886", wantErr: assert.Error},
+		{raw: "246000000", wantErr: assert.Error},
+		{raw: "106000000", wantErr: assert.Error},
+	}
+	for _, tt := range tests {
+		t.Run(tt.raw, func(t *testing.T) {
+			got, err := parseTradeTime(date, tt.raw)
+			if !tt.wantErr(t, err) || err != nil {
+				return
+			}
+			assert.True(t, tt.want.Equal(got), "want %s, got %s", tt.want, got)
+			assert.Equal(t, entity.B3TimeZone, got.Location().String())
+		})
+	}
+}
+
+func BenchmarkParsePrice(b *testing.B) {
+	b.Run("parsePrice", func(b *testing.B) {
+		b.ReportAllocs()
+		for range b.N {
+			_, _ = parsePrice("35,870")
+		}
+	})
+	b.Run("decimal.NewFromString", func(b *testing.B) {
+		b.ReportAllocs()
+		for range b.N {
+			_, _ = decimal.NewFromString(strings.ReplaceAll("35,870", ",", "."))
+		}
+	})
+}
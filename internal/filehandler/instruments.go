@@ -0,0 +1,134 @@
+package filehandler
+
+import (
+	"b3challenge/internal/domain/entity"
+	"context"
+	"io"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+const (
+	instrumentsName               = "INSTRUMENTS"
+	instrumentReferenceDateColumn = "RptDt"
+	instrumentTickerColumn        = "TckrSymb"
+	instrumentAssetColumn         = "Asst"
+	instrumentSegmentColumn       = "SgmtNm"
+	instrumentMarketColumn        = "MktNm"
+	instrumentSecurityTypeColumn  = "SctyCtgyNm"
+	instrumentTradingStartColumn  = "TradgStartDt"
+	instrumentTradingEndColumn    = "TradgEndDt"
+	instrumentISINColumn          = "ISIN"
+	instrumentLotSizeColumn       = "AllcnRndLot"
+	instrumentCurrencyColumn      = "TradgCcy"
+	instrumentSpecificationColumn = "SpcfctnCd"
+	instrumentIssuerNameColumn    = "CrpnNm"
+)
+
+func IsInstrumentsFile(name string) bool {
+	return strings.Contains(strings.ToUpper(filepath.Base(name)), instrumentsName)
+}
+
+func ParseInstrumentsFile(
+	ctx context.Context,
+	filePath string,
+	fromLine int64,
+	out chan<- *Batch,
+	opts ParseOptions,
+	logger *zap.Logger,
+) (ParseStats, error) {
+	parser := &instrumentParser{tradeParser: newTradeParser(filePath, 0, fromLine, out, opts, logger)}
+	var lineOffset int64
+
+	err := forEachTradeStream(filePath, func(stream io.Reader) error {
+		lastLine, err := parser.parse(ctx, stream, lineOffset)
+		lineOffset = lastLine
+
+		return err
+	})
+
+	return parser.finish(ctx, err)
+}
+
+type instrumentParser struct {
+	*tradeParser
+}
+
+func (p *instrumentParser) parse(ctx context.Context, stream io.Reader, lineOffset int64) (int64, error) {
+	required := []string{instrumentReferenceDateColumn, instrumentTickerColumn}
+
+	return p.parseHeaderCSV(ctx, stream, lineOffset, required, p.parseRecord)
+}
+
+func (p *instrumentParser) parseRecord(batch *Batch, columns csvColumns, rec []string, line int64) (time.Time, error) {
+	instrument := batch.nextInstrument(line)
+	if err := p.parseInstrument(columns, rec, instrument); err != nil {
+		return time.Time{}, err
+	}
+
+	return instrument.ReferenceDate, nil
+}
+
+func (p *instrumentParser) parseInstrument(columns csvColumns, rec []string, instrument *entity.Instrument) error {
+	referenceDate, err := p.cache.date(columns.get(rec, instrumentReferenceDateColumn))
+	if err != nil {
+		return newRejectError(ReasonInvalidReferenceDate, errors.Wrap(err, "parsing reference date"))
+	}
+	ticker := columns.get(rec, instrumentTickerColumn)
+	if ticker == "" {
+		return newRejectError(ReasonInvalidTicker, errors.New("missing ticker"))
+	}
+
+	var lotSize int64
+	if raw := columns.get(rec, instrumentLotSizeColumn); raw != "" {
+		lot, err := parsePrice(raw)
+		if err != nil {
+			return newRejectError(ReasonInvalidQuantity, errors.Wrap(err, "parsing lot size"))
+		}
+		lotSize = lot.IntPart()
+	}
+
+	tradingStart, err := p.optionalDate(columns.get(rec, instrumentTradingStartColumn))
+	if err != nil {
+		return newRejectError(ReasonInvalidDate, errors.Wrap(err, "parsing trading start date"))
+	}
+	tradingEnd, err := p.optionalDate(columns.get(rec, instrumentTradingEndColumn))
+	if err != nil {
+		return newRejectError(ReasonInvalidDate, errors.Wrap(err, "parsing trading end date"))
+	}
+
+	*instrument = entity.Instrument{
+		ReferenceDate:    referenceDate,
+		Ticker:           strings.Clone(ticker),
+		ISIN:             p.cache.intern(columns.get(rec, instrumentISINColumn)),
+		Asset:            p.cache.intern(columns.get(rec, instrumentAssetColumn)),
+		IssuerName:       p.cache.intern(decodeLatin1(columns.get(rec, instrumentIssuerNameColumn))),
+		SecurityType:     p.cache.intern(decodeLatin1(columns.get(rec, instrumentSecurityTypeColumn))),
+		Segment:          p.cache.intern(decodeLatin1(columns.get(rec, instrumentSegmentColumn))),
+		Market:           p.cache.intern(decodeLatin1(columns.get(rec, instrumentMarketColumn))),
+		Specification:    p.cache.intern(columns.get(rec, instrumentSpecificationColumn)),
+		Currency:         p.cache.intern(columns.get(rec, instrumentCurrencyColumn)),
+		LotSize:          lotSize,
+		TradingStartDate: tradingStart,
+		TradingEndDate:   tradingEnd,
+	}
+
+	return nil
+}
+
+func (p *instrumentParser) optionalDate(raw string) (*time.Time, error) {
+	if raw == "" {
+		return nil, nil //nolint:nilnil
+	}
+
+	date, err := p.cache.date(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	return &date, nil
+}
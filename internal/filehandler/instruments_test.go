@@ -0,0 +1,95 @@
+package filehandler
+
+import (
+	"b3challenge/internal/domain/entity"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+const instrumentsFile = "testdata/instruments/InstrumentsConsolidatedFile_20250602.csv"
+
+func drainInstruments(out chan *Batch) ([]int64, []entity.Instrument) {
+	close(out)
+
+	var lines []int64
+	var instruments []entity.Instrument
+	for batch := range out {
+		lines = append(lines, batch.Lines...)
+		instruments = append(instruments, batch.Instruments...)
+	}
+
+	return lines, instruments
+}
+
+func TestIsInstrumentsFile(t *testing.T) {
+	assert.True(t, IsInstrumentsFile("b3Data/InstrumentsConsolidatedFile_20250602.csv"))
+	assert.True(t, IsSupportedFile("b3Data/InstrumentsConsolidatedFile_20250602.csv"))
+	assert.False(t, IsSupportedFile("b3Data/rejects.csv"))
+	assert.False(t, IsInstrumentsFile("b3Data/02-06-2025_NEGOCIOSAVISTA.txt"))
+}
+
+func TestParseInstrumentsFile(t *testing.T) {
+	date := func(year int, month time.Month, day int) *time.Time {
+		value := time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+		return &value
+	}
+
+	out := make(chan *Batch, 10)
+	rejects := newTestRejectWriter(t)
+	stats, err := ParseSegment(
+		context.Background(), instrumentsFile, WholeFile(), 0, out, newTestParseOptions(10, rejects), zap.NewNop(),
+	)
+	require.NoError(t, err)
+
+	lines, instruments := drainInstruments(out)
+	assert.Equal(t, ParseStats{Parsed: 2, Rejected: 2, Filtered: 0}, stats)
+	assert.Equal(t, []int64{2, 3}, lines)
+	assert.Equal(t, map[RejectReason]int64{ReasonInvalidDate: 1, ReasonInvalidTicker: 1}, rejects.Counts())
+	assert.Equal(t, []entity.Instrument{
+		{
+			ReferenceDate:    *date(2025, 6, 2),
+			Ticker:           "PETR4",
+			ISIN:             "BRPETRACNPR6",
+			Asset:            "PETR",
+			IssuerName:       "PETROLEO BRASILEIRO S.A. PETROBRAS",
+			SecurityType:     "SHARES",
+			Segment:          "CASH",
+			Market:           "EQUITY-CASH",
+			Specification:    "PN      N2",
+			Currency:         "BRL",
+			LotSize:          100,
+			TradingStartDate: date(2000, 1, 3),
+			TradingEndDate:   date(9999, 12, 31),
+		},
+		{
+			ReferenceDate:    *date(2025, 6, 2),
+			Ticker:           "VALE3",
+			ISIN:             "BRVALEACNOR0",
+			Asset:            "VALE",
+			IssuerName:       "VALE S.A.",
+			SecurityType:     "SHARES",
+			Segment:          "CASH",
+			Market:           "EQUITY-CASH",
+			Specification:    "ON      NM",
+			Currency:         "BRL",
+			LotSize:          100,
+			TradingStartDate: date(1994, 1, 3),
+			TradingEndDate:   nil,
+		},
+	}, instruments)
+}
+
+func TestParseInstrumentsFile_MissingColumn(t *testing.T) {
+	parser := &instrumentParser{
+		tradeParser: newTradeParser(instrumentsFile, 0, 0, nil, newTestParseOptions(10, nil), zap.NewNop()),
+	}
+
+	_, err := parser.parse(context.Background(), strings.NewReader("RptDt;Asst\n2025-06-02;PETR\n"), 0)
+	assert.ErrorIs(t, err, errMissingColumn)
+}
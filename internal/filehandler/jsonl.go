@@ -0,0 +1,175 @@
+package filehandler
+
+import (
+	"b3challenge/internal/domain/entity"
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"strings"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+const (
+	jsonlBufferSize    = 64 * 1024
+	jsonlMaxRecordSize = 1024 * 1024
+)
+
+type jsonTrade struct {
+	ReferenceDate string      `json:"reference_date"`
+	Ticker        string      `json:"ticker"`
+	UpdateAction  *int16      `json:"update_action"`
+	Price         json.Number `json:"price"`
+	Quantity      *int32      `json:"quantity"`
+	TradedAt      string      `json:"traded_at"`
+	TradeID       *int64      `json:"trade_id"`
+	SessionType   *int16      `json:"session_type"`
+	Date          string      `json:"date"`
+	BuyerCode     *int32      `json:"buyer_code"`
+	SellerCode    *int32      `json:"seller_code"`
+}
+
+func ParseJSONLFile(
+	ctx context.Context,
+	filePath string,
+	fromLine int64,
+	out chan<- *Batch,
+	opts ParseOptions,
+	logger *zap.Logger,
+) (ParseStats, error) {
+	parser := newTradeParser(filePath, 0, fromLine, out, opts, logger)
+	var lineOffset int64
+
+	err := forEachTradeStream(filePath, func(stream io.Reader) error {
+		lastLine, err := parser.parseSource(ctx, NewJSONLTradeSource(stream, lineOffset), lineOffset)
+		lineOffset = lastLine
+
+		return err
+	})
+
+	return parser.finish(ctx, err)
+}
+
+type jsonlTradeSource struct {
+	scanner *bufio.Scanner
+	line    int64
+	cache   *fieldCache
+}
+
+func NewJSONLTradeSource(stream io.Reader, lineOffset int64) TradeSource {
+	scanner := bufio.NewScanner(stream)
+	scanner.Buffer(make([]byte, 0, jsonlBufferSize), jsonlMaxRecordSize)
+
+	return &jsonlTradeSource{scanner: scanner, line: lineOffset, cache: newFieldCache()}
+}
+
+func (s *jsonlTradeSource) Next() (int64, error) {
+	for s.scanner.Scan() {
+		s.line++
+		if len(bytes.TrimSpace(s.scanner.Bytes())) > 0 {
+			return s.line, nil
+		}
+	}
+	if err := s.scanner.Err(); err != nil {
+		return s.line, errors.Wrap(err, "reading json lines")
+	}
+
+	return s.line, io.EOF
+}
+
+func (s *jsonlTradeSource) Decode(batch *Batch, trade *entity.Trade) error {
+	var record jsonTrade
+	if err := json.Unmarshal(s.scanner.Bytes(), &record); err != nil {
+		var typeErr *json.UnmarshalTypeError
+		if errors.As(err, &typeErr) {
+			if reason, ok := sourceFieldReasons[typeErr.Field]; ok {
+				return newRejectError(reason, errors.Wrap(err, "decoding record"))
+			}
+		}
+
+		return newRejectError(ReasonInvalidJSON, errors.Wrap(err, "decoding record"))
+	}
+
+	return s.toTrade(&record, batch, trade)
+}
+
+func (s *jsonlTradeSource) Raw() []string {
+	return []string{s.scanner.Text()}
+}
+
+func (s *jsonlTradeSource) toTrade(record *jsonTrade, batch *Batch, trade *entity.Trade) error {
+	referenceDate, err := s.cache.date(record.ReferenceDate)
+	if err != nil {
+		return newFieldError(sourceReferenceDateField, err)
+	}
+	if strings.TrimSpace(record.Ticker) == "" {
+		return newFieldError(sourceTickerField, errors.New("missing ticker"))
+	}
+	price, err := parsePrice(record.Price.String())
+	if err != nil {
+		return newFieldError(sourcePriceField, err)
+	}
+	date, err := s.cache.date(record.Date)
+	if err != nil {
+		return newFieldError(sourceDateField, err)
+	}
+	tradedAt, err := parseSourceTime(record.TradedAt)
+	if err != nil {
+		return newFieldError(sourceTradedAtField, err)
+	}
+	updateAction, err := required(sourceUpdateActionField, record.UpdateAction)
+	if err != nil {
+		return err
+	}
+	quantity, err := required(sourceQuantityField, record.Quantity)
+	if err != nil {
+		return err
+	}
+	tradeID, err := required(sourceTradeIDField, record.TradeID)
+	if err != nil {
+		return err
+	}
+	sessionType, err := required(sourceSessionTypeField, record.SessionType)
+	if err != nil {
+		return err
+	}
+
+	*trade = entity.Trade{
+		ReferenceDate: referenceDate,
+		Ticker:        s.cache.intern(strings.TrimSpace(record.Ticker)),
+		UpdateAction:  entity.UpdateAction(updateAction),
+		Price:         price,
+		Quantity:      quantity,
+		TradedAt:      tradedAt,
+		TradeID:       tradeID,
+		SessionType:   sessionType,
+		Date:          date,
+		BuyerCode:     optionalCode(record.BuyerCode, batch),
+		SellerCode:    optionalCode(record.SellerCode, batch),
+	}
+
+	return nil
+}
+
+// required rejects a record whose numeric field is absent or null; decoding it as zero would
+// collapse every such trade onto the same (ticker, date, trade_id) key.
+func required[T any](field string, value *T) (T, error) {
+	if value == nil {
+		var zero T
+
+		return zero, newFieldError(field, errors.New("missing value"))
+	}
+
+	return *value, nil
+}
+
+func optionalCode(code *int32, batch *Batch) *int32 {
+	if code == nil {
+		return nil
+	}
+
+	return batch.code(*code)
+}
@@ -0,0 +1,51 @@
+package filehandler
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestJSONLTradeSource_RequiredFields(t *testing.T) {
+	const record = `{"reference_date":"2025-06-02","ticker":"PETR4","update_action":0,"price":32.150,` +
+		`"quantity":100,"traded_at":"2025-06-02T10:00:01.123-03:00","trade_id":10,"session_type":1,` +
+		`"date":"2025-06-02","buyer_code":3,"seller_code":8}`
+
+	tests := []struct {
+		name       string
+		field      string
+		with       string
+		wantReason RejectReason
+	}{
+		{name: "missing trade_id", field: `"trade_id":10,`, with: "", wantReason: ReasonInvalidTradeID},
+		{name: "missing quantity", field: `"quantity":100,`, with: "", wantReason: ReasonInvalidQuantity},
+		{name: "missing update_action", field: `"update_action":0,`, with: "", wantReason: ReasonInvalidUpdateAction},
+		{name: "missing session_type", field: `"session_type":1,`, with: "", wantReason: ReasonInvalidSessionType},
+		{name: "null trade_id", field: `"trade_id":10`, with: `"trade_id":null`, wantReason: ReasonInvalidTradeID},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Contains(t, record, tt.field)
+			stream := strings.NewReader(record + "\n" + strings.Replace(record, tt.field, tt.with, 1))
+
+			rejects := newTestRejectWriter(t)
+			out := make(chan *Batch, 1)
+			stats, err := ParseTradeSource(
+				context.Background(), "stdin", NewJSONLTradeSource(stream, 0), 0, out,
+				newTestParseOptions(10, rejects), zap.NewNop(),
+			)
+			require.NoError(t, err)
+
+			lines, trades, _ := drainBatches(out)
+			assert.Equal(t, ParseStats{Parsed: 1, Rejected: 1, Filtered: 0}, stats)
+			assert.Equal(t, []int64{1}, lines)
+			assert.Equal(t, sourceTrades()[:1], trades)
+			assert.Equal(t, map[RejectReason]int64{tt.wantReason: 1}, rejects.Counts())
+		})
+	}
+}
@@ -0,0 +1,213 @@
+package filehandler
+
+import (
+	"b3challenge/internal/domain/entity"
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"sync/atomic"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+const loaderRejectsDir = "rejects"
+
+// Loader parses uploaded files for ingestion jobs. Trades are held in the staging area of the job's
+// ingestion; promoting them to the trades table is left to the caller once the whole file loaded.
+// Batches are written through a RetryingWriter like the CLI does, and rejected rows go to
+// <dir>/rejects/ingestion_<id>.jsonl.
+type Loader struct {
+	dir       string
+	write     BatchWriter
+	stage     TradeStager
+	retry     RetryPolicy
+	validator *Validator
+	pool      *BatchPool
+	workers   int
+	logger    *zap.Logger
+}
+
+func NewLoader(
+	dir string,
+	write BatchWriter,
+	stage TradeStager,
+	retry RetryPolicy,
+	validator *Validator,
+	batchSize, workers int,
+	logger *zap.Logger,
+) *Loader {
+	return &Loader{
+		dir:       dir,
+		write:     write,
+		stage:     stage,
+		retry:     retry,
+		validator: validator,
+		pool:      NewBatchPool(batchSize),
+		workers:   max(workers, 1),
+		logger:    logger,
+	}
+}
+
+func (l *Loader) Supports(name string) bool {
+	return IsSupportedFile(filepath.Base(name))
+}
+
+func (l *Loader) Store(name string, body io.Reader) (string, int64, string, error) {
+	const dirPerm = 0o755
+	if err := os.MkdirAll(l.dir, dirPerm); err != nil {
+		return "", 0, "", errors.Wrap(err, "create upload dir")
+	}
+
+	file, err := os.CreateTemp(l.dir, "*_"+filepath.Base(name))
+	if err != nil {
+		return "", 0, "", errors.Wrap(err, "create upload file")
+	}
+
+	hash := sha256.New()
+	size, err := io.Copy(io.MultiWriter(file, hash), body)
+	if err == nil {
+		err = file.Close()
+	} else {
+		file.Close()
+	}
+	if err != nil {
+		os.Remove(file.Name())
+
+		return "", 0, "", errors.Wrap(err, "write upload")
+	}
+
+	return file.Name(), size, hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+func (l *Loader) Load(ctx context.Context, ingestionID int32, path string, progress *entity.IngestionProgress) error {
+	writer := l.write
+	writer.StagedTrades = func(ctx context.Context, _ string, trades []entity.Trade, lines []int64) (int, int, error) {
+		return l.stage(ctx, ingestionID, trades, lines)
+	}
+
+	rejects, closeRejects, err := l.openRejects(ingestionID)
+	if err != nil {
+		return err
+	}
+	defer closeRejects()
+	write := NewRetryingWriter(writer, l.retry, rejects, l.logger)
+
+	out := make(chan *Batch, l.workers)
+	var failed atomic.Int64
+	var wg sync.WaitGroup
+	for range l.workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for batch := range out {
+				progress.AddParsed(int64(batch.Len()))
+				if ctx.Err() == nil {
+					l.writeBatch(ctx, write, path, batch, progress, &failed)
+				}
+				l.pool.Put(batch)
+			}
+		}()
+	}
+
+	opts := ParseOptions{
+		Pool:      l.pool,
+		Rejects:   rejects,
+		Dates:     DateRange{},
+		Format:    TradeFormatAuto,
+		Validator: l.validator,
+		Observer:  nil,
+	}
+	stats, err := ParseSegment(ctx, path, WholeFile(), 0, out, opts, l.logger)
+	close(out)
+	wg.Wait()
+	progress.AddRejected(stats.Rejected)
+
+	switch {
+	case err != nil:
+		return errors.Wrap(err, "parse")
+	case ctx.Err() != nil:
+		return errors.Wrap(ctx.Err(), "load interrupted")
+	case failed.Load() > 0:
+		return errors.Errorf("%d batches failed", failed.Load())
+	default:
+		return nil
+	}
+}
+
+// Remove deletes an uploaded file once its job finished. Uploads are never resumed, so the file is
+// not needed after a failure either.
+func (l *Loader) Remove(path string) error {
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		l.logger.Error("Error removing uploaded file", zap.String("file", path), zap.Error(err))
+
+		return errors.Wrap(err, "remove upload")
+	}
+
+	return nil
+}
+
+// openRejects creates the rejects file of an ingestion. The returned func flushes and closes it,
+// removing the file when no row was rejected.
+func (l *Loader) openRejects(ingestionID int32) (*RejectWriter, func(), error) {
+	const dirPerm = 0o755
+	dir := filepath.Join(l.dir, loaderRejectsDir)
+	if err := os.MkdirAll(dir, dirPerm); err != nil {
+		return nil, nil, errors.Wrap(err, "create rejects dir")
+	}
+
+	path := filepath.Join(dir, "ingestion_"+strconv.Itoa(int(ingestionID))+".jsonl")
+	file, err := os.Create(path)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "create rejects file")
+	}
+
+	rejects, err := NewRejectWriter(bufio.NewWriter(file), RejectFormatJSONL)
+	if err != nil {
+		file.Close()
+
+		return nil, nil, errors.Wrap(err, "rejects writer")
+	}
+
+	return rejects, func() {
+		if err := rejects.Flush(); err != nil {
+			l.logger.Error("Error flushing rejects", zap.String("file", path), zap.Error(err))
+		}
+		file.Close()
+
+		if len(rejects.Counts()) == 0 {
+			os.Remove(path)
+
+			return
+		}
+		l.logger.Info("Rejected rows written", zap.String("file", path), zap.Any("reasons", rejects.Counts()))
+	}, nil
+}
+
+func (l *Loader) writeBatch(
+	ctx context.Context,
+	write *RetryingWriter,
+	path string,
+	batch *Batch,
+	progress *entity.IngestionProgress,
+	failed *atomic.Int64,
+) {
+	result, err := write.Write(ctx, batch)
+	progress.AddRejected(int64(result.Rejected))
+	if err != nil {
+		failed.Add(1)
+		l.logger.Error("Error writing batch", zap.String("file", path), zap.Error(err))
+
+		return
+	}
+	// Staged trades count as inserted once they are promoted.
+	if len(batch.Trades) == 0 {
+		progress.AddInserted(int64(result.Written + result.Cancelled))
+	}
+}
@@ -0,0 +1,170 @@
+package filehandler
+
+import (
+	"b3challenge/internal/domain/entity"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func newLoaderTestPolicy() RetryPolicy {
+	return RetryPolicy{
+		Attempts:      3,
+		BaseDelay:     time.Millisecond,
+		MaxDelay:      time.Millisecond,
+		MaxPoisonRows: 10,
+		Transient: func(err error) bool {
+			return errors.Is(err, errTestTransient)
+		},
+		RowError: func(err error) bool {
+			return errors.Is(err, errTestPermanent)
+		},
+	}
+}
+
+func TestLoader_Store(t *testing.T) {
+	const content = "DataReferencia;CodigoInstrumento\n"
+
+	dir := filepath.Join(t.TempDir(), "uploads")
+	writer := BatchWriter{Trades: nil, StagedTrades: nil, Quotes: nil, Instruments: nil, Events: nil}
+	loader := NewLoader(dir, writer, nil, newLoaderTestPolicy(), nil, 10, 1, zap.NewNop())
+
+	assert.True(t, loader.Supports("../02-06-2025_NEGOCIOSAVISTA.zip"))
+	assert.False(t, loader.Supports("report.pdf"))
+
+	path, size, checksum, err := loader.Store("../02-06-2025_NEGOCIOSAVISTA.txt", strings.NewReader(content))
+	require.NoError(t, err)
+
+	sum := sha256.Sum256([]byte(content))
+	assert.Equal(t, dir, filepath.Dir(path))
+	assert.True(t, strings.HasSuffix(path, "_02-06-2025_NEGOCIOSAVISTA.txt"))
+	assert.Equal(t, int64(len(content)), size)
+	assert.Equal(t, hex.EncodeToString(sum[:]), checksum)
+
+	stored, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, content, string(stored))
+}
+
+func TestLoader_Load(t *testing.T) {
+	tests := []struct {
+		name         string
+		transients   int
+		poisoned     string
+		writeErr     error
+		wantStaged   int
+		wantRejected int64
+		wantErr      assert.ErrorAssertionFunc
+	}{
+		{
+			name:         "all batches staged",
+			transients:   0,
+			poisoned:     "",
+			writeErr:     nil,
+			wantStaged:   2,
+			wantRejected: 0,
+			wantErr:      assert.NoError,
+		},
+		{
+			name:         "transient errors retried",
+			transients:   2,
+			poisoned:     "",
+			writeErr:     nil,
+			wantStaged:   2,
+			wantRejected: 0,
+			wantErr:      assert.NoError,
+		},
+		{
+			name:         "row error rejected",
+			transients:   0,
+			poisoned:     "FRCQ25",
+			writeErr:     nil,
+			wantStaged:   1,
+			wantRejected: 1,
+			wantErr:      assert.NoError,
+		},
+		{
+			name:         "failed batches",
+			transients:   0,
+			poisoned:     "",
+			writeErr:     errTestFatal,
+			wantStaged:   0,
+			wantRejected: 0,
+			wantErr:      assert.Error,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var mu sync.Mutex
+			var staged []int64
+			transients := tt.transients
+			stage := func(_ context.Context, ingestionID int32, trades []entity.Trade, lines []int64) (int, int, error) {
+				assert.Equal(t, int32(7), ingestionID)
+				mu.Lock()
+				defer mu.Unlock()
+				switch {
+				case tt.writeErr != nil:
+					return 0, 0, tt.writeErr
+				case transients > 0:
+					transients--
+
+					return 0, 0, errTestTransient
+				case trades[0].Ticker == tt.poisoned:
+					return 0, 0, errTestPermanent
+				}
+				staged = append(staged, lines...)
+
+				return len(trades), 0, nil
+			}
+
+			dir := t.TempDir()
+			writer := BatchWriter{Trades: nil, StagedTrades: nil, Quotes: nil, Instruments: nil, Events: nil}
+			loader := NewLoader(dir, writer, stage, newLoaderTestPolicy(), nil, 1, 2, zap.NewNop())
+			progress := &entity.IngestionProgress{}
+			err := loader.Load(context.Background(), 7, "testdata/mock-csv.txt", progress)
+			tt.wantErr(t, err)
+
+			var got entity.Ingestion
+			progress.ApplyTo(&got)
+			assert.Equal(t, int64(2), got.RowsParsed)
+			assert.Equal(t, tt.wantRejected, got.RowsRejected)
+			assert.Equal(t, int64(0), got.RowsInserted)
+			assert.Len(t, staged, tt.wantStaged)
+
+			rejects, err := os.ReadFile(filepath.Join(dir, "rejects", "ingestion_7.jsonl"))
+			if tt.wantRejected == 0 {
+				assert.ErrorIs(t, err, os.ErrNotExist)
+
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, int(tt.wantRejected), strings.Count(string(rejects), "\n"))
+			assert.Contains(t, string(rejects), `"reason":"db_write"`)
+		})
+	}
+}
+
+func TestLoader_Remove(t *testing.T) {
+	dir := t.TempDir()
+	writer := BatchWriter{Trades: nil, StagedTrades: nil, Quotes: nil, Instruments: nil, Events: nil}
+	loader := NewLoader(dir, writer, nil, newLoaderTestPolicy(), nil, 10, 1, zap.NewNop())
+
+	path, _, _, err := loader.Store("trades.jsonl", strings.NewReader("{}"))
+	require.NoError(t, err)
+
+	require.NoError(t, loader.Remove(path))
+	assert.NoFileExists(t, path)
+	assert.NoError(t, loader.Remove(path))
+}
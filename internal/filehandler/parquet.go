@@ -0,0 +1,493 @@
+package filehandler
+
+import (
+	"b3challenge/internal/domain/entity"
+	"context"
+	"encoding/hex"
+	"io"
+	"math"
+	"math/big"
+	"os"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/parquet-go/parquet-go"
+	"github.com/parquet-go/parquet-go/format"
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+)
+
+const (
+	parquetReferenceDate = iota
+	parquetTicker
+	parquetUpdateAction
+	parquetPrice
+	parquetQuantity
+	parquetTradedAt
+	parquetTradeID
+	parquetSessionType
+	parquetDate
+	parquetBuyerCode
+	parquetSellerCode
+)
+
+const (
+	secondsPerDay      = 24 * 60 * 60
+	parquetJulianEpoch = 2440588
+	parquetReadRows    = 256
+	bitsPerByte        = 8
+)
+
+//nolint:gochecknoglobals
+var (
+	parquetTradeColumns = []string{
+		sourceReferenceDateField,
+		sourceTickerField,
+		sourceUpdateActionField,
+		sourcePriceField,
+		sourceQuantityField,
+		sourceTradedAtField,
+		sourceTradeIDField,
+		sourceSessionTypeField,
+		sourceDateField,
+		sourceBuyerCodeField,
+		sourceSellerCodeField,
+	}
+	parquetOptionalColumns = []string{sourceBuyerCodeField, sourceSellerCodeField}
+	parquetColumnKinds     = map[string][]parquet.Kind{
+		sourceReferenceDateField: {parquet.Int32, parquet.ByteArray},
+		sourceTickerField:        {parquet.ByteArray, parquet.FixedLenByteArray},
+		sourceUpdateActionField:  {parquet.Int32, parquet.Int64},
+		sourcePriceField: {
+			parquet.Int32, parquet.Int64, parquet.Float, parquet.Double, parquet.ByteArray, parquet.FixedLenByteArray,
+		},
+		sourceQuantityField:    {parquet.Int32, parquet.Int64},
+		sourceTradedAtField:    {parquet.Int64, parquet.Int96, parquet.ByteArray},
+		sourceTradeIDField:     {parquet.Int32, parquet.Int64},
+		sourceSessionTypeField: {parquet.Int32, parquet.Int64},
+		sourceDateField:        {parquet.Int32, parquet.ByteArray},
+		sourceBuyerCodeField:   {parquet.Int32, parquet.Int64},
+		sourceSellerCodeField:  {parquet.Int32, parquet.Int64},
+	}
+)
+
+var (
+	errParquetFormat      = errors.New("invalid parquet file")
+	errParquetUnsupported = errors.New("unsupported parquet feature")
+)
+
+func ParseParquetFile(
+	ctx context.Context,
+	filePath string,
+	fromLine int64,
+	out chan<- *Batch,
+	opts ParseOptions,
+	logger *zap.Logger,
+) (ParseStats, error) {
+	source, err := openParquetTradeSource(filePath)
+	if err != nil {
+		return ParseStats{Parsed: 0, Rejected: 0, Filtered: 0}, err
+	}
+	defer source.Close()
+
+	return ParseTradeSource(ctx, filePath, source, fromLine, out, opts, logger)
+}
+
+// parquetColumn is the file column a trade field is read from. index is -1 for an optional field
+// the file does not have.
+type parquetColumn struct {
+	index int
+	kind  parquet.Kind
+	typ   *format.LogicalType
+}
+
+// parquetTradeSource reads trades row by row with parquet-go, which takes care of page versions,
+// encodings and codecs; only the mapping of the columns to entity.Trade lives here.
+type parquetTradeSource struct {
+	file     *os.File
+	groups   []parquet.RowGroup
+	columns  []parquetColumn
+	fields   []int
+	rows     parquet.Rows
+	rowGroup int
+	buffer   []parquet.Row
+	pos      int
+	end      int
+	values   []parquet.Value
+	row      int64
+	cache    *fieldCache
+}
+
+func openParquetTradeSource(filePath string) (*parquetTradeSource, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot open file")
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+
+		return nil, errors.Wrap(err, "stat file")
+	}
+	parquetFile, err := parquet.OpenFile(file, info.Size())
+	if err != nil {
+		file.Close()
+
+		return nil, errors.Wrap(errParquetFormat, err.Error())
+	}
+	columns, err := parquetTradeSchema(parquetFile.Schema())
+	if err != nil {
+		file.Close()
+
+		return nil, err
+	}
+
+	fields := make([]int, len(parquetFile.Schema().Columns()))
+	for i := range fields {
+		fields[i] = -1
+	}
+	for i, column := range columns {
+		if column.index >= 0 {
+			fields[column.index] = i
+		}
+	}
+
+	return &parquetTradeSource{
+		file:     file,
+		groups:   parquetFile.RowGroups(),
+		columns:  columns,
+		fields:   fields,
+		rows:     nil,
+		rowGroup: 0,
+		buffer:   make([]parquet.Row, parquetReadRows),
+		pos:      0,
+		end:      0,
+		values:   make([]parquet.Value, len(columns)),
+		row:      0,
+		cache:    newFieldCache(),
+	}, nil
+}
+
+func parquetTradeSchema(schema *parquet.Schema) ([]parquetColumn, error) {
+	columns := make([]parquetColumn, len(parquetTradeColumns))
+	for i, name := range parquetTradeColumns {
+		leaf, ok := schema.Lookup(name)
+		switch {
+		case !ok && slices.Contains(parquetOptionalColumns, name):
+			columns[i] = parquetColumn{index: -1, kind: 0, typ: nil}
+
+			continue
+		case !ok:
+			return nil, errors.Wrap(errMissingColumn, name)
+		case leaf.MaxRepetitionLevel > 0:
+			return nil, errors.Wrapf(errParquetUnsupported, "repeated column %s", name)
+		case !slices.Contains(parquetColumnKinds[name], leaf.Node.Type().Kind()):
+			return nil, errors.Wrapf(errParquetUnsupported, "physical type %s for column %s", leaf.Node.Type().Kind(), name)
+		}
+
+		column := parquetColumn{index: leaf.ColumnIndex, kind: leaf.Node.Type().Kind(), typ: leaf.Node.Type().LogicalType()}
+		if name == sourceTradedAtField && column.kind == parquet.Int64 && column.timestamp() == nil {
+			return nil, errors.Wrapf(errParquetUnsupported, "column %s is not a timestamp", name)
+		}
+		columns[i] = column
+	}
+
+	return columns, nil
+}
+
+func (c parquetColumn) timestamp() *format.TimestampType {
+	if c.typ == nil {
+		return nil
+	}
+
+	return c.typ.Timestamp
+}
+
+func (c parquetColumn) decimal() *format.DecimalType {
+	if c.typ == nil {
+		return nil
+	}
+
+	return c.typ.Decimal
+}
+
+func (s *parquetTradeSource) Next() (int64, error) {
+	for s.pos >= s.end {
+		if err := s.read(); err != nil {
+			return s.row, err
+		}
+	}
+
+	for i := range s.values {
+		s.values[i] = parquet.Value{}
+	}
+	for _, value := range s.buffer[s.pos] {
+		if field := s.fields[value.Column()]; field >= 0 {
+			s.values[field] = value
+		}
+	}
+	s.pos++
+	s.row++
+
+	return s.row, nil
+}
+
+func (s *parquetTradeSource) read() error {
+	for {
+		if s.rows == nil {
+			if s.rowGroup >= len(s.groups) {
+				return io.EOF
+			}
+			s.rows = s.groups[s.rowGroup].Rows()
+			s.rowGroup++
+		}
+
+		n, err := s.rows.ReadRows(s.buffer)
+		s.pos, s.end = 0, n
+		if errors.Is(err, io.EOF) {
+			s.rows.Close()
+			s.rows = nil
+		} else if err != nil {
+			return errors.Wrapf(err, "row group %d", s.rowGroup-1)
+		}
+		if n > 0 {
+			return nil
+		}
+	}
+}
+
+func (s *parquetTradeSource) Close() error {
+	if s.rows != nil {
+		s.rows.Close()
+	}
+
+	return errors.Wrap(s.file.Close(), "close file")
+}
+
+func (s *parquetTradeSource) Decode(batch *Batch, trade *entity.Trade) error {
+	referenceDate, err := s.date(parquetReferenceDate)
+	if err != nil {
+		return err
+	}
+	ticker, err := s.present(parquetTicker)
+	if err != nil {
+		return err
+	}
+	if len(ticker.ByteArray()) == 0 {
+		return newFieldError(sourceTickerField, errors.New("missing ticker"))
+	}
+	updateAction, err := s.int(parquetUpdateAction, math.MinInt16, math.MaxInt16)
+	if err != nil {
+		return err
+	}
+	price, err := s.decimal(parquetPrice)
+	if err != nil {
+		return err
+	}
+	quantity, err := s.int(parquetQuantity, math.MinInt32, math.MaxInt32)
+	if err != nil {
+		return err
+	}
+	tradedAt, err := s.time(parquetTradedAt)
+	if err != nil {
+		return err
+	}
+	tradeID, err := s.int(parquetTradeID, math.MinInt64, math.MaxInt64)
+	if err != nil {
+		return err
+	}
+	sessionType, err := s.int(parquetSessionType, math.MinInt16, math.MaxInt16)
+	if err != nil {
+		return err
+	}
+	date, err := s.date(parquetDate)
+	if err != nil {
+		return err
+	}
+	buyerCode, err := s.code(parquetBuyerCode, batch)
+	if err != nil {
+		return err
+	}
+	sellerCode, err := s.code(parquetSellerCode, batch)
+	if err != nil {
+		return err
+	}
+
+	*trade = entity.Trade{
+		ReferenceDate: referenceDate,
+		Ticker:        s.cache.intern(string(ticker.ByteArray())),
+		UpdateAction:  entity.UpdateAction(updateAction),
+		Price:         price,
+		Quantity:      int32(quantity),
+		TradedAt:      tradedAt,
+		TradeID:       tradeID,
+		SessionType:   int16(sessionType),
+		Date:          date,
+		BuyerCode:     buyerCode,
+		SellerCode:    sellerCode,
+	}
+
+	return nil
+}
+
+func (s *parquetTradeSource) Raw() []string {
+	raw := make([]string, len(s.values))
+	for i, value := range s.values {
+		raw[i] = s.format(s.columns[i], value)
+	}
+
+	return raw
+}
+
+func (s *parquetTradeSource) present(index int) (parquet.Value, error) {
+	value := s.values[index]
+	if value.IsNull() {
+		return value, newFieldError(parquetTradeColumns[index], errors.New("missing value"))
+	}
+
+	return value, nil
+}
+
+func (s *parquetTradeSource) int(index int, minValue, maxValue int64) (int64, error) {
+	value, err := s.present(index)
+	if err != nil {
+		return 0, err
+	}
+	if err := checkIntRange(value.Int64(), minValue, maxValue); err != nil {
+		return 0, newFieldError(parquetTradeColumns[index], err)
+	}
+
+	return value.Int64(), nil
+}
+
+func (s *parquetTradeSource) code(index int, batch *Batch) (*int32, error) {
+	if s.values[index].IsNull() {
+		return nil, nil //nolint:nilnil
+	}
+
+	code, err := s.int(index, math.MinInt32, math.MaxInt32)
+	if err != nil {
+		return nil, err
+	}
+
+	return batch.code(int32(code)), nil
+}
+
+func (s *parquetTradeSource) date(index int) (time.Time, error) {
+	value, err := s.present(index)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if s.columns[index].kind == parquet.Int32 {
+		return time.Unix(value.Int64()*secondsPerDay, 0).UTC(), nil
+	}
+
+	date, err := s.cache.date(string(value.ByteArray()))
+	if err != nil {
+		return time.Time{}, newFieldError(parquetTradeColumns[index], err)
+	}
+
+	return date, nil
+}
+
+func (s *parquetTradeSource) time(index int) (time.Time, error) {
+	value, err := s.present(index)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	column := s.columns[index]
+	switch column.kind {
+	case parquet.Int64:
+		timestamp := column.timestamp()
+		instant := time.Unix(0, value.Int64()*int64(parquetTimeUnit(timestamp.Unit))).UTC()
+		if !timestamp.IsAdjustedToUTC {
+			return time.Date(
+				instant.Year(), instant.Month(), instant.Day(),
+				instant.Hour(), instant.Minute(), instant.Second(), instant.Nanosecond(), entity.B3Location,
+			), nil
+		}
+
+		return instant.In(entity.B3Location), nil
+	case parquet.Int96:
+		int96 := value.Int96()
+		nanos := int64(uint64(int96[1])<<32 | uint64(int96[0])) //nolint:gosec
+		days := int64(int96[2]) - parquetJulianEpoch
+
+		return time.Unix(days*secondsPerDay, nanos).In(entity.B3Location), nil
+	default:
+		tradedAt, err := parseSourceTime(string(value.ByteArray()))
+		if err != nil {
+			return time.Time{}, newFieldError(parquetTradeColumns[index], err)
+		}
+
+		return tradedAt, nil
+	}
+}
+
+func parquetTimeUnit(unit format.TimeUnit) time.Duration {
+	switch {
+	case unit.Nanos != nil:
+		return time.Nanosecond
+	case unit.Micros != nil:
+		return time.Microsecond
+	default:
+		return time.Millisecond
+	}
+}
+
+func (s *parquetTradeSource) decimal(index int) (decimal.Decimal, error) {
+	value, err := s.present(index)
+	if err != nil {
+		return decimal.Zero, err
+	}
+
+	column := s.columns[index]
+	var scale int32
+	if column.decimal() != nil {
+		scale = column.decimal().Scale
+	}
+	switch {
+	case column.kind == parquet.Int32 || column.kind == parquet.Int64:
+		return decimal.New(value.Int64(), -scale), nil
+	case column.kind == parquet.Float:
+		return decimal.NewFromFloat32(value.Float()), nil
+	case column.kind == parquet.Double:
+		return decimal.NewFromFloat(value.Double()), nil
+	case column.decimal() != nil:
+		bytes := value.ByteArray()
+		unscaled := new(big.Int).SetBytes(bytes)
+		if len(bytes) > 0 && bytes[0]&0x80 != 0 {
+			unscaled.Sub(unscaled, new(big.Int).Lsh(big.NewInt(1), uint(len(bytes)*bitsPerByte)))
+		}
+
+		return decimal.NewFromBigInt(unscaled, -scale), nil
+	default:
+		price, err := parsePrice(string(value.ByteArray()))
+		if err != nil {
+			return decimal.Zero, newFieldError(parquetTradeColumns[index], err)
+		}
+
+		return price, nil
+	}
+}
+
+func (s *parquetTradeSource) format(column parquetColumn, value parquet.Value) string {
+	switch {
+	case value.IsNull():
+		return ""
+	case column.kind == parquet.Int32 || column.kind == parquet.Int64:
+		return strconv.FormatInt(value.Int64(), 10)
+	case column.kind == parquet.Float:
+		return strconv.FormatFloat(float64(value.Float()), 'f', -1, 32)
+	case column.kind == parquet.Double:
+		return strconv.FormatFloat(value.Double(), 'f', -1, 64)
+	case column.kind == parquet.Int96 || column.decimal() != nil:
+		return hex.EncodeToString(value.ByteArray())
+	default:
+		return string(value.ByteArray())
+	}
+}
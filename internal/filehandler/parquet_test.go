@@ -0,0 +1,386 @@
+package filehandler
+
+import (
+	"b3challenge/internal/domain/entity"
+	"context"
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/AlekSi/pointer"
+	"github.com/parquet-go/parquet-go"
+	"github.com/parquet-go/parquet-go/compress"
+	"github.com/parquet-go/parquet-go/deprecated"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+const parquetMetaSourceField = "source"
+
+// parquetTestColumn is how a test file stores one trade field: its schema node and the value written
+// for each trade.
+type parquetTestColumn struct {
+	node  parquet.Node
+	value func(trade entity.Trade) parquet.Value
+}
+
+type parquetTestFile struct {
+	pageVersion int
+	codec       compress.Codec
+	columns     map[string]parquetTestColumn
+}
+
+// parquetTrades are sourceTrades with the record the ticker rule rejects as the fourth row, the
+// same rows the JSON Lines fixture has.
+func parquetTrades() []entity.Trade {
+	trades := sourceTrades()
+	rejected := entity.Trade{
+		ReferenceDate: trades[3].ReferenceDate, Ticker: "", UpdateAction: 0, Price: decimal.New(1000, -3),
+		Quantity: 1, TradedAt: time.Date(2025, 6, 3, 10, 0, 0, 0, entity.B3Location), TradeID: 13,
+		SessionType: 1, Date: trades[3].Date, BuyerCode: nil, SellerCode: nil,
+	}
+
+	return append(trades[:3:3], rejected, trades[3])
+}
+
+func parquetDays(date time.Time) int32 {
+	return int32(date.Unix() / secondsPerDay)
+}
+
+func parquetInt96(at time.Time) deprecated.Int96 {
+	at = at.UTC()
+	midnight := time.Date(at.Year(), at.Month(), at.Day(), 0, 0, 0, 0, time.UTC)
+	nanos := uint64(at.Sub(midnight).Nanoseconds())
+
+	return deprecated.Int96{uint32(nanos), uint32(nanos >> 32), uint32(parquetDays(midnight) + parquetJulianEpoch)}
+}
+
+func parquetFixedDecimal(price decimal.Decimal, scale int32) []byte {
+	unscaled := price.Shift(scale).IntPart()
+	bytes := make([]byte, 8)
+	binary.BigEndian.PutUint64(bytes, uint64(unscaled))
+
+	return bytes
+}
+
+func parquetCode(code *int32) parquet.Value {
+	if code == nil {
+		return parquet.NullValue()
+	}
+
+	return parquet.Int32Value(*code)
+}
+
+// defaultParquetColumns is the layout of a pyarrow export of the trades table: DATE, STRING,
+// DECIMAL(18,3) on INT64 and millisecond timestamps, with a nested group the reader skips.
+func defaultParquetColumns() map[string]parquetTestColumn {
+	return map[string]parquetTestColumn{
+		sourceReferenceDateField: {
+			node:  parquet.Date(),
+			value: func(trade entity.Trade) parquet.Value { return parquet.Int32Value(parquetDays(trade.ReferenceDate)) },
+		},
+		sourceTickerField: {
+			node:  parquet.String(),
+			value: func(trade entity.Trade) parquet.Value { return parquet.ByteArrayValue([]byte(trade.Ticker)) },
+		},
+		sourceUpdateActionField: {
+			node:  parquet.Int(32),
+			value: func(trade entity.Trade) parquet.Value { return parquet.Int32Value(int32(trade.UpdateAction)) },
+		},
+		sourcePriceField: {
+			node:  parquet.Decimal(3, 18, parquet.Int64Type),
+			value: func(trade entity.Trade) parquet.Value { return parquet.Int64Value(trade.Price.Shift(3).IntPart()) },
+		},
+		sourceQuantityField: {
+			node:  parquet.Int(32),
+			value: func(trade entity.Trade) parquet.Value { return parquet.Int32Value(trade.Quantity) },
+		},
+		sourceTradedAtField: {
+			node:  parquet.Timestamp(parquet.Millisecond),
+			value: func(trade entity.Trade) parquet.Value { return parquet.Int64Value(trade.TradedAt.UnixMilli()) },
+		},
+		sourceTradeIDField: {
+			node:  parquet.Int(64),
+			value: func(trade entity.Trade) parquet.Value { return parquet.Int64Value(trade.TradeID) },
+		},
+		sourceSessionTypeField: {
+			node:  parquet.Int(32),
+			value: func(trade entity.Trade) parquet.Value { return parquet.Int32Value(int32(trade.SessionType)) },
+		},
+		sourceDateField: {
+			node:  parquet.Date(),
+			value: func(trade entity.Trade) parquet.Value { return parquet.Int32Value(parquetDays(trade.Date)) },
+		},
+		sourceBuyerCodeField: {
+			node:  parquet.Optional(parquet.Int(32)),
+			value: func(trade entity.Trade) parquet.Value { return parquetCode(trade.BuyerCode) },
+		},
+		sourceSellerCodeField: {
+			node:  parquet.Optional(parquet.Int(32)),
+			value: func(trade entity.Trade) parquet.Value { return parquetCode(trade.SellerCode) },
+		},
+	}
+}
+
+// writeParquetTrades writes parquetTrades to a file in row groups of three rows. Columns not in
+// spec.columns use defaultParquetColumns.
+func writeParquetTrades(t *testing.T, spec parquetTestFile) string {
+	t.Helper()
+
+	columns := defaultParquetColumns()
+	for name, column := range spec.columns {
+		columns[name] = column
+	}
+	group := parquet.Group{"meta": parquet.Group{parquetMetaSourceField: parquet.String()}}
+	for name, column := range columns {
+		group[name] = column.node
+	}
+	schema := parquet.NewSchema("trades", group)
+
+	rows := make([]parquet.Row, 0, len(parquetTrades()))
+	for _, trade := range parquetTrades() {
+		row := make(parquet.Row, len(schema.Columns()))
+		meta, _ := schema.Lookup("meta", parquetMetaSourceField)
+		row[meta.ColumnIndex] = parquet.ByteArrayValue([]byte("b3")).Level(0, 0, meta.ColumnIndex)
+		for name, column := range columns {
+			leaf, ok := schema.Lookup(name)
+			require.True(t, ok, name)
+			value := column.value(trade)
+			definition := 0
+			if leaf.MaxDefinitionLevel > 0 && !value.IsNull() {
+				definition = leaf.MaxDefinitionLevel
+			}
+			row[leaf.ColumnIndex] = value.Level(0, definition, leaf.ColumnIndex)
+		}
+		rows = append(rows, row)
+	}
+
+	path := filepath.Join(t.TempDir(), "trades.parquet")
+	file, err := os.Create(path)
+	require.NoError(t, err)
+	defer file.Close()
+
+	options := []parquet.WriterOption{schema, parquet.MaxRowsPerRowGroup(3)}
+	if spec.pageVersion != 0 {
+		options = append(options, parquet.DataPageVersion(spec.pageVersion))
+	}
+	if spec.codec != nil {
+		options = append(options, parquet.Compression(spec.codec))
+	}
+	writer := parquet.NewWriter(file, options...)
+	_, err = writer.WriteRows(rows)
+	require.NoError(t, err)
+	require.NoError(t, writer.Close())
+
+	return path
+}
+
+func TestParquetTradeSource_Layouts(t *testing.T) {
+	deltaInt32 := func(value func(entity.Trade) int32) parquetTestColumn {
+		return parquetTestColumn{
+			node:  parquet.Encoded(parquet.Int(32), &parquet.DeltaBinaryPacked),
+			value: func(trade entity.Trade) parquet.Value { return parquet.Int32Value(value(trade)) },
+		}
+	}
+	deltaColumns := map[string]parquetTestColumn{
+		sourceTickerField: {
+			node:  parquet.Encoded(parquet.String(), &parquet.DeltaByteArray),
+			value: func(trade entity.Trade) parquet.Value { return parquet.ByteArrayValue([]byte(trade.Ticker)) },
+		},
+		sourceQuantityField: deltaInt32(func(trade entity.Trade) int32 { return trade.Quantity }),
+		sourceTradeIDField: {
+			node:  parquet.Encoded(parquet.Int(64), &parquet.DeltaBinaryPacked),
+			value: func(trade entity.Trade) parquet.Value { return parquet.Int64Value(trade.TradeID) },
+		},
+		sourceTradedAtField: {
+			node:  parquet.Encoded(parquet.Timestamp(parquet.Microsecond), &parquet.DeltaBinaryPacked),
+			value: func(trade entity.Trade) parquet.Value { return parquet.Int64Value(trade.TradedAt.UnixMicro()) },
+		},
+		sourcePriceField: {
+			node:  parquet.Encoded(parquet.Leaf(parquet.DoubleType), &parquet.ByteStreamSplit),
+			value: func(trade entity.Trade) parquet.Value { return parquet.DoubleValue(trade.Price.InexactFloat64()) },
+		},
+	}
+	sparkColumns := map[string]parquetTestColumn{
+		sourceTickerField: {
+			node:  parquet.Encoded(parquet.String(), &parquet.RLEDictionary),
+			value: func(trade entity.Trade) parquet.Value { return parquet.ByteArrayValue([]byte(trade.Ticker)) },
+		},
+		sourcePriceField: {
+			node: parquet.Decimal(3, 18, parquet.FixedLenByteArrayType(8)),
+			value: func(trade entity.Trade) parquet.Value {
+				return parquet.FixedLenByteArrayValue(parquetFixedDecimal(trade.Price, 3))
+			},
+		},
+		sourceTradedAtField: {
+			node:  parquet.Leaf(parquet.Int96Type),
+			value: func(trade entity.Trade) parquet.Value { return parquet.Int96Value(parquetInt96(trade.TradedAt)) },
+		},
+	}
+	textColumns := map[string]parquetTestColumn{
+		sourceReferenceDateField: {
+			node: parquet.String(),
+			value: func(trade entity.Trade) parquet.Value {
+				return parquet.ByteArrayValue([]byte(trade.ReferenceDate.Format(time.DateOnly)))
+			},
+		},
+		sourcePriceField: {
+			node:  parquet.Encoded(parquet.String(), &parquet.DeltaLengthByteArray),
+			value: func(trade entity.Trade) parquet.Value { return parquet.ByteArrayValue([]byte(trade.Price.String())) },
+		},
+		sourceTradedAtField: {
+			node: parquet.String(),
+			value: func(trade entity.Trade) parquet.Value {
+				return parquet.ByteArrayValue([]byte(trade.TradedAt.Format(time.RFC3339Nano)))
+			},
+		},
+		sourceDateField: {
+			node: parquet.String(),
+			value: func(trade entity.Trade) parquet.Value {
+				return parquet.ByteArrayValue([]byte(trade.Date.Format(time.DateOnly)))
+			},
+		},
+	}
+	localColumns := map[string]parquetTestColumn{
+		sourceTradedAtField: {
+			node: parquet.TimestampAdjusted(parquet.Nanosecond, false),
+			value: func(trade entity.Trade) parquet.Value {
+				wall := trade.TradedAt
+				local := time.Date(
+					wall.Year(), wall.Month(), wall.Day(), wall.Hour(), wall.Minute(), wall.Second(), wall.Nanosecond(), time.UTC,
+				)
+
+				return parquet.Int64Value(local.UnixNano())
+			},
+		},
+	}
+
+	tests := []struct {
+		name string
+		spec parquetTestFile
+	}{
+		{name: "v1 uncompressed", spec: parquetTestFile{pageVersion: 1, codec: &parquet.Uncompressed, columns: nil}},
+		{name: "v1 snappy", spec: parquetTestFile{pageVersion: 1, codec: &parquet.Snappy, columns: nil}},
+		{name: "v1 gzip", spec: parquetTestFile{pageVersion: 1, codec: &parquet.Gzip, columns: nil}},
+		{name: "v2 uncompressed", spec: parquetTestFile{pageVersion: 2, codec: &parquet.Uncompressed, columns: nil}},
+		{name: "v2 zstd", spec: parquetTestFile{pageVersion: 2, codec: &parquet.Zstd, columns: nil}},
+		{name: "v2 lz4", spec: parquetTestFile{pageVersion: 2, codec: &parquet.Lz4Raw, columns: nil}},
+		{name: "v2 brotli", spec: parquetTestFile{pageVersion: 2, codec: &parquet.Brotli, columns: nil}},
+		{name: "v1 delta encodings", spec: parquetTestFile{pageVersion: 1, codec: &parquet.Snappy, columns: deltaColumns}},
+		{name: "v2 delta encodings", spec: parquetTestFile{pageVersion: 2, codec: &parquet.Zstd, columns: deltaColumns}},
+		{name: "v1 int96 and flba", spec: parquetTestFile{pageVersion: 1, codec: &parquet.Snappy, columns: sparkColumns}},
+		{name: "v2 int96 and flba", spec: parquetTestFile{pageVersion: 2, codec: &parquet.Gzip, columns: sparkColumns}},
+		{name: "text columns", spec: parquetTestFile{pageVersion: 2, codec: &parquet.Snappy, columns: textColumns}},
+		{name: "local timestamps", spec: parquetTestFile{pageVersion: 1, codec: &parquet.Zstd, columns: localColumns}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rejects := newTestRejectWriter(t)
+			out := make(chan *Batch, 10)
+			stats, err := ParseParquetFile(
+				context.Background(), writeParquetTrades(t, tt.spec), 0, out, newTestParseOptions(10, rejects), zap.NewNop(),
+			)
+			require.NoError(t, err)
+
+			lines, trades, _ := drainBatches(out)
+			assert.Equal(t, ParseStats{Parsed: 4, Rejected: 1, Filtered: 0}, stats)
+			assert.Equal(t, []int64{1, 2, 3, 5}, lines)
+			assert.Equal(t, map[RejectReason]int64{ReasonInvalidTicker: 1}, rejects.Counts())
+
+			want := sourceTrades()
+			require.Len(t, trades, len(want))
+			for i := range trades {
+				assert.True(t, want[i].Price.Equal(trades[i].Price), "price %s", trades[i].Price)
+				trades[i].Price = want[i].Price
+			}
+			assert.Equal(t, want, trades)
+		})
+	}
+}
+
+func TestParquetTradeSource_Schema(t *testing.T) {
+	tests := []struct {
+		name    string
+		group   parquet.Group
+		wantErr error
+	}{
+		{
+			name:    "missing column",
+			group:   parquet.Group{sourceTickerField: parquet.String()},
+			wantErr: errMissingColumn,
+		},
+		{
+			name:    "repeated column",
+			group:   withParquetColumn(sourceTickerField, parquet.Repeated(parquet.String())),
+			wantErr: errParquetUnsupported,
+		},
+		{
+			name:    "unsupported type",
+			group:   withParquetColumn(sourceQuantityField, parquet.Leaf(parquet.DoubleType)),
+			wantErr: errParquetUnsupported,
+		},
+		{
+			name:    "integer traded at",
+			group:   withParquetColumn(sourceTradedAtField, parquet.Int(64)),
+			wantErr: errParquetUnsupported,
+		},
+		{
+			name:    "nested column",
+			group:   withParquetColumn(sourceTickerField, parquet.Group{"value": parquet.String()}),
+			wantErr: errMissingColumn,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parquetTradeSchema(parquet.NewSchema("trades", tt.group))
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+
+	group := parquet.Group{}
+	for name, column := range defaultParquetColumns() {
+		group[name] = column.node
+	}
+	delete(group, sourceBuyerCodeField)
+	columns, err := parquetTradeSchema(parquet.NewSchema("trades", group))
+	require.NoError(t, err)
+	assert.Equal(t, -1, columns[parquetBuyerCode].index)
+}
+
+func withParquetColumn(name string, node parquet.Node) parquet.Group {
+	group := parquet.Group{}
+	for field, column := range defaultParquetColumns() {
+		group[field] = column.node
+	}
+	group[name] = node
+
+	return group
+}
+
+func TestParquetTradeSource_Raw(t *testing.T) {
+	source, err := openParquetTradeSource(writeParquetTrades(t, parquetTestFile{pageVersion: 0, codec: nil, columns: nil}))
+	require.NoError(t, err)
+	defer source.Close()
+
+	line, err := source.Next()
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), line)
+	line, err = source.Next()
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), line)
+
+	want := []string{"20241", "VALE3", "0", "55000", "200", "1748869500000", "11", "1", "20241", "", "72"}
+	assert.Equal(t, want, source.Raw())
+
+	var batch Batch
+	var trade entity.Trade
+	require.NoError(t, source.Decode(&batch, &trade))
+	assert.Nil(t, trade.BuyerCode)
+	assert.Equal(t, pointer.ToInt32(72), trade.SellerCode)
+}
@@ -0,0 +1,187 @@
+package filehandler
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+type RejectReason string
+
+const (
+	ReasonCSVRead              RejectReason = "csv_read"
+	ReasonInvalidRecordLength  RejectReason = "invalid_record_length"
+	ReasonInvalidReferenceDate RejectReason = "invalid_reference_date"
+	ReasonInvalidUpdateAction  RejectReason = "invalid_update_action"
+	ReasonInvalidPrice         RejectReason = "invalid_price"
+	ReasonInvalidQuantity      RejectReason = "invalid_quantity"
+	ReasonInvalidHour          RejectReason = "invalid_hour"
+	ReasonInvalidTradeID       RejectReason = "invalid_trade_id"
+	ReasonInvalidSessionType   RejectReason = "invalid_session_type"
+	ReasonInvalidDate          RejectReason = "invalid_date"
+	ReasonInvalidBuyerCode     RejectReason = "invalid_buyer_code"
+	ReasonInvalidSellerCode    RejectReason = "invalid_seller_code"
+	ReasonInvalidRecordType    RejectReason = "invalid_record_type"
+	ReasonInvalidBDICode       RejectReason = "invalid_bdi_code"
+	ReasonInvalidMarketType    RejectReason = "invalid_market_type"
+	ReasonInvalidForwardTerm   RejectReason = "invalid_forward_term"
+	ReasonInvalidTradesCount   RejectReason = "invalid_trades_count"
+	ReasonInvalidQuoteFactor   RejectReason = "invalid_quote_factor"
+	ReasonInvalidTicker        RejectReason = "invalid_ticker"
+	ReasonInvalidEventType     RejectReason = "invalid_event_type"
+	ReasonInvalidFactor        RejectReason = "invalid_factor"
+	ReasonInvalidJSON          RejectReason = "invalid_json"
+	ReasonDBWrite              RejectReason = "db_write"
+	ReasonNonPositivePrice     RejectReason = "non_positive_price"
+	ReasonNonPositiveQuantity  RejectReason = "non_positive_quantity"
+	ReasonMalformedTicker      RejectReason = "malformed_ticker"
+	ReasonDateMismatch         RejectReason = "date_mismatch"
+)
+
+type RejectFormat string
+
+const (
+	RejectFormatJSONL RejectFormat = "jsonl"
+	RejectFormatCSV   RejectFormat = "csv"
+)
+
+type Reject struct {
+	File   string       `json:"file"`
+	Line   int64        `json:"line"`
+	Raw    string       `json:"raw"`
+	Reason RejectReason `json:"reason"`
+	Error  string       `json:"error"`
+}
+
+type rejectError struct {
+	reason RejectReason
+	err    error
+}
+
+func (e *rejectError) Error() string {
+	return e.err.Error()
+}
+
+func (e *rejectError) Unwrap() error {
+	return e.err
+}
+
+func newRejectError(reason RejectReason, err error) error {
+	return &rejectError{reason: reason, err: err}
+}
+
+func isRejectError(err error) bool {
+	var rejectErr *rejectError
+
+	return errors.As(err, &rejectErr)
+}
+
+func ReasonOf(err error) RejectReason {
+	var rejectErr *rejectError
+	if errors.As(err, &rejectErr) {
+		return rejectErr.reason
+	}
+
+	return ReasonCSVRead
+}
+
+func RejectFormatFromPath(path string) RejectFormat {
+	if strings.EqualFold(filepath.Ext(path), ".csv") {
+		return RejectFormatCSV
+	}
+
+	return RejectFormatJSONL
+}
+
+type RejectWriter struct {
+	mu        sync.Mutex
+	format    RejectFormat
+	out       io.Writer
+	csvWriter *csv.Writer
+	counts    map[RejectReason]int64
+}
+
+func NewRejectWriter(out io.Writer, format RejectFormat) (*RejectWriter, error) {
+	writer := &RejectWriter{
+		mu:        sync.Mutex{},
+		format:    format,
+		out:       out,
+		csvWriter: nil,
+		counts:    make(map[RejectReason]int64),
+	}
+
+	if out != nil && format == RejectFormatCSV {
+		writer.csvWriter = csv.NewWriter(out)
+		if err := writer.csvWriter.Write([]string{"file", "line", "raw", "reason", "error"}); err != nil {
+			return nil, errors.Wrap(err, "write csv header")
+		}
+	}
+
+	return writer, nil
+}
+
+func (w *RejectWriter) Write(reject Reject) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.counts[reject.Reason]++
+
+	if w.out == nil {
+		return nil
+	}
+
+	if w.csvWriter != nil {
+		record := []string{reject.File, strconv.FormatInt(reject.Line, 10), reject.Raw, string(reject.Reason), reject.Error}
+		if err := w.csvWriter.Write(record); err != nil {
+			return errors.Wrap(err, "write csv reject")
+		}
+
+		return nil
+	}
+
+	line, err := json.Marshal(reject)
+	if err != nil {
+		return errors.Wrap(err, "marshal reject")
+	}
+	if _, err := w.out.Write(append(line, '\n')); err != nil {
+		return errors.Wrap(err, "write jsonl reject")
+	}
+
+	return nil
+}
+
+func (w *RejectWriter) Counts() map[RejectReason]int64 {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	counts := make(map[RejectReason]int64, len(w.counts))
+	for reason, count := range w.counts {
+		counts[reason] = count
+	}
+
+	return counts
+}
+
+func (w *RejectWriter) Flush() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.csvWriter != nil {
+		w.csvWriter.Flush()
+		if err := w.csvWriter.Error(); err != nil {
+			return errors.Wrap(err, "flush csv rejects")
+		}
+	}
+
+	if flusher, ok := w.out.(interface{ Flush() error }); ok {
+		return errors.Wrap(flusher.Flush(), "flush rejects")
+	}
+
+	return nil
+}
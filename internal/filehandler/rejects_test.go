@@ -0,0 +1,74 @@
+package filehandler
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRejectFormatFromPath(t *testing.T) {
+	assert.Equal(t, RejectFormatCSV, RejectFormatFromPath("rejects.CSV"))
+	assert.Equal(t, RejectFormatJSONL, RejectFormatFromPath("rejects.jsonl"))
+	assert.Equal(t, RejectFormatJSONL, RejectFormatFromPath("rejects"))
+}
+
+func TestReasonOf(t *testing.T) {
+	err := errors.Wrap(newRejectError(ReasonInvalidPrice, assert.AnError), "wrapped")
+	assert.Equal(t, ReasonInvalidPrice, ReasonOf(err))
+	assert.ErrorIs(t, err, assert.AnError)
+	assert.Equal(t, ReasonCSVRead, ReasonOf(assert.AnError))
+}
+
+func TestRejectWriter_Write(t *testing.T) {
+	reject := Reject{
+		File:   "b3Data/file.txt",
+		Line:   7,
+		Raw:    "2025-06-02;TF583R;0;abc",
+		Reason: ReasonInvalidPrice,
+		Error:  "parsing price: invalid",
+	}
+
+	tests := []struct {
+		name   string
+		format RejectFormat
+		want   string
+	}{
+		{
+			name:   "jsonl",
+			format: RejectFormatJSONL,
+			want: `{"file":"b3Data/file.txt","line":7,"raw":"2025-06-02;TF583R;0;abc",` +
+				`"reason":"invalid_price","error":"parsing price: invalid"}` + "\n",
+		},
+		{
+			name:   "csv",
+			format: RejectFormatCSV,
+			want: "file,line,raw,reason,error\n" +
+				"b3Data/file.txt,7,2025-06-02;TF583R;0;abc,invalid_price,parsing price: invalid\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			writer, err := NewRejectWriter(&buf, tt.format)
+			assert.NoError(t, err)
+
+			assert.NoError(t, writer.Write(reject))
+			assert.NoError(t, writer.Flush())
+			assert.Equal(t, tt.want, buf.String())
+			assert.Equal(t, map[RejectReason]int64{ReasonInvalidPrice: 1}, writer.Counts())
+		})
+	}
+}
+
+func TestRejectWriter_CountOnly(t *testing.T) {
+	writer, err := NewRejectWriter(nil, RejectFormatJSONL)
+	assert.NoError(t, err)
+
+	assert.NoError(t, writer.Write(Reject{Reason: ReasonCSVRead}))
+	assert.NoError(t, writer.Write(Reject{Reason: ReasonCSVRead}))
+	assert.NoError(t, writer.Flush())
+	assert.Equal(t, map[RejectReason]int64{ReasonCSVRead: 2}, writer.Counts())
+}
@@ -0,0 +1,154 @@
+package filehandler
+
+import (
+	"context"
+	"encoding/json"
+	"math/rand/v2"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+var ErrTooManyPoisonRows = errors.New("too many rows rejected by the database")
+
+type RetryPolicy struct {
+	Attempts      int
+	BaseDelay     time.Duration
+	MaxDelay      time.Duration
+	MaxPoisonRows int
+	Transient     func(err error) bool
+	RowError      func(err error) bool
+}
+
+type WriteResult struct {
+	Written   int
+	Cancelled int
+	Rejected  int
+}
+
+func (r *WriteResult) add(other WriteResult) {
+	r.Written += other.Written
+	r.Cancelled += other.Cancelled
+	r.Rejected += other.Rejected
+}
+
+// RetryingWriter retries transient write errors with exponential backoff. When a batch fails with an
+// error caused by the data of some of its rows it is split in halves until the offending rows are
+// isolated; those rows go to the rejects output and the rest of the batch is written. Any other
+// error fails the batch as is.
+type RetryingWriter struct {
+	write   BatchWriter
+	policy  RetryPolicy
+	rejects *RejectWriter
+	logger  *zap.Logger
+}
+
+func NewRetryingWriter(write BatchWriter, policy RetryPolicy, rejects *RejectWriter, logger *zap.Logger) *RetryingWriter {
+	return &RetryingWriter{write: write, policy: policy, rejects: rejects, logger: logger}
+}
+
+func (w *RetryingWriter) Write(ctx context.Context, batch *Batch) (WriteResult, error) {
+	result, err := w.writeWithRetry(ctx, batch)
+	if err == nil || !w.isRowError(ctx, err) {
+		return result, err
+	}
+
+	w.logger.Warn(
+		"Batch failed with a row error, splitting it to isolate the offending rows",
+		zap.String("file", batch.Source),
+		zap.Int("rows", batch.Len()),
+		zap.Error(err),
+	)
+
+	return w.bisect(ctx, batch, err, WriteResult{Written: 0, Cancelled: 0, Rejected: 0})
+}
+
+func (w *RetryingWriter) bisect(ctx context.Context, batch *Batch, cause error, result WriteResult) (WriteResult, error) {
+	if batch.Len() == 1 {
+		if result.Rejected >= w.policy.MaxPoisonRows {
+			return result, errors.Wrapf(ErrTooManyPoisonRows, "%s: %s", batch.Source, cause.Error())
+		}
+		w.reject(batch, cause)
+		result.Rejected++
+
+		return result, nil
+	}
+
+	mid := batch.Len() / 2
+	for _, half := range []*Batch{batch.slice(0, mid), batch.slice(mid, batch.Len())} {
+		written, err := w.writeWithRetry(ctx, half)
+		result.add(written)
+		if err == nil {
+			continue
+		}
+		if !w.isRowError(ctx, err) {
+			return result, err
+		}
+		if result, err = w.bisect(ctx, half, err, result); err != nil {
+			return result, err
+		}
+	}
+
+	return result, nil
+}
+
+func (w *RetryingWriter) writeWithRetry(ctx context.Context, batch *Batch) (WriteResult, error) {
+	delay := w.policy.BaseDelay
+	for attempt := 1; ; attempt++ {
+		written, cancelled, err := w.write.Write(ctx, batch)
+		if err == nil {
+			return WriteResult{Written: written, Cancelled: cancelled, Rejected: 0}, nil
+		}
+		if attempt >= w.policy.Attempts || !w.isTransient(ctx, err) {
+			return WriteResult{Written: 0, Cancelled: 0, Rejected: 0}, err
+		}
+
+		wait := jitter(delay)
+		w.logger.Warn(
+			"Transient error writing batch, retrying",
+			zap.String("file", batch.Source),
+			zap.Int("attempt", attempt),
+			zap.Duration("backoff", wait),
+			zap.Error(err),
+		)
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+
+			return WriteResult{Written: 0, Cancelled: 0, Rejected: 0}, errors.Wrap(ctx.Err(), "retry cancelled")
+		case <-timer.C:
+		}
+		delay = min(delay*2, w.policy.MaxDelay)
+	}
+}
+
+func (w *RetryingWriter) isTransient(ctx context.Context, err error) bool {
+	return ctx.Err() == nil && w.policy.Transient(err)
+}
+
+func (w *RetryingWriter) isRowError(ctx context.Context, err error) bool {
+	return ctx.Err() == nil && !w.policy.Transient(err) && w.policy.RowError(err)
+}
+
+func (w *RetryingWriter) reject(batch *Batch, cause error) {
+	line := batch.Lines[0]
+	raw, err := json.Marshal(batch.record(0))
+	if err != nil {
+		raw = []byte(err.Error())
+	}
+
+	w.logger.Error("Row rejected by the database", zap.String("file", batch.Source), zap.Int64("line", line), zap.Error(cause))
+	writeReject(w.rejects, batch.Source, line, []string{string(raw)}, ReasonDBWrite, cause, w.logger)
+}
+
+// jitter spreads the backoff over [delay/2, delay] so DB workers that failed together do not retry in lockstep.
+func jitter(delay time.Duration) time.Duration {
+	if delay <= 1 {
+		return delay
+	}
+
+	return delay/2 + rand.N(delay/2) //nolint:gosec
+}
@@ -0,0 +1,252 @@
+package filehandler
+
+import (
+	"b3challenge/internal/domain/entity"
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+var (
+	errTestTransient = errors.New("connection reset")
+	errTestPermanent = errors.New("value out of range")
+	errTestFatal     = errors.New("relation does not exist")
+)
+
+type fakeTradeStore struct {
+	poisoned   []int64
+	transients int
+	calls      int
+	written    []int64
+}
+
+func (s *fakeTradeStore) write(_ context.Context, trades []entity.Trade) (int, int, error) {
+	s.calls++
+	if s.transients > 0 {
+		s.transients--
+
+		return 0, 0, errTestTransient
+	}
+	for _, trade := range trades {
+		if slices.Contains(s.poisoned, trade.TradeID) {
+			return 0, 0, errors.Wrap(errTestPermanent, "copy trades")
+		}
+	}
+	for _, trade := range trades {
+		s.written = append(s.written, trade.TradeID)
+	}
+
+	return len(trades), 0, nil
+}
+
+func newRetryTestBatch(size int) *Batch {
+	batch := NewBatchPool(size).Get()
+	batch.Source = "trades.txt"
+	for i := range size {
+		trade := batch.next(int64(i + 2))
+		trade.TradeID = int64(i + 1)
+	}
+
+	return batch
+}
+
+func TestRetryingWriter_Write(t *testing.T) {
+	tests := []struct {
+		name         string
+		poisoned     []int64
+		transients   int
+		maxPoison    int
+		want         WriteResult
+		wantErr      error
+		wantRejected []int64
+		wantCalls    int
+	}{
+		{
+			name:      "clean batch",
+			maxPoison: 10,
+			want:      WriteResult{Written: 8, Cancelled: 0, Rejected: 0},
+			wantCalls: 1,
+		},
+		{
+			name:       "transient error retried",
+			transients: 2,
+			maxPoison:  10,
+			want:       WriteResult{Written: 8, Cancelled: 0, Rejected: 0},
+			wantCalls:  3,
+		},
+		{
+			name:       "transient error exhausts attempts",
+			transients: 3,
+			maxPoison:  10,
+			want:       WriteResult{Written: 0, Cancelled: 0, Rejected: 0},
+			wantErr:    errTestTransient,
+			wantCalls:  3,
+		},
+		{
+			name:         "poison rows isolated",
+			poisoned:     []int64{3, 8},
+			maxPoison:    10,
+			want:         WriteResult{Written: 6, Cancelled: 0, Rejected: 2},
+			wantRejected: []int64{4, 9},
+			wantCalls:    11,
+		},
+		{
+			name:         "too many poison rows",
+			poisoned:     []int64{1, 2, 3},
+			maxPoison:    1,
+			want:         WriteResult{Written: 0, Cancelled: 0, Rejected: 1},
+			wantErr:      ErrTooManyPoisonRows,
+			wantRejected: []int64{2},
+			wantCalls:    5,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			rejects, err := NewRejectWriter(&out, RejectFormatJSONL)
+			require.NoError(t, err)
+
+			store := &fakeTradeStore{poisoned: tt.poisoned, transients: tt.transients, calls: 0, written: nil}
+			policy := RetryPolicy{
+				Attempts:      3,
+				BaseDelay:     time.Millisecond,
+				MaxDelay:      2 * time.Millisecond,
+				MaxPoisonRows: tt.maxPoison,
+				Transient: func(err error) bool {
+					return errors.Is(err, errTestTransient)
+				},
+				RowError: func(err error) bool {
+					return errors.Is(err, errTestPermanent)
+				},
+			}
+			write := BatchWriter{Trades: store.write, StagedTrades: nil, Quotes: nil, Instruments: nil, Events: nil}
+			writer := NewRetryingWriter(write, policy, rejects, zap.NewNop())
+
+			got, err := writer.Write(context.Background(), newRetryTestBatch(8))
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantCalls, store.calls)
+			assert.Len(t, store.written, got.Written)
+
+			var rejected []int64
+			scanner := bufio.NewScanner(&out)
+			for scanner.Scan() {
+				var reject Reject
+				require.NoError(t, json.Unmarshal(scanner.Bytes(), &reject))
+				assert.Equal(t, ReasonDBWrite, reject.Reason)
+				assert.Equal(t, "trades.txt", reject.File)
+				rejected = append(rejected, reject.Line)
+			}
+			assert.Equal(t, tt.wantRejected, rejected)
+		})
+	}
+}
+
+func TestRetryingWriter_WriteCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	store := &fakeTradeStore{poisoned: nil, transients: 10, calls: 0, written: nil}
+	policy := RetryPolicy{
+		Attempts:      10,
+		BaseDelay:     time.Hour,
+		MaxDelay:      time.Hour,
+		MaxPoisonRows: 10,
+		Transient: func(err error) bool {
+			cancel()
+
+			return errors.Is(err, errTestTransient)
+		},
+		RowError: func(err error) bool {
+			return errors.Is(err, errTestPermanent)
+		},
+	}
+	write := BatchWriter{Trades: store.write, StagedTrades: nil, Quotes: nil, Instruments: nil, Events: nil}
+	writer := NewRetryingWriter(write, policy, newTestRejectWriter(t), zap.NewNop())
+
+	_, err := writer.Write(ctx, newRetryTestBatch(4))
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, 1, store.calls)
+}
+
+func TestRetryingWriter_WriteStaged(t *testing.T) {
+	store := &fakeTradeStore{poisoned: []int64{3}, transients: 0, calls: 0, written: nil}
+	var sources []string
+	var lines []int64
+	staged := func(ctx context.Context, source string, trades []entity.Trade, batchLines []int64) (int, int, error) {
+		written, cancelled, err := store.write(ctx, trades)
+		if err == nil {
+			sources = append(sources, source)
+			lines = append(lines, batchLines...)
+		}
+
+		return written, cancelled, err
+	}
+	policy := RetryPolicy{
+		Attempts:      1,
+		BaseDelay:     time.Millisecond,
+		MaxDelay:      time.Millisecond,
+		MaxPoisonRows: 10,
+		Transient: func(err error) bool {
+			return errors.Is(err, errTestTransient)
+		},
+		RowError: func(err error) bool {
+			return errors.Is(err, errTestPermanent)
+		},
+	}
+	write := BatchWriter{Trades: nil, StagedTrades: staged, Quotes: nil, Instruments: nil, Events: nil}
+	writer := NewRetryingWriter(write, policy, newTestRejectWriter(t), zap.NewNop())
+
+	got, err := writer.Write(context.Background(), newRetryTestBatch(4))
+	require.NoError(t, err)
+	assert.Equal(t, WriteResult{Written: 3, Cancelled: 0, Rejected: 1}, got)
+	assert.Equal(t, []int64{2, 3, 5}, lines)
+	for _, source := range sources {
+		assert.Equal(t, "trades.txt", source)
+	}
+}
+
+func TestRetryingWriter_WriteNonRowError(t *testing.T) {
+	var out bytes.Buffer
+	rejects, err := NewRejectWriter(&out, RejectFormatJSONL)
+	require.NoError(t, err)
+
+	calls := 0
+	trades := func(_ context.Context, _ []entity.Trade) (int, int, error) {
+		calls++
+
+		return 0, 0, errors.Wrap(errTestFatal, "copy trades")
+	}
+	policy := RetryPolicy{
+		Attempts:      3,
+		BaseDelay:     time.Millisecond,
+		MaxDelay:      time.Millisecond,
+		MaxPoisonRows: 10,
+		Transient: func(err error) bool {
+			return errors.Is(err, errTestTransient)
+		},
+		RowError: func(err error) bool {
+			return errors.Is(err, errTestPermanent)
+		},
+	}
+	write := BatchWriter{Trades: trades, StagedTrades: nil, Quotes: nil, Instruments: nil, Events: nil}
+	writer := NewRetryingWriter(write, policy, rejects, zap.NewNop())
+
+	got, err := writer.Write(context.Background(), newRetryTestBatch(8))
+	assert.ErrorIs(t, err, errTestFatal)
+	assert.Equal(t, WriteResult{Written: 0, Cancelled: 0, Rejected: 0}, got)
+	assert.Equal(t, 1, calls)
+	assert.Empty(t, out.String())
+}
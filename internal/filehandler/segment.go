@@ -0,0 +1,219 @@
+package filehandler
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+const countBufferSize = 64 * 1024
+
+type Segment struct {
+	Index     int
+	Start     int64
+	End       int64
+	FirstLine int64
+	LastLine  int64
+}
+
+func (s Segment) IsWholeFile() bool {
+	return s.End == 0
+}
+
+func WholeFile() Segment {
+	return Segment{Index: 0, Start: 0, End: 0, FirstLine: 1, LastLine: 0}
+}
+
+// FileScan is what a single read of an input file yields: its checksum and size, and the
+// line-aligned segments it is parsed in.
+type FileScan struct {
+	Checksum string
+	Size     int64
+	Segments []Segment
+}
+
+// ScanFile hashes the file and, for .txt files larger than chunkSize, splits it into segments of
+// about chunkSize bytes ending on line breaks, in the same pass. The line numbers of every segment
+// are counted on the way so checkpoints and rejects keep file line numbers.
+func ScanFile(filePath string, chunkSize int64) (FileScan, error) {
+	hash := sha256.New()
+	size, segments, err := scanFile(filePath, chunkSize, hash)
+	if err != nil {
+		return FileScan{Checksum: "", Size: 0, Segments: nil}, err
+	}
+
+	return FileScan{Checksum: hex.EncodeToString(hash.Sum(nil)), Size: size, Segments: segments}, nil
+}
+
+func SplitFile(filePath string, chunkSize int64) ([]Segment, error) {
+	_, segments, err := scanFile(filePath, chunkSize, io.Discard)
+
+	return segments, err
+}
+
+func scanFile(filePath string, chunkSize int64, hash io.Writer) (int64, []Segment, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return 0, nil, errors.Wrap(err, "cannot open file")
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return 0, nil, errors.Wrap(err, "stat file")
+	}
+
+	splittable := chunkSize > 0 && info.Size() > chunkSize &&
+		!IsCotahistFile(filePath) && strings.EqualFold(filepath.Ext(filePath), txtExtension)
+	if !splittable {
+		size, err := io.Copy(hash, file)
+		if err != nil {
+			return 0, nil, errors.Wrap(err, "reading file")
+		}
+
+		return size, []Segment{WholeFile()}, nil
+	}
+
+	splitter := newSegmentSplitter(info.Size(), chunkSize)
+	size, err := io.CopyBuffer(io.MultiWriter(hash, splitter), file, make([]byte, countBufferSize))
+	if err != nil {
+		return 0, nil, errors.Wrap(err, "reading file")
+	}
+
+	return size, splitter.finish(), nil
+}
+
+// segmentSplitter is fed the file sequentially. Each segment ends at the first line break at or
+// after the next multiple of chunkSize past the previous boundary.
+type segmentSplitter struct {
+	size      int64
+	chunkSize int64
+	target    int64
+	pos       int64
+	lines     int64
+	last      byte
+	segments  []Segment
+}
+
+func newSegmentSplitter(size, chunkSize int64) *segmentSplitter {
+	return &segmentSplitter{
+		size:      size,
+		chunkSize: chunkSize,
+		target:    chunkSize,
+		pos:       0,
+		lines:     0,
+		last:      '\n',
+		segments:  []Segment{{Index: 0, Start: 0, End: 0, FirstLine: 1, LastLine: 0}},
+	}
+}
+
+func (s *segmentSplitter) Write(p []byte) (int, error) {
+	for rest := p; len(rest) > 0; {
+		if s.pos < s.target {
+			n := int(min(int64(len(rest)), s.target-s.pos))
+			s.consume(rest[:n])
+			rest = rest[n:]
+
+			continue
+		}
+
+		i := bytes.IndexByte(rest, '\n')
+		if i < 0 {
+			s.consume(rest)
+
+			break
+		}
+		s.consume(rest[:i+1])
+		rest = rest[i+1:]
+		s.cut()
+	}
+
+	return len(p), nil
+}
+
+func (s *segmentSplitter) consume(p []byte) {
+	s.lines += int64(bytes.Count(p, []byte{'\n'}))
+	s.pos += int64(len(p))
+	s.last = p[len(p)-1]
+}
+
+func (s *segmentSplitter) cut() {
+	s.target = (s.pos/s.chunkSize + 1) * s.chunkSize
+	if s.pos >= s.size {
+		return
+	}
+
+	current := &s.segments[len(s.segments)-1]
+	current.End = s.pos
+	current.LastLine = current.FirstLine + s.lines - 1
+	s.segments = append(s.segments, Segment{
+		Index:     len(s.segments),
+		Start:     s.pos,
+		End:       0,
+		FirstLine: current.LastLine + 1,
+		LastLine:  0,
+	})
+	s.lines = 0
+}
+
+func (s *segmentSplitter) finish() []Segment {
+	if s.last != '\n' {
+		s.lines++
+	}
+
+	current := &s.segments[len(s.segments)-1]
+	current.End = s.pos
+	current.LastLine = current.FirstLine + s.lines - 1
+
+	return s.segments
+}
+
+func ParseSegment(
+	ctx context.Context,
+	filePath string,
+	segment Segment,
+	fromLine int64,
+	out chan<- *Batch,
+	opts ParseOptions,
+	logger *zap.Logger,
+) (ParseStats, error) {
+	if IsCotahistFile(filePath) {
+		return ParseCotahistFile(ctx, filePath, fromLine, out, opts, logger)
+	}
+	if IsInstrumentsFile(filePath) {
+		return ParseInstrumentsFile(ctx, filePath, fromLine, out, opts, logger)
+	}
+	if IsCorporateEventsFile(filePath) {
+		return ParseCorporateEventsFile(ctx, filePath, fromLine, out, opts, logger)
+	}
+	switch opts.Format.Resolve(filePath) {
+	case TradeFormatJSONL:
+		return ParseJSONLFile(ctx, filePath, fromLine, out, opts, logger)
+	case TradeFormatParquet:
+		return ParseParquetFile(ctx, filePath, fromLine, out, opts, logger)
+	case TradeFormatAuto, TradeFormatCSV:
+	}
+	if segment.IsWholeFile() {
+		return ParseFileToTrades(ctx, filePath, fromLine, out, opts, logger)
+	}
+
+	file, err := os.Open(filePath)
+	if err != nil {
+		return ParseStats{Parsed: 0, Rejected: 0, Filtered: 0}, errors.Wrap(err, "cannot open file")
+	}
+	defer file.Close()
+
+	parser := newTradeParser(filePath, segment.Index, fromLine, out, opts, logger)
+	stream := io.NewSectionReader(file, segment.Start, segment.End-segment.Start)
+	_, err = parser.parse(ctx, stream, segment.FirstLine-1, segment.Index == 0)
+
+	return parser.finish(ctx, err)
+}
@@ -0,0 +1,208 @@
+package filehandler
+
+import (
+	"b3challenge/internal/domain/entity"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+const testHeader = "DataReferencia;CodigoInstrumento;AcaoAtualizacao;PrecoNegocio;QuantidadeNegociada;" +
+	"HoraFechamento;CodigoIdentificadorNegocio;TipoSessaoPregao;DataNegocio;" +
+	"CodigoParticipanteComprador;CodigoParticipanteVendedor"
+
+func writeTradesFile(t testing.TB, rows int) string {
+	t.Helper()
+
+	var sb strings.Builder
+	sb.WriteString(testHeader + "\n")
+	for i := range rows {
+		if i%50 == 7 {
+			sb.WriteString("broken;row\n")
+
+			continue
+		}
+		fmt.Fprintf(&sb, "2025-06-02;PETR4;0;%d,500;%d;090000%03d;%d;1;2025-06-02;1;2\n", 30+i%5, 100+i, i%1000, i+1)
+	}
+
+	path := filepath.Join(t.TempDir(), "trades.txt")
+	require.NoError(t, os.WriteFile(path, []byte(sb.String()), 0o600))
+
+	return path
+}
+
+func parseSegments(
+	t testing.TB,
+	path string,
+	segments []Segment,
+	fromLine int64,
+	pool *BatchPool,
+	out chan<- *Batch,
+) ParseStats {
+	t.Helper()
+
+	var total ParseStats
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for _, segment := range segments {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			opts := ParseOptions{
+				Pool:      pool,
+				Rejects:   newTestRejectWriter(t),
+				Dates:     DateRange{},
+				Format:    TradeFormatAuto,
+				Validator: nil,
+				Observer:  nil,
+			}
+			stats, err := ParseSegment(context.Background(), path, segment, fromLine, out, opts, zap.NewNop())
+			assert.NoError(t, err)
+
+			mu.Lock()
+			total.Parsed += stats.Parsed
+			total.Rejected += stats.Rejected
+			total.Filtered += stats.Filtered
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+	close(out)
+
+	return total
+}
+
+type lineTrade struct {
+	line  int64
+	trade entity.Trade
+}
+
+func collectSegments(t testing.TB, path string, segments []Segment, fromLine int64) ([]lineTrade, ParseStats) {
+	t.Helper()
+
+	out := make(chan *Batch, 4)
+	done := make(chan []lineTrade)
+	go func() {
+		var rows []lineTrade
+		for batch := range out {
+			for i := range batch.Trades {
+				rows = append(rows, lineTrade{line: batch.Lines[i], trade: batch.Trades[i]})
+			}
+		}
+		done <- rows
+	}()
+
+	stats := parseSegments(t, path, segments, fromLine, NewBatchPool(64), out)
+	rows := <-done
+	sort.Slice(rows, func(i, j int) bool {
+		return rows[i].line < rows[j].line
+	})
+
+	return rows, stats
+}
+
+func TestSplitFile(t *testing.T) {
+	path := writeTradesFile(t, 500)
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+
+	segments, err := SplitFile(path, 4096)
+	require.NoError(t, err)
+	require.Greater(t, len(segments), 1)
+
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), segments[0].Start)
+	assert.Equal(t, info.Size(), segments[len(segments)-1].End)
+	assert.Equal(t, int64(501), segments[len(segments)-1].LastLine)
+	for i, segment := range segments {
+		assert.Equal(t, i, segment.Index)
+		assert.Equal(t, byte('\n'), content[segment.End-1])
+		if i > 0 {
+			assert.Equal(t, segments[i-1].End, segment.Start)
+			assert.Equal(t, segments[i-1].LastLine+1, segment.FirstLine)
+		}
+	}
+
+	segments, err = SplitFile(path, info.Size())
+	require.NoError(t, err)
+	assert.Equal(t, []Segment{WholeFile()}, segments)
+
+	segments, err = SplitFile("testdata/mock-csv.zip", 1)
+	require.NoError(t, err)
+	assert.Equal(t, []Segment{WholeFile()}, segments)
+}
+
+func TestScanFile(t *testing.T) {
+	path := writeTradesFile(t, 500)
+	wantChecksum, wantSize, err := ComputeChecksum(path)
+	require.NoError(t, err)
+	wantSegments, err := SplitFile(path, 4096)
+	require.NoError(t, err)
+
+	scan, err := ScanFile(path, 4096)
+	require.NoError(t, err)
+	assert.Equal(t, FileScan{Checksum: wantChecksum, Size: wantSize, Segments: wantSegments}, scan)
+
+	scan, err = ScanFile("testdata/mock-csv.zip", 1)
+	require.NoError(t, err)
+	assert.Equal(t, []Segment{WholeFile()}, scan.Segments)
+
+	_, err = ScanFile("testdata/missing.txt", 4096)
+	assert.Error(t, err)
+}
+
+func TestParseSegment(t *testing.T) {
+	path := writeTradesFile(t, 500)
+	segments, err := SplitFile(path, 4096)
+	require.NoError(t, err)
+
+	for _, fromLine := range []int64{0, 250} {
+		want, wantStats := collectSegments(t, path, []Segment{WholeFile()}, fromLine)
+		got, gotStats := collectSegments(t, path, segments, fromLine)
+
+		assert.Equal(t, wantStats, gotStats)
+		require.Len(t, got, len(want))
+		for i := range want {
+			assert.Equal(t, want[i].line, got[i].line)
+			assert.Equal(t, want[i].trade, got[i].trade)
+		}
+	}
+}
+
+func BenchmarkParseSegment(b *testing.B) {
+	path := writeTradesFile(b, 200000)
+	split, err := SplitFile(path, 1<<20)
+	require.NoError(b, err)
+
+	for _, bc := range []struct {
+		name     string
+		segments []Segment
+	}{
+		{name: "sequential", segments: []Segment{WholeFile()}},
+		{name: "split", segments: split},
+	} {
+		b.Run(bc.name, func(b *testing.B) {
+			b.ReportAllocs()
+			pool := NewBatchPool(50000)
+			for range b.N {
+				out := make(chan *Batch, 4)
+				go func() {
+					for batch := range out {
+						pool.Put(batch)
+					}
+				}()
+				parseSegments(b, path, bc.segments, 0, pool, out)
+			}
+		})
+	}
+}
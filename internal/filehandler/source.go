@@ -0,0 +1,242 @@
+package filehandler
+
+import (
+	"b3challenge/internal/domain/entity"
+	"context"
+	"io"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+type TradeFormat string
+
+const (
+	TradeFormatAuto    TradeFormat = ""
+	TradeFormatCSV     TradeFormat = "csv"
+	TradeFormatJSONL   TradeFormat = "jsonl"
+	TradeFormatParquet TradeFormat = "parquet"
+)
+
+const (
+	jsonlExtension   = ".jsonl"
+	ndjsonExtension  = ".ndjson"
+	parquetExtension = ".parquet"
+	autoFormatName   = "auto"
+)
+
+const (
+	sourceReferenceDateField = "reference_date"
+	sourceTickerField        = "ticker"
+	sourceUpdateActionField  = "update_action"
+	sourcePriceField         = "price"
+	sourceQuantityField      = "quantity"
+	sourceTradedAtField      = "traded_at"
+	sourceTradeIDField       = "trade_id"
+	sourceSessionTypeField   = "session_type"
+	sourceDateField          = "date"
+	sourceBuyerCodeField     = "buyer_code"
+	sourceSellerCodeField    = "seller_code"
+)
+
+var ErrUnknownTradeFormat = errors.New("unknown trade format")
+
+//nolint:gochecknoglobals
+var sourceFieldReasons = map[string]RejectReason{
+	sourceReferenceDateField: ReasonInvalidReferenceDate,
+	sourceTickerField:        ReasonInvalidTicker,
+	sourceUpdateActionField:  ReasonInvalidUpdateAction,
+	sourcePriceField:         ReasonInvalidPrice,
+	sourceQuantityField:      ReasonInvalidQuantity,
+	sourceTradedAtField:      ReasonInvalidHour,
+	sourceTradeIDField:       ReasonInvalidTradeID,
+	sourceSessionTypeField:   ReasonInvalidSessionType,
+	sourceDateField:          ReasonInvalidDate,
+	sourceBuyerCodeField:     ReasonInvalidBuyerCode,
+	sourceSellerCodeField:    ReasonInvalidSellerCode,
+}
+
+// TradeSource yields trades record by record. Next advances to the next record and returns its
+// line (or row) number, io.EOF once the input is exhausted, or a reject error for a record that
+// cannot be read. Decode fills trade from the current record and Raw returns it for the rejects
+// output.
+type TradeSource interface {
+	Next() (int64, error)
+	Decode(batch *Batch, trade *entity.Trade) error
+	Raw() []string
+}
+
+func ParseTradeFormat(raw string) (TradeFormat, error) {
+	switch format := TradeFormat(strings.ToLower(strings.TrimSpace(raw))); format {
+	case autoFormatName:
+		return TradeFormatAuto, nil
+	case TradeFormatAuto, TradeFormatCSV, TradeFormatJSONL, TradeFormatParquet:
+		return format, nil
+	default:
+		return TradeFormatAuto, errors.Wrap(ErrUnknownTradeFormat, raw)
+	}
+}
+
+func DetectTradeFormat(name string) TradeFormat {
+	base := strings.ToLower(filepath.Base(name))
+	switch filepath.Ext(strings.TrimSuffix(base, gzExtension)) {
+	case jsonlExtension, ndjsonExtension:
+		return TradeFormatJSONL
+	case parquetExtension:
+		return TradeFormatParquet
+	default:
+		return TradeFormatCSV
+	}
+}
+
+func (f TradeFormat) Resolve(name string) TradeFormat {
+	if f == TradeFormatAuto {
+		return DetectTradeFormat(name)
+	}
+
+	return f
+}
+
+func (f TradeFormat) Splittable() bool {
+	return f == TradeFormatAuto || f == TradeFormatCSV
+}
+
+func ParseTradeSource(
+	ctx context.Context,
+	name string,
+	source TradeSource,
+	fromLine int64,
+	out chan<- *Batch,
+	opts ParseOptions,
+	logger *zap.Logger,
+) (ParseStats, error) {
+	parser := newTradeParser(name, 0, fromLine, out, opts, logger)
+	_, err := parser.parseSource(ctx, source, 0)
+
+	return parser.finish(ctx, err)
+}
+
+func (p *tradeParser) parseSource(ctx context.Context, source TradeSource, lastLine int64) (int64, error) {
+	for {
+		if err := ctx.Err(); err != nil {
+			p.logger.Info("Context cancelled, stopping file parsing")
+
+			return lastLine, errors.Wrap(err, "context cancelled")
+		}
+
+		line, err := source.Next()
+		if errors.Is(err, io.EOF) {
+			return lastLine, nil
+		}
+		if err != nil && !isRejectError(err) {
+			return lastLine, err
+		}
+
+		lastLine = line
+		if line <= p.fromLine {
+			continue
+		}
+
+		if err != nil {
+			p.reject(line, source.Raw(), "reading record: ", err)
+
+			continue
+		}
+
+		batch, err := p.currentBatch(ctx)
+		if err != nil {
+			return lastLine, err
+		}
+		trade := batch.next(line)
+		if err := source.Decode(batch, trade); err != nil {
+			batch.discardLast()
+			p.reject(line, source.Raw(), "parsing trade: ", err)
+
+			continue
+		}
+		if !p.opts.Dates.Contains(trade.Date) {
+			batch.discardLast()
+			p.stats.Filtered++
+
+			continue
+		}
+		if keep, err := p.validate(line, source, trade); !keep {
+			batch.discardLast()
+			if err != nil {
+				return lastLine, err
+			}
+
+			continue
+		}
+		p.stats.Parsed++
+
+		if batch.Len() >= p.opts.Pool.Size() {
+			if err := p.flush(ctx); err != nil {
+				return lastLine, err
+			}
+		}
+	}
+}
+
+func (p *tradeParser) reject(line int64, rec []string, msg string, err error) {
+	p.logger.Error(msg, zap.String("file", p.filePath), zap.Int64("line", line), zap.Error(err))
+	p.stats.Rejected++
+	writeReject(p.opts.Rejects, p.filePath, line, rec, ReasonOf(err), err, p.logger)
+}
+
+func (p *tradeParser) validate(line int64, source TradeSource, trade *entity.Trade) (bool, error) {
+	p.violations = p.opts.Validator.Check(trade, p.violations[:0])
+
+	var rejected *Violation
+	for i := range p.violations {
+		violation := &p.violations[i]
+		switch violation.Action {
+		case RuleActionAbort:
+			return false, errors.Wrapf(ErrValidationAborted, "line %d: %s: %s", line, violation.Reason, violation.Err)
+		case RuleActionReject:
+			if rejected == nil {
+				rejected = violation
+			}
+		default:
+			p.logger.Warn(
+				"Trade failed validation",
+				zap.String("file", p.filePath),
+				zap.Int64("line", line),
+				zap.String("rule", string(violation.Reason)),
+				zap.Error(violation.Err),
+			)
+		}
+	}
+	if rejected != nil {
+		p.reject(line, source.Raw(), "validating trade: ", newRejectError(rejected.Reason, rejected.Err))
+
+		return false, nil
+	}
+
+	return true, nil
+}
+
+func newFieldError(field string, err error) error {
+	return newRejectError(sourceFieldReasons[field], errors.Wrapf(err, "parsing %s", field))
+}
+
+func parseSourceTime(raw string) (time.Time, error) {
+	if tradedAt, err := time.Parse(time.RFC3339Nano, raw); err == nil {
+		return tradedAt.In(entity.B3Location), nil
+	}
+
+	tradedAt, err := time.ParseInLocation("2006-01-02T15:04:05.999999999", raw, entity.B3Location)
+
+	return tradedAt, errors.Wrap(err, "parse time")
+}
+
+func checkIntRange(value, minValue, maxValue int64) error {
+	if value < minValue || value > maxValue {
+		return errors.Errorf("value %d out of range", value)
+	}
+
+	return nil
+}
@@ -0,0 +1,229 @@
+package filehandler
+
+import (
+	"b3challenge/internal/domain/entity"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/AlekSi/pointer"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+const jsonlTradesFile = "testdata/sources/trades.jsonl"
+
+func sourceTrades() []entity.Trade {
+	day := func(d int) time.Time {
+		return time.Date(2025, 6, d, 0, 0, 0, 0, time.UTC)
+	}
+	at := func(d, hour, minute, second, millis int) time.Time {
+		return time.Date(2025, 6, d, hour, minute, second, millis*int(time.Millisecond), entity.B3Location)
+	}
+
+	return []entity.Trade{
+		{
+			ReferenceDate: day(2), Ticker: "PETR4", UpdateAction: 0, Price: decimal.New(32150, -3), Quantity: 100,
+			TradedAt: at(2, 10, 0, 1, 123), TradeID: 10, SessionType: 1, Date: day(2),
+			BuyerCode: pointer.ToInt32(3), SellerCode: pointer.ToInt32(8),
+		},
+		{
+			ReferenceDate: day(2), Ticker: "VALE3", UpdateAction: 0, Price: decimal.New(55000, -3), Quantity: 200,
+			TradedAt: at(2, 10, 5, 0, 0), TradeID: 11, SessionType: 1, Date: day(2),
+			BuyerCode: nil, SellerCode: pointer.ToInt32(72),
+		},
+		{
+			ReferenceDate: day(2), Ticker: "PETR4", UpdateAction: 0, Price: decimal.New(32200, -3), Quantity: 300,
+			TradedAt: at(2, 10, 10, 0, 500), TradeID: 12, SessionType: 1, Date: day(2),
+			BuyerCode: pointer.ToInt32(3), SellerCode: pointer.ToInt32(8),
+		},
+		{
+			ReferenceDate: day(3), Ticker: "PETR4", UpdateAction: 2, Price: decimal.New(33000, -3), Quantity: 50,
+			TradedAt: at(3, 17, 59, 59, 999), TradeID: 14, SessionType: 1, Date: day(3),
+			BuyerCode: nil, SellerCode: pointer.ToInt32(8),
+		},
+	}
+}
+
+func TestDetectTradeFormat(t *testing.T) {
+	tests := []struct {
+		name string
+		want TradeFormat
+	}{
+		{name: "b3Data/02-06-2025_NEGOCIOSAVISTA.txt", want: TradeFormatCSV},
+		{name: "b3Data/02-06-2025_NEGOCIOSAVISTA.zip", want: TradeFormatCSV},
+		{name: "exports/trades.jsonl", want: TradeFormatJSONL},
+		{name: "exports/trades.NDJSON.gz", want: TradeFormatJSONL},
+		{name: "exports/trades.parquet", want: TradeFormatParquet},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, DetectTradeFormat(tt.name))
+			assert.True(t, IsSupportedFile(tt.name))
+		})
+	}
+
+	assert.Equal(t, TradeFormatParquet, TradeFormatParquet.Resolve("trades.txt"))
+	assert.Equal(t, TradeFormatJSONL, TradeFormatAuto.Resolve("trades.jsonl"))
+	assert.False(t, TradeFormatJSONL.Splittable())
+}
+
+func TestParseTradeFormat(t *testing.T) {
+	tests := []struct {
+		raw     string
+		want    TradeFormat
+		wantErr bool
+	}{
+		{raw: "", want: TradeFormatAuto},
+		{raw: "auto", want: TradeFormatAuto},
+		{raw: "CSV", want: TradeFormatCSV},
+		{raw: "jsonl", want: TradeFormatJSONL},
+		{raw: "parquet", want: TradeFormatParquet},
+		{raw: "avro", want: TradeFormatAuto, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.raw, func(t *testing.T) {
+			got, err := ParseTradeFormat(tt.raw)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrUnknownTradeFormat)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestParseSegment_Sources(t *testing.T) {
+	gzipped := filepath.Join(t.TempDir(), "trades.jsonl.gz")
+	raw, err := os.ReadFile(jsonlTradesFile)
+	require.NoError(t, err)
+	var buf bytes.Buffer
+	writer := gzip.NewWriter(&buf)
+	_, err = writer.Write(raw)
+	require.NoError(t, err)
+	require.NoError(t, writer.Close())
+	require.NoError(t, os.WriteFile(gzipped, buf.Bytes(), 0o600))
+	parquetFile := writeParquetTrades(t, parquetTestFile{pageVersion: 0, codec: nil, columns: nil})
+
+	jsonlRejects := map[RejectReason]int64{ReasonInvalidJSON: 1, ReasonInvalidTicker: 1, ReasonInvalidQuantity: 1}
+	tests := []struct {
+		name        string
+		file        string
+		wantStats   ParseStats
+		wantLines   []int64
+		wantRejects map[RejectReason]int64
+	}{
+		{
+			name:        "json lines",
+			file:        jsonlTradesFile,
+			wantStats:   ParseStats{Parsed: 4, Rejected: 3, Filtered: 0},
+			wantLines:   []int64{1, 2, 4, 8},
+			wantRejects: jsonlRejects,
+		},
+		{
+			name:        "gzipped json lines",
+			file:        gzipped,
+			wantStats:   ParseStats{Parsed: 4, Rejected: 3, Filtered: 0},
+			wantLines:   []int64{1, 2, 4, 8},
+			wantRejects: jsonlRejects,
+		},
+		{
+			name:        "parquet",
+			file:        parquetFile,
+			wantStats:   ParseStats{Parsed: 4, Rejected: 1, Filtered: 0},
+			wantLines:   []int64{1, 2, 3, 5},
+			wantRejects: map[RejectReason]int64{ReasonInvalidTicker: 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rejects := newTestRejectWriter(t)
+			out := make(chan *Batch, 10)
+			stats, err := ParseSegment(
+				context.Background(), tt.file, WholeFile(), 0, out, newTestParseOptions(3, rejects), zap.NewNop(),
+			)
+			require.NoError(t, err)
+
+			lines, trades, _ := drainBatches(out)
+			assert.Equal(t, tt.wantStats, stats)
+			assert.Equal(t, tt.wantLines, lines)
+			assert.Equal(t, tt.wantRejects, rejects.Counts())
+			assert.Equal(t, sourceTrades(), trades)
+		})
+	}
+}
+
+func TestParseSegment_SourcesFromLineAndDates(t *testing.T) {
+	parquetFile := writeParquetTrades(t, parquetTestFile{pageVersion: 0, codec: nil, columns: nil})
+	for _, file := range []string{jsonlTradesFile, parquetFile} {
+		t.Run(filepath.Ext(file), func(t *testing.T) {
+			opts := newTestParseOptions(10, newTestRejectWriter(t))
+			opts.Dates = DateRange{From: time.Date(2025, 6, 3, 0, 0, 0, 0, time.UTC), To: time.Time{}}
+			out := make(chan *Batch, 10)
+			stats, err := ParseSegment(context.Background(), file, WholeFile(), 1, out, opts, zap.NewNop())
+			require.NoError(t, err)
+
+			_, trades, _ := drainBatches(out)
+			assert.Equal(t, int64(2), stats.Filtered)
+			assert.Equal(t, sourceTrades()[3:], trades)
+		})
+	}
+}
+
+func TestParseSegment_FormatOverride(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "trades.txt")
+	raw, err := os.ReadFile(jsonlTradesFile)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(path, raw, 0o600))
+
+	opts := newTestParseOptions(10, newTestRejectWriter(t))
+	opts.Format = TradeFormatJSONL
+	out := make(chan *Batch, 10)
+	stats, err := ParseSegment(context.Background(), path, WholeFile(), 0, out, opts, zap.NewNop())
+	require.NoError(t, err)
+
+	_, trades, _ := drainBatches(out)
+	assert.Equal(t, int64(4), stats.Parsed)
+	assert.Equal(t, sourceTrades(), trades)
+}
+
+func TestParseTradeSource(t *testing.T) {
+	stream := strings.NewReader(`{"reference_date":"2025-06-02","ticker":"PETR4","update_action":0,"price":32.150,` +
+		`"quantity":100,"traded_at":"2025-06-02T10:00:01.123-03:00","trade_id":10,"session_type":1,` +
+		`"date":"2025-06-02","buyer_code":3,"seller_code":8}`)
+
+	out := make(chan *Batch, 1)
+	stats, err := ParseTradeSource(
+		context.Background(), "stdin", NewJSONLTradeSource(stream, 0), 0, out,
+		newTestParseOptions(10, newTestRejectWriter(t)), zap.NewNop(),
+	)
+	require.NoError(t, err)
+
+	_, trades, batches := drainBatches(out)
+	assert.Equal(t, ParseStats{Parsed: 1, Rejected: 0, Filtered: 0}, stats)
+	assert.Equal(t, sourceTrades()[:1], trades)
+	assert.Equal(t, "stdin", batches[0].Source)
+}
+
+func TestParquetTradeSource_Invalid(t *testing.T) {
+	dir := t.TempDir()
+	notParquet := filepath.Join(dir, "trades.parquet")
+	require.NoError(t, os.WriteFile(notParquet, []byte("reference_date;ticker\n"), 0o600))
+
+	_, err := ParseParquetFile(
+		context.Background(), notParquet, 0, make(chan *Batch, 1),
+		newTestParseOptions(10, newTestRejectWriter(t)), zap.NewNop(),
+	)
+	assert.ErrorIs(t, err, errParquetFormat)
+}
@@ -0,0 +1,177 @@
+package filehandler
+
+import (
+	"b3challenge/internal/domain/entity"
+	"maps"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+type RuleAction string
+
+const (
+	RuleActionOff    RuleAction = "off"
+	RuleActionWarn   RuleAction = "warn"
+	RuleActionReject RuleAction = "reject"
+	RuleActionAbort  RuleAction = "abort"
+)
+
+const (
+	minTickerLength = 4
+	maxTickerLength = 12
+)
+
+var (
+	ErrInvalidValidationRule = errors.New("invalid validation rule")
+	ErrValidationAborted     = errors.New("validation rule aborted the file")
+)
+
+type validationRule struct {
+	reason RejectReason
+	check  func(trade *entity.Trade) error
+}
+
+//nolint:gochecknoglobals
+var validationRules = []validationRule{
+	{reason: ReasonNonPositivePrice, check: checkPositivePrice},
+	{reason: ReasonNonPositiveQuantity, check: checkQuantity},
+	{reason: ReasonMalformedTicker, check: checkTicker},
+	{reason: ReasonDateMismatch, check: checkReferenceDate},
+}
+
+type Violation struct {
+	Reason RejectReason
+	Action RuleAction
+	Err    error
+}
+
+// Validator applies the semantic trade rules that parsing alone does not catch. Each rule is
+// configured to warn (the row is kept), reject (the row goes to the rejects output) or abort the
+// file. It is shared by the parser workers and counts violations per rule.
+type Validator struct {
+	actions map[RejectReason]RuleAction
+	mu      sync.Mutex
+	counts  map[RejectReason]int64
+}
+
+func DefaultRuleActions() map[RejectReason]RuleAction {
+	return map[RejectReason]RuleAction{
+		ReasonNonPositivePrice:    RuleActionReject,
+		ReasonNonPositiveQuantity: RuleActionReject,
+		ReasonMalformedTicker:     RuleActionReject,
+		ReasonDateMismatch:        RuleActionWarn,
+	}
+}
+
+// ParseRuleActions reads overrides such as "non_positive_quantity=warn,date_mismatch=abort" on top of
+// DefaultRuleActions.
+func ParseRuleActions(raw string) (map[RejectReason]RuleAction, error) {
+	actions := DefaultRuleActions()
+	for _, entry := range strings.Split(raw, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		name, value, ok := strings.Cut(entry, "=")
+		reason := RejectReason(strings.TrimSpace(name))
+		action := RuleAction(strings.ToLower(strings.TrimSpace(value)))
+		if _, known := actions[reason]; !ok || !known {
+			return nil, errors.Wrap(ErrInvalidValidationRule, entry)
+		}
+		switch action {
+		case RuleActionOff, RuleActionWarn, RuleActionReject, RuleActionAbort:
+			actions[reason] = action
+		default:
+			return nil, errors.Wrap(ErrInvalidValidationRule, entry)
+		}
+	}
+
+	return actions, nil
+}
+
+func NewValidator(actions map[RejectReason]RuleAction) *Validator {
+	return &Validator{
+		actions: maps.Clone(actions),
+		mu:      sync.Mutex{},
+		counts:  make(map[RejectReason]int64),
+	}
+}
+
+// Check appends the rules trade breaks to violations, skipping the ones turned off.
+func (v *Validator) Check(trade *entity.Trade, violations []Violation) []Violation {
+	if v == nil {
+		return violations
+	}
+
+	for _, rule := range validationRules {
+		action := v.actions[rule.reason]
+		if action == RuleActionOff || action == "" {
+			continue
+		}
+		if err := rule.check(trade); err != nil {
+			violations = append(violations, Violation{Reason: rule.reason, Action: action, Err: err})
+		}
+	}
+	if len(violations) > 0 {
+		v.mu.Lock()
+		for _, violation := range violations {
+			v.counts[violation.Reason]++
+		}
+		v.mu.Unlock()
+	}
+
+	return violations
+}
+
+func (v *Validator) Counts() map[RejectReason]int64 {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	return maps.Clone(v.counts)
+}
+
+func checkPositivePrice(trade *entity.Trade) error {
+	if !trade.Price.IsPositive() {
+		return errors.Errorf("price %s is not positive", trade.Price)
+	}
+
+	return nil
+}
+
+func checkQuantity(trade *entity.Trade) error {
+	if trade.Quantity <= 0 {
+		return errors.Errorf("quantity %d is not positive", trade.Quantity)
+	}
+
+	return nil
+}
+
+func checkTicker(trade *entity.Trade) error {
+	if len(trade.Ticker) < minTickerLength || len(trade.Ticker) > maxTickerLength {
+		return errors.Errorf(
+			"ticker %q must have between %d and %d characters", trade.Ticker, minTickerLength, maxTickerLength,
+		)
+	}
+	for _, c := range []byte(trade.Ticker) {
+		if (c < 'A' || c > 'Z') && (c < '0' || c > '9') {
+			return errors.Errorf("ticker %q must only contain uppercase letters and digits", trade.Ticker)
+		}
+	}
+
+	return nil
+}
+
+func checkReferenceDate(trade *entity.Trade) error {
+	if !trade.Date.Equal(trade.ReferenceDate) {
+		return errors.Errorf(
+			"trade date %s differs from reference date %s",
+			trade.Date.Format(time.DateOnly), trade.ReferenceDate.Format(time.DateOnly),
+		)
+	}
+
+	return nil
+}
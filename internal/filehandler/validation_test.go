@@ -0,0 +1,135 @@
+package filehandler
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+const validationCSV = "DataReferencia;CodigoInstrumento;AcaoAtualizacao;PrecoNegocio;QuantidadeNegociada;" +
+	"HoraFechamento;CodigoIdentificadorNegocio;TipoSessaoPregao;DataNegocio;" +
+	"CodigoParticipanteComprador;CodigoParticipanteVendedor\n" +
+	"2025-06-02;PETR4;0;32,150;100;100001123;10;1;2025-06-02;3;8\n" +
+	"2025-06-02;PETR4;0;0,000;100;100002000;11;1;2025-06-02;3;8\n" +
+	"2025-06-02;VALE3;0;55,000;0;100003000;12;1;2025-06-02;3;8\n" +
+	"2025-06-02;vale 3;0;55,000;10;100004000;13;1;2025-06-02;3;8\n" +
+	"2025-06-02;PETR4;0;32,200;300;100005000;14;1;2025-06-03;3;8\n"
+
+func TestParseRuleActions(t *testing.T) {
+	tests := []struct {
+		name    string
+		raw     string
+		want    map[RejectReason]RuleAction
+		wantErr bool
+	}{
+		{name: "defaults", raw: "", want: DefaultRuleActions()},
+		{
+			name: "overrides",
+			raw:  " non_positive_quantity=WARN, date_mismatch=abort,malformed_ticker=off",
+			want: map[RejectReason]RuleAction{
+				ReasonNonPositivePrice:    RuleActionReject,
+				ReasonNonPositiveQuantity: RuleActionWarn,
+				ReasonMalformedTicker:     RuleActionOff,
+				ReasonDateMismatch:        RuleActionAbort,
+			},
+		},
+		{name: "unknown rule", raw: "negative_volume=reject", wantErr: true},
+		{name: "unknown action", raw: "date_mismatch=ignore", wantErr: true},
+		{name: "missing action", raw: "date_mismatch", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseRuleActions(tt.raw)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrInvalidValidationRule)
+
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestParseSegment_Validation(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "trades.txt")
+	require.NoError(t, os.WriteFile(path, []byte(validationCSV), 0o600))
+
+	allViolations := map[RejectReason]int64{
+		ReasonNonPositivePrice: 1, ReasonNonPositiveQuantity: 1, ReasonMalformedTicker: 1, ReasonDateMismatch: 1,
+	}
+	tests := []struct {
+		name           string
+		rules          string
+		wantStats      ParseStats
+		wantLines      []int64
+		wantRejects    map[RejectReason]int64
+		wantViolations map[RejectReason]int64
+		wantErr        error
+	}{
+		{
+			name:      "defaults",
+			wantStats: ParseStats{Parsed: 2, Rejected: 3, Filtered: 0},
+			wantLines: []int64{2, 6},
+			wantRejects: map[RejectReason]int64{
+				ReasonNonPositivePrice: 1, ReasonNonPositiveQuantity: 1, ReasonMalformedTicker: 1,
+			},
+			wantViolations: allViolations,
+		},
+		{
+			name:           "warn only",
+			rules:          "non_positive_price=warn,non_positive_quantity=warn,malformed_ticker=warn",
+			wantStats:      ParseStats{Parsed: 5, Rejected: 0, Filtered: 0},
+			wantLines:      []int64{2, 3, 4, 5, 6},
+			wantRejects:    map[RejectReason]int64{},
+			wantViolations: allViolations,
+		},
+		{
+			name:           "rules off",
+			rules:          "non_positive_price=off,non_positive_quantity=off,malformed_ticker=off,date_mismatch=off",
+			wantStats:      ParseStats{Parsed: 5, Rejected: 0, Filtered: 0},
+			wantLines:      []int64{2, 3, 4, 5, 6},
+			wantRejects:    map[RejectReason]int64{},
+			wantViolations: map[RejectReason]int64{},
+		},
+		{
+			name:           "abort",
+			rules:          "non_positive_quantity=abort",
+			wantStats:      ParseStats{Parsed: 1, Rejected: 1, Filtered: 0},
+			wantLines:      nil,
+			wantRejects:    map[RejectReason]int64{ReasonNonPositivePrice: 1},
+			wantViolations: map[RejectReason]int64{ReasonNonPositivePrice: 1, ReasonNonPositiveQuantity: 1},
+			wantErr:        ErrValidationAborted,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actions, err := ParseRuleActions(tt.rules)
+			require.NoError(t, err)
+
+			rejects := newTestRejectWriter(t)
+			opts := newTestParseOptions(10, rejects)
+			opts.Validator = NewValidator(actions)
+			out := make(chan *Batch, 10)
+			stats, err := ParseSegment(context.Background(), path, WholeFile(), 0, out, opts, zap.NewNop())
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			lines, _, _ := drainBatches(out)
+			assert.Equal(t, tt.wantStats, stats)
+			assert.Equal(t, tt.wantLines, lines)
+			assert.Equal(t, tt.wantRejects, rejects.Counts())
+			assert.Equal(t, tt.wantViolations, opts.Validator.Counts())
+		})
+	}
+}